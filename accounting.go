@@ -0,0 +1,99 @@
+package gokzg4844
+
+import (
+	"context"
+	"time"
+)
+
+// Usage describes a single call to one of the methods of a [Context] which takes a [context.Context].
+//
+// It is passed to the hook configured with [WithAccounting].
+type Usage struct {
+	// Tenant is the tag attached to the context of the call with [WithTenant],
+	// or the empty string if there is none.
+	Tenant string
+	// Op is the name of the operation, for example "blob_to_kzg_commitment".
+	// The names match the ones used by the gokzg_profiling build tag.
+	Op string
+	// Items is the number of blobs or proofs processed by the call.
+	Items int
+	// Bytes is the number of bytes in the serialized inputs of the call.
+	Bytes int
+	// Duration is the wall-clock time that the call took.
+	//
+	// Go does not expose the CPU time of individual go-routines, so calls which use more than one go-routine
+	// consume more CPU time than this. Callers who need to bill by CPU time should scale this by the amount of
+	// concurrency that they configured.
+	Duration time.Duration
+}
+
+// AccountingHook is called with the [Usage] of each call once the call has finished, whether or not it succeeded.
+//
+// The hook is called synchronously by the go-routine which made the call, so it should return quickly. It must be
+// safe for concurrent use if the [Context] is used from multiple go-routines.
+type AccountingHook func(Usage)
+
+// WithAccounting sets a hook which is called after each call to one of the methods of the [Context] which take a
+// [context.Context], such as [Context.BlobToKZGCommitmentCtx] or [Context.VerifyBlobKZGProofBatchCtx].
+//
+// Together with [WithTenant], this lets a [Context] which is shared by many callers attribute its usage to each of
+// them, for example to bill or to rate limit them. The methods without a [context.Context] argument are counted
+// with an empty tenant when they are implemented on top of the variant which takes one. Each call is counted once:
+// methods which verify proofs as part of a larger operation, such as [Context.VerifyCellsWithBlobProof], do not
+// report the proofs that they verify.
+func WithAccounting(hook AccountingHook) Option {
+	return func(cfg *config) {
+		cfg.accounting = hook
+	}
+}
+
+// tenantKey is the key under which [WithTenant] stores the tenant in a [context.Context].
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx which is tagged with the tenant. The tag is reported in the [Usage] of calls
+// which are made with the returned context.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant that ctx was tagged with by [WithTenant], or the empty string if it has
+// not been tagged.
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+// accountant records the usage of a single call. Its zero value, which is used when no hook is configured, does
+// nothing.
+type accountant struct {
+	hook  AccountingHook
+	usage Usage
+	start time.Time
+}
+
+// startAccounting starts recording the usage of a call. The returned accountant must be ended with
+// [accountant.end] once the call has finished.
+func (c *Context) startAccounting(ctx context.Context, op string, items, bytes int) accountant {
+	if c.accounting == nil {
+		return accountant{}
+	}
+	return accountant{
+		hook: c.accounting,
+		usage: Usage{
+			Tenant: TenantFromContext(ctx),
+			Op:     op,
+			Items:  items,
+			Bytes:  bytes,
+		},
+		start: time.Now(),
+	}
+}
+
+// end reports the usage of the call to the hook.
+func (a accountant) end() {
+	if a.hook == nil {
+		return
+	}
+	a.usage.Duration = time.Since(a.start)
+	a.hook(a.usage)
+}
@@ -0,0 +1,93 @@
+package gokzg4844_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccounting(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		usages []gokzg4844.Usage
+	)
+	ctxAccounting, err := gokzg4844.NewContext4096Secure(gokzg4844.WithAccounting(func(usage gokzg4844.Usage) {
+		mu.Lock()
+		defer mu.Unlock()
+		usages = append(usages, usage)
+	}))
+	require.NoError(t, err)
+
+	blobs := []gokzg4844.Blob{*GetRandBlob(1), *GetRandBlob(2)}
+	tenantCtx := gokzg4844.WithTenant(context.Background(), "alice")
+	require.Equal(t, "alice", gokzg4844.TenantFromContext(tenantCtx))
+
+	commitments, err := ctxAccounting.BlobsToKZGCommitmentsCtx(tenantCtx, blobs, NumGoRoutines)
+	require.NoError(t, err)
+	proofs, err := ctxAccounting.ComputeBlobKZGProofs(blobs, commitments, NumGoRoutines)
+	require.NoError(t, err)
+	err = ctxAccounting.VerifyBlobKZGProofCtx(gokzg4844.WithTenant(context.Background(), "bob"), &blobs[0], commitments[0], proofs[0])
+	require.NoError(t, err)
+
+	require.Len(t, usages, 3)
+
+	require.Equal(t, "alice", usages[0].Tenant)
+	require.Equal(t, "blobs_to_kzg_commitments", usages[0].Op)
+	require.Equal(t, 2, usages[0].Items)
+	require.Equal(t, 2*len(gokzg4844.Blob{}), usages[0].Bytes)
+	require.Positive(t, usages[0].Duration)
+
+	require.Equal(t, "", usages[1].Tenant)
+	require.Equal(t, "compute_blob_kzg_proofs", usages[1].Op)
+
+	require.Equal(t, "bob", usages[2].Tenant)
+	require.Equal(t, "verify_blob_kzg_proof", usages[2].Op)
+	require.Equal(t, 1, usages[2].Items)
+
+	// Methods which verify proofs as part of a larger operation do not count them on their own
+	usages = nil
+	require.NoError(t, ctxAccounting.VerifyBlobKZGProofBatchPar(blobs, commitments, proofs))
+	cells, err := ctxAccounting.ComputeCells(&blobs[0])
+	require.NoError(t, err)
+	indices := make([]gokzg4844.CellIndex, gokzg4844.CellsPerExtBlob)
+	for i := range indices {
+		indices[i] = gokzg4844.CellIndex(i)
+	}
+	require.NoError(t, ctxAccounting.VerifyCellsWithBlobProof(commitments[0], cells[:], indices, proofs[0]))
+	require.Empty(t, usages)
+
+	_, err = ctxAccounting.VerifyBlobKZGProofWithReceipt(&blobs[0], commitments[0], proofs[0])
+	require.NoError(t, err)
+	require.Len(t, usages, 1)
+	require.Equal(t, "verify_blob_kzg_proof", usages[0].Op)
+}
+
+// A call to a closed context is not counted
+func TestAccountingClosed(t *testing.T) {
+	var usages []gokzg4844.Usage
+	closedCtx, err := gokzg4844.NewContext4096Secure(gokzg4844.WithAccounting(func(usage gokzg4844.Usage) {
+		usages = append(usages, usage)
+	}))
+	require.NoError(t, err)
+	require.NoError(t, closedCtx.Close())
+
+	err = closedCtx.VerifyKZGProofCtx(context.Background(), gokzg4844.KZGCommitment{}, gokzg4844.Scalar{}, gokzg4844.Scalar{}, gokzg4844.KZGProof{})
+	require.ErrorIs(t, err, gokzg4844.ErrContextClosed)
+	err = closedCtx.VerifyBlobKZGProof(GetRandBlob(1), gokzg4844.KZGCommitment{}, gokzg4844.KZGProof{})
+	require.ErrorIs(t, err, gokzg4844.ErrContextClosed)
+
+	// The batch methods, which check the context further down, must also check it before recording their usage
+	blobs := []gokzg4844.Blob{*GetRandBlob(2)}
+	_, err = closedCtx.BlobsToKZGCommitments(blobs, NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrContextClosed)
+	_, err = closedCtx.ComputeBlobKZGProofs(blobs, []gokzg4844.KZGCommitment{{}}, NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrContextClosed)
+	_, err = closedCtx.BuildSidecars(blobs, NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrContextClosed)
+	err = closedCtx.VerifyBlobKZGProofBatch(blobs, []gokzg4844.KZGCommitment{{}}, []gokzg4844.KZGProof{{}})
+	require.ErrorIs(t, err, gokzg4844.ErrContextClosed)
+	require.Empty(t, usages)
+}
@@ -0,0 +1,194 @@
+package gokzg4844
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// AccumulatingVerifier collects KZG proofs over time and verifies all of them at once.
+//
+// Each call to [AccumulatingVerifier.Add] deserializes and checks its inputs straight away, and only keeps the
+// resulting points and scalars; no blobs are held in memory. [AccumulatingVerifier.Finalize] then folds all of the
+// proofs using randomness and checks them with a single pairing check, as done by [Context.VerifyBlobKZGProofBatch].
+//
+// By default, a single invalid claim makes the whole accumulation fail. With [AccumulatingVerifier.SetQuarantine],
+// the invalid claims are instead handed to a callback and the valid ones are accepted, so that one peer sending
+// invalid proofs cannot stall the verification of everyone else's.
+//
+// An AccumulatingVerifier is safe for concurrent use.
+type AccumulatingVerifier struct {
+	ctx *Context
+
+	mu            sync.Mutex
+	quarantine    func(QuarantinedClaim)
+	commitments   []kzg.Commitment
+	openingProofs []kzg.OpeningProof
+	tags          []any
+	// The serialized commitments and proofs are only needed when the
+	// context derives the batch challenge from a transcript.
+	serCommitments []KZGCommitment
+	serProofs      []KZGProof
+}
+
+// NewAccumulatingVerifier returns an empty [AccumulatingVerifier] which uses this context to verify proofs.
+func (c *Context) NewAccumulatingVerifier() *AccumulatingVerifier {
+	return &AccumulatingVerifier{ctx: c}
+}
+
+// QuarantinedClaim is a claim which failed to verify in [AccumulatingVerifier.Finalize], as passed to the callback
+// set with [AccumulatingVerifier.SetQuarantine].
+type QuarantinedClaim struct {
+	// Index is the position of the claim among those added since the verifier was created or last finalized.
+	Index int
+	// Tag is the tag that the claim was added with by [AccumulatingVerifier.AddTagged], for example the peer which
+	// sent it, or nil if it was added by [AccumulatingVerifier.Add].
+	Tag any
+
+	Commitment   KZGCommitment
+	Proof        KZGProof
+	InputPoint   Scalar
+	ClaimedValue Scalar
+}
+
+// SetQuarantine sets a callback which [AccumulatingVerifier.Finalize] calls with each claim that fails to verify,
+// instead of failing. Setting it to nil restores the default.
+//
+// The invalid claims are found by bisecting the failed batch, which costs a number of extra batch verifications that
+// grows with the number of invalid claims times the logarithm of the size of the batch. The callback is called
+// synchronously, in the order in which the claims were added, and must not call the methods of the verifier.
+func (v *AccumulatingVerifier) SetQuarantine(quarantine func(QuarantinedClaim)) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.quarantine = quarantine
+}
+
+// Add adds a claim that the polynomial committed to by blobCommitment evaluates to claimedValueBytes at
+// inputPointBytes, with kzgProof as the proof. These are the same arguments as for [Context.VerifyKZGProof].
+//
+// Returns an error if any of the inputs cannot be deserialized, in which case the claim is not added.
+func (v *AccumulatingVerifier) Add(blobCommitment KZGCommitment, kzgProof KZGProof, inputPointBytes, claimedValueBytes Scalar) error {
+	return v.AddTagged(nil, blobCommitment, kzgProof, inputPointBytes, claimedValueBytes)
+}
+
+// AddTagged is the same as [AccumulatingVerifier.Add], except that the claim is tagged, so that it can be told apart
+// if it is quarantined. See [QuarantinedClaim].
+func (v *AccumulatingVerifier) AddTagged(tag any, blobCommitment KZGCommitment, kzgProof KZGProof, inputPointBytes, claimedValueBytes Scalar) error {
+	// 1. Deserialization
+	//
+	claimedValue, err := DeserializeScalar(claimedValueBytes)
+	if err != nil {
+		return err
+	}
+
+	inputPoint, err := DeserializeScalar(inputPointBytes)
+	if err != nil {
+		return err
+	}
+
+	polynomialCommitment, err := DeserializeKZGCommitment(blobCommitment)
+	if err != nil {
+		return err
+	}
+
+	quotientCommitment, err := DeserializeKZGProof(kzgProof)
+	if err != nil {
+		return err
+	}
+
+	// 2. Accumulate opening proof
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.commitments = append(v.commitments, polynomialCommitment)
+	v.openingProofs = append(v.openingProofs, kzg.OpeningProof{
+		QuotientCommitment: quotientCommitment,
+		InputPoint:         inputPoint,
+		ClaimedValue:       claimedValue,
+	})
+	v.tags = append(v.tags, tag)
+	if v.ctx.fiatShamirBatch {
+		v.serCommitments = append(v.serCommitments, blobCommitment)
+		v.serProofs = append(v.serProofs, kzgProof)
+	}
+
+	return nil
+}
+
+// Len returns the number of claims that have been added since the verifier was created or last finalized.
+func (v *AccumulatingVerifier) Len() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return len(v.commitments)
+}
+
+// Finalize verifies all of the claims that have been added, and returns nil if all of them are valid.
+//
+// If there are no claims, this returns nil. If a quarantine callback is set, the claims which fail to verify are
+// passed to it and this returns nil, since all of the other claims are valid. The verifier is emptied, so that it can
+// be used to accumulate a new batch of claims.
+func (v *AccumulatingVerifier) Finalize() error {
+	if err := v.ctx.checkOpen(); err != nil {
+		return err
+	}
+	v.mu.Lock()
+	quarantine := v.quarantine
+	commitments, openingProofs, tags := v.commitments, v.openingProofs, v.tags
+	serCommitments, serProofs := v.serCommitments, v.serProofs
+	v.commitments, v.openingProofs, v.tags = nil, nil, nil
+	v.serCommitments, v.serProofs = nil, nil
+	v.mu.Unlock()
+
+	prof := startProfile(context.Background(), "accumulating_verifier_finalize", len(commitments))
+	defer prof.end()
+
+	err := v.ctx.batchVerify(commitments, openingProofs, serCommitments, serProofs)
+	if quarantine == nil || !errors.Is(err, kzg.ErrVerifyOpeningProof) {
+		return err
+	}
+
+	prof.phase("bisect")
+	for _, i := range v.ctx.findInvalidProofs(commitments, openingProofs, serCommitments, serProofs) {
+		quarantine(QuarantinedClaim{
+			Index:        i,
+			Tag:          tags[i],
+			Commitment:   KZGCommitment(SerializeG1Point(commitments[i])),
+			Proof:        KZGProof(SerializeG1Point(openingProofs[i].QuotientCommitment)),
+			InputPoint:   SerializeScalar(openingProofs[i].InputPoint),
+			ClaimedValue: SerializeScalar(openingProofs[i].ClaimedValue),
+		})
+	}
+	return nil
+}
+
+// findInvalidProofs returns the indices, in increasing order, of all of the invalid proofs in a batch which failed to
+// verify. Like [Context.findInvalidProof], it bisects the batch, but it searches both halves of each range which
+// fails. The serialized commitments and proofs may be nil if the context does not need them.
+func (c *Context) findInvalidProofs(commitments []kzg.Commitment, openingProofs []kzg.OpeningProof, serCommitments []KZGCommitment, serProofs []KZGProof) []int {
+	var invalid []int
+	var search func(start, end int)
+	search = func(start, end int) {
+		if end-start == 1 {
+			invalid = append(invalid, start)
+			return
+		}
+		mid := start + (end-start)/2
+		for _, r := range [][2]int{{start, mid}, {mid, end}} {
+			err := c.batchVerify(commitments[r[0]:r[1]], openingProofs[r[0]:r[1]], subslice(serCommitments, r[0], r[1]), subslice(serProofs, r[0], r[1]))
+			if err != nil {
+				search(r[0], r[1])
+			}
+		}
+	}
+	search(0, len(openingProofs))
+	return invalid
+}
+
+// subslice returns s[start:end], or nil if s is nil.
+func subslice[T any](s []T, start, end int) []T {
+	if s == nil {
+		return nil
+	}
+	return s[start:end]
+}
@@ -0,0 +1,86 @@
+package gokzg4844_test
+
+import (
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccumulatingVerifier(t *testing.T) {
+	verifier := ctx.NewAccumulatingVerifier()
+	require.NoError(t, verifier.Finalize(), "an empty verifier should succeed")
+
+	for i := 0; i < 4; i++ {
+		blob := GetRandBlob(int64(i))
+		inputPoint := GetRandFieldElement(int64(i))
+		commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+		require.NoError(t, err)
+		proof, claimedValue, err := ctx.ComputeKZGProof(blob, inputPoint, NumGoRoutines)
+		require.NoError(t, err)
+
+		require.NoError(t, verifier.Add(commitment, proof, inputPoint, claimedValue))
+	}
+	require.Equal(t, 4, verifier.Len())
+	require.NoError(t, verifier.Finalize())
+	require.Equal(t, 0, verifier.Len())
+
+	// A single invalid claim should make the whole batch fail
+	blob := GetRandBlob(123)
+	inputPoint := GetRandFieldElement(123)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	proof, claimedValue, err := ctx.ComputeKZGProof(blob, inputPoint, NumGoRoutines)
+	require.NoError(t, err)
+	require.NoError(t, verifier.Add(commitment, proof, inputPoint, claimedValue))
+	require.NoError(t, verifier.Add(commitment, proof, inputPoint, GetRandFieldElement(456)))
+	require.Error(t, verifier.Finalize())
+
+	// Inputs which cannot be deserialized are rejected straight away
+	err = verifier.Add(commitment, proof, nonCanonicalScalar(1), claimedValue)
+	require.Error(t, err)
+	require.Equal(t, 0, verifier.Len())
+}
+
+func TestAccumulatingVerifierQuarantine(t *testing.T) {
+	verifier := ctx.NewAccumulatingVerifier()
+	var quarantined []gokzg4844.QuarantinedClaim
+	verifier.SetQuarantine(func(claim gokzg4844.QuarantinedClaim) {
+		quarantined = append(quarantined, claim)
+	})
+
+	blob := GetRandBlob(7)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		inputPoint := GetRandFieldElement(int64(i))
+		proof, claimedValue, err := ctx.ComputeKZGProof(blob, inputPoint, NumGoRoutines)
+		require.NoError(t, err)
+		// The claims from the second peer are invalid
+		peer := "honest"
+		if i == 1 || i == 4 {
+			peer = "malicious"
+			claimedValue = GetRandFieldElement(int64(100 + i))
+		}
+		require.NoError(t, verifier.AddTagged(peer, commitment, proof, inputPoint, claimedValue))
+	}
+
+	require.NoError(t, verifier.Finalize())
+	require.Len(t, quarantined, 2)
+	for j, i := range []int{1, 4} {
+		require.Equal(t, i, quarantined[j].Index)
+		require.Equal(t, "malicious", quarantined[j].Tag)
+		require.Equal(t, commitment, quarantined[j].Commitment)
+		require.Equal(t, gokzg4844.Scalar(GetRandFieldElement(int64(i))), quarantined[j].InputPoint)
+		require.Equal(t, gokzg4844.Scalar(GetRandFieldElement(int64(100+i))), quarantined[j].ClaimedValue)
+		require.ErrorIs(t, ctx.VerifyKZGProof(quarantined[j].Commitment, quarantined[j].InputPoint, quarantined[j].ClaimedValue, quarantined[j].Proof), gokzg4844.ErrProofInvalid)
+	}
+
+	// Without the callback, the whole accumulation fails again
+	verifier.SetQuarantine(nil)
+	inputPoint := GetRandFieldElement(1)
+	proof, _, err := ctx.ComputeKZGProof(blob, inputPoint, NumGoRoutines)
+	require.NoError(t, err)
+	require.NoError(t, verifier.Add(commitment, proof, inputPoint, GetRandFieldElement(2)))
+	require.ErrorIs(t, verifier.Finalize(), gokzg4844.ErrProofInvalid)
+}
@@ -0,0 +1,149 @@
+package gokzg4844
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+	"github.com/crate-crypto/go-kzg-4844/internal/multiexp"
+	"github.com/crate-crypto/go-kzg-4844/internal/utils"
+)
+
+// ComputeAggregateKZGProof computes a single proof that the polynomial of each of the blobs takes the returned value
+// at the same input point, so that a block needs one proof instead of one per blob.
+//
+// The polynomials are combined into sum_i r^i * p_i(X), where the challenge r is derived from a hash of the
+// commitments, the input point and the values, as in the aggregate proofs used before Deneb. The proof is the opening
+// of the combined polynomial at the input point, whose commitment the verifier computes from the commitments to the
+// blobs. The i'th value in the returned slice is the evaluation of the i'th blob.
+//
+// Note: As with [Context.ComputeBlobKZGProof], this method does not check that the commitments correspond to the
+// blobs; a proof for commitments which do not is not valid.
+//
+// numGoRoutines is used to configure the amount of concurrency needed. Setting this
+// value to a negative number or 0 will make it default to the value configured with
+// [WithNumGoRoutines], which itself defaults to the number of CPUs.
+//
+// It returns [ErrBatchLengthCheck] if the number of blobs and commitments differ.
+func (c *Context) ComputeAggregateKZGProof(blobs []Blob, commitments []KZGCommitment, inputPointBytes Scalar, numGoRoutines int) (KZGProof, []Scalar, error) {
+	if err := c.checkTypes(); err != nil {
+		return KZGProof{}, nil, err
+	}
+	if len(blobs) != len(commitments) {
+		return KZGProof{}, nil, ErrBatchLengthCheck
+	}
+
+	// 1. Deserialization
+	//
+	// We only deserialize the commitments to check if they are in the correct subgroup
+	for i := range commitments {
+		if _, err := DeserializeKZGCommitment(commitments[i]); err != nil {
+			return KZGProof{}, nil, err
+		}
+	}
+	inputPoint, err := DeserializeScalar(inputPointBytes)
+	if err != nil {
+		return KZGProof{}, nil, err
+	}
+	polynomials := make([]kzg.Polynomial, len(blobs))
+	for i := range blobs {
+		polynomials[i], err = DeserializeBlobPar(&blobs[i], c.goRoutines(numGoRoutines))
+		if err != nil {
+			return KZGProof{}, nil, err
+		}
+	}
+
+	// 2. Evaluate the polynomials and combine them
+	//
+	evaluations, err := c.domain().EvaluateLagrangePolynomials(polynomials, inputPoint)
+	if err != nil {
+		return KZGProof{}, nil, err
+	}
+	claimedValues := make([]Scalar, len(evaluations))
+	for i := range evaluations {
+		claimedValues[i] = SerializeScalar(evaluations[i])
+	}
+
+	challenge := computeAggregateChallenge(c.preset.ScalarsPerBlob, commitments, inputPointBytes, claimedValues)
+	powers := utils.ComputePowers(challenge, uint(len(polynomials)))
+	combined := make(kzg.Polynomial, c.preset.ScalarsPerBlob)
+	for i := range polynomials {
+		for j := range combined {
+			var term fr.Element
+			term.Mul(&polynomials[i][j], &powers[i])
+			combined[j].Add(&combined[j], &term)
+		}
+	}
+
+	// 3. Open the combined polynomial
+	//
+	openingProof, err := kzg.Open(c.domain(), combined, inputPoint, c.commitKey(), c.goRoutines(numGoRoutines))
+	if err != nil {
+		return KZGProof{}, nil, err
+	}
+	return KZGProof(SerializeG1Point(openingProof.QuotientCommitment)), claimedValues, nil
+}
+
+// VerifyAggregateKZGProof verifies a proof returned by [Context.ComputeAggregateKZGProof] that the polynomial with
+// the i'th commitment takes the i'th claimed value at the input point.
+//
+// The commitments and claimed values are combined with the powers of the same challenge as the prover used, and the
+// proof is then verified as the opening of the combined commitment, as done by [Context.VerifyKZGProof].
+//
+// It returns [ErrBatchLengthCheck] if the number of commitments and claimed values differ, and [ErrProofInvalid] if
+// the proof is not valid.
+func (c *Context) VerifyAggregateKZGProof(commitments []KZGCommitment, inputPointBytes Scalar, claimedValuesBytes []Scalar, kzgProof KZGProof) error {
+	if err := c.checkTypes(); err != nil {
+		return err
+	}
+	if len(commitments) != len(claimedValuesBytes) {
+		return ErrBatchLengthCheck
+	}
+
+	// 1. Deserialization
+	//
+	polyCommitments := make([]kzg.Commitment, len(commitments))
+	for i := range commitments {
+		var err error
+		polyCommitments[i], err = DeserializeKZGCommitment(commitments[i])
+		if err != nil {
+			return err
+		}
+	}
+	inputPoint, err := DeserializeScalar(inputPointBytes)
+	if err != nil {
+		return err
+	}
+	claimedValues := make([]fr.Element, len(claimedValuesBytes))
+	for i := range claimedValuesBytes {
+		claimedValues[i], err = DeserializeScalar(claimedValuesBytes[i])
+		if err != nil {
+			return err
+		}
+	}
+	quotientCommitment, err := DeserializeKZGProof(kzgProof)
+	if err != nil {
+		return err
+	}
+
+	// 2. Combine the commitments and the claimed values
+	//
+	challenge := computeAggregateChallenge(c.preset.ScalarsPerBlob, commitments, inputPointBytes, claimedValuesBytes)
+	powers := utils.ComputePowers(challenge, uint(len(commitments)))
+	var combinedCommitment kzg.Commitment
+	if len(commitments) > 0 {
+		combined, err := multiexp.MultiExp(powers, polyCommitments, c.goRoutines(0))
+		if err != nil {
+			return err
+		}
+		combinedCommitment = *combined
+	}
+	combinedValue := kzg.InnerProduct(claimedValues, powers)
+
+	// 3. Verify the opening of the combined commitment
+	//
+	proof := kzg.OpeningProof{
+		QuotientCommitment: quotientCommitment,
+		InputPoint:         inputPoint,
+		ClaimedValue:       combinedValue,
+	}
+	return kzg.Verify(&combinedCommitment, &proof, c.openKey)
+}
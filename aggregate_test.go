@@ -0,0 +1,50 @@
+package gokzg4844_test
+
+import (
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateKZGProof(t *testing.T) {
+	blobs := []gokzg4844.Blob{*GetRandBlob(51), *GetRandBlob(52), *GetRandBlob(53)}
+	commitments, err := ctx.BlobsToKZGCommitments(blobs, NumGoRoutines)
+	require.NoError(t, err)
+	z := GetRandFieldElement(54)
+
+	proof, values, err := ctx.ComputeAggregateKZGProof(blobs, commitments, z, NumGoRoutines)
+	require.NoError(t, err)
+	for i := range blobs {
+		_, y, err := ctx.ComputeKZGProof(&blobs[i], z, NumGoRoutines)
+		require.NoError(t, err)
+		require.Equal(t, y, values[i])
+	}
+	require.NoError(t, ctx.VerifyAggregateKZGProof(commitments, z, values, proof))
+
+	// A single blob gives the same proof as ComputeKZGProof
+	single, singleValues, err := ctx.ComputeAggregateKZGProof(blobs[:1], commitments[:1], z, NumGoRoutines)
+	require.NoError(t, err)
+	expected, _, err := ctx.ComputeKZGProof(&blobs[0], z, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, expected, single)
+	require.NoError(t, ctx.VerifyAggregateKZGProof(commitments[:1], z, singleValues, single))
+
+	// Swapping two values, or the commitments, fails
+	swapped := []gokzg4844.Scalar{values[1], values[0], values[2]}
+	require.ErrorIs(t, ctx.VerifyAggregateKZGProof(commitments, z, swapped, proof), gokzg4844.ErrProofInvalid)
+	swappedCommitments := []gokzg4844.KZGCommitment{commitments[1], commitments[0], commitments[2]}
+	require.ErrorIs(t, ctx.VerifyAggregateKZGProof(swappedCommitments, z, values, proof), gokzg4844.ErrProofInvalid)
+	require.ErrorIs(t, ctx.VerifyAggregateKZGProof(commitments, GetRandFieldElement(55), values, proof), gokzg4844.ErrProofInvalid)
+
+	// No blobs have the proof of the zero polynomial
+	empty, emptyValues, err := ctx.ComputeAggregateKZGProof(nil, nil, z, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.KZGProof(gokzg4844.PointAtInfinity), empty)
+	require.Empty(t, emptyValues)
+	require.NoError(t, ctx.VerifyAggregateKZGProof(nil, z, nil, empty))
+
+	_, _, err = ctx.ComputeAggregateKZGProof(blobs, commitments[:2], z, NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrBatchLengthCheck)
+	require.ErrorIs(t, ctx.VerifyAggregateKZGProof(commitments, z, values[:2], proof), gokzg4844.ErrBatchLengthCheck)
+}
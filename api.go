@@ -2,8 +2,12 @@ package gokzg4844
 
 import (
 	"encoding/json"
+	"io"
+	"runtime"
 
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+	"github.com/crate-crypto/go-kzg-4844/internal/multiexp"
 )
 
 // Context holds the necessary configuration needed to create and verify proofs.
@@ -11,9 +15,50 @@ import (
 // Note: We could marshall this object so that clients won't need to process the SRS each time. The time to process is
 // about 2-5 seconds.
 type Context struct {
-	domain    *kzg.Domain
-	commitKey *kzg.CommitKey
-	openKey   *kzg.OpeningKey
+	openKey *kzg.OpeningKey
+
+	// lazy holds the domain and the commit key, among others, which are
+	// computed when the context is created, or when they are first needed
+	// if it was created with [WithLazyInit].
+	lazy lazySetup
+
+	// monomial holds the monomial form of the G1 points, which is derived
+	// when it is first needed.
+	monomial monomialKey
+
+	// order is the order in which the evaluations of a blob are stored.
+	order Permutation
+
+	// preset holds the sizes of the blobs that the context is for.
+	preset Preset
+
+	// numGoRoutines is the amount of concurrency to use when a method
+	// is called with a non-positive numGoRoutines argument.
+	numGoRoutines int
+
+	// latencyPool holds the dedicated workers used by the single blob proving
+	// methods when the context was created with [WithLatencyMode].
+	latencyPool *multiexp.Pool
+
+	// setupDigest identifies the trusted setup that the context was created with.
+	setupDigest [32]byte
+
+	// batchRandomness is the source of the randomness used to fold proofs in batch
+	// verification. If nil, crypto/rand is used.
+	batchRandomness io.Reader
+	// fiatShamirBatch denotes whether the batch verification challenge is derived
+	// from a hash of the proofs, in which case batchRandomness is not used.
+	fiatShamirBatch bool
+
+	// accounting is called with the usage of each call which takes a
+	// context.Context, if it is not nil.
+	accounting AccountingHook
+
+	// closed is set to 1 by [Context.Close].
+	closed int32
+	// shared denotes whether this is the context returned by
+	// [SharedContext4096Secure], which cannot be closed.
+	shared bool
 }
 
 // BlsModulus is the bytes representation of the bls12-381 scalar field modulus.
@@ -39,7 +84,9 @@ var PointAtInfinity = [48]byte{0xc0}
 // methods. "4096" denotes that we will only be able to commit to polynomials with at most 4096 evaluations. "Secure"
 // denotes that this method is using a trusted setup file that was generated in an official
 // ceremony. In particular, the trusted file being used was taken from the ethereum KZG ceremony.
-func NewContext4096Secure() (*Context, error) {
+//
+// The context can be configured by passing in a list of [Option]s.
+func NewContext4096Secure(opts ...Option) (*Context, error) {
 	if ScalarsPerBlob != 4096 {
 		// This is a library bug and so we panic.
 		panic("this method is named `NewContext4096Insecure1337` we expect SCALARS_PER_BLOB to be 4096")
@@ -56,7 +103,7 @@ func NewContext4096Secure() (*Context, error) {
 		// This is a library method and so we panic
 		panic("this method is named `NewContext4096Insecure1337` we expect the number of G1 elements in the trusted setup to be 4096")
 	}
-	return NewContext4096(&parsedSetup)
+	return NewContext4096(&parsedSetup, opts...)
 }
 
 // NewContext4096 creates a new context object which will hold the state needed for one to use the EIP-4844 methods. The
@@ -75,44 +122,177 @@ func NewContext4096Secure() (*Context, error) {
 //   - G2points = {H, alpha * H, alpha^2 * H, ..., alpha^n * H}
 //   - Lagrange G1Points = {L_0(alpha^0) * G, L_1(alpha) * G, L_2(alpha^2) * G, ..., L_n(alpha^n) * G}
 //
+// Unless the setup is the one from the Ethereum KZG ceremony, as identified by [MainnetSetupDigest], the points are
+// checked to be in the correct subgroup with [CheckTrustedSetupIsWellFormed]. This can be changed with
+// [WithSetupChecks].
+//
+// Note: Earlier versions did not check any setup, so creating a context from a setup other than the mainnet one now
+// takes about five times as long. Callers who load such a setup from a trusted source, for example in tests, can pass
+// WithSetupChecks([SetupChecksNever]) to keep the previous behaviour.
+//
+// The context can be configured by passing in a list of [Option]s.
+//
 // [Full Danksharding]: https://notes.ethereum.org/@dankrad/new_sharding
-func NewContext4096(trustedSetup *JSONTrustedSetup) (*Context, error) {
+func NewContext4096(trustedSetup *JSONTrustedSetup, opts ...Option) (*Context, error) {
+	cfg := newConfig(opts)
+
+	if err := SelfTest(); err != nil {
+		return nil, err
+	}
+	if cfg.lazyInit {
+		return newLazyContext4096(cfg, trustedSetup)
+	}
+
+	setupDigest, genG1, setupLagrangeG1Points, setupG2Points, err := parseCheckedTrustedSetup(cfg, trustedSetup)
+	if err != nil {
+		return nil, err
+	}
+	return newContext4096(cfg, setupDigest, genG1, setupLagrangeG1Points, setupG2Points)
+}
+
+// parseCheckedTrustedSetup parses the points of the trusted setup, after checking that they are in the correct
+// subgroup if the configuration requires it, and returns them along with the digest of the setup.
+func parseCheckedTrustedSetup(cfg config, trustedSetup *JSONTrustedSetup) ([32]byte, bls12381.G1Affine, []bls12381.G1Affine, []bls12381.G2Affine, error) {
 	// This should not happen for the ETH protocol
 	// However since it's a public method, we add the check.
 	if len(trustedSetup.SetupG2) < 2 {
-		return nil, kzg.ErrMinSRSSize
+		return [32]byte{}, bls12381.G1Affine{}, nil, nil, kzg.ErrMinSRSSize
+	}
+
+	// The digest is computed from the hex strings, so that the setup can be identified before its points are
+	// deserialized. It does not depend on the configuration.
+	setupDigest, err := SetupDigest(trustedSetup)
+	if err != nil {
+		return [32]byte{}, bls12381.G1Affine{}, nil, nil, err
+	}
+
+	if cfg.needsSetupChecks(setupDigest) {
+		if err := CheckTrustedSetupIsWellFormedPar(trustedSetup, cfg.numGoRoutines); err != nil {
+			return [32]byte{}, bls12381.G1Affine{}, nil, nil, err
+		}
 	}
 
 	// Parse the trusted setup from hex strings to G1 and G2 points
-	genG1, setupLagrangeG1Points, setupG2Points := parseTrustedSetup(trustedSetup)
+	genG1, setupLagrangeG1Points, setupG2Points, err := parseTrustedSetup(trustedSetup, cfg.numGoRoutines)
+	if err != nil {
+		return [32]byte{}, bls12381.G1Affine{}, nil, nil, err
+	}
+	return setupDigest, genG1, setupLagrangeG1Points, setupG2Points, nil
+}
 
+// needsSetupChecks reports whether the points of the setup with the given digest must be checked to be in the
+// correct subgroup.
+//
+// The subgroup checks are the most expensive part of creating a context, and are not needed for a setup
+// which is known to be well-formed.
+func (cfg *config) needsSetupChecks(setupDigest [32]byte) bool {
+	return cfg.setupChecks == SetupChecksAlways || (cfg.setupChecks == SetupChecksUnknown && setupDigest != MainnetSetupDigest)
+}
+
+// newContext4096 creates a context for the [MainnetPreset] from the deserialized points of a trusted setup, which has
+// at least two G2 points. The points must already have been checked, if the configuration requires it.
+func newContext4096(cfg config, setupDigest [32]byte, genG1 bls12381.G1Affine, setupLagrangeG1Points []bls12381.G1Affine, setupG2Points []bls12381.G2Affine) (*Context, error) {
+	return newContext(cfg, mainnetPreset, setupDigest, genG1, setupLagrangeG1Points, setupG2Points)
+}
+
+// newContext is the same as [newContext4096], except that the context is for the given preset, which must be valid.
+// There must be one Lagrange G1 point for each scalar in a blob of the preset.
+func newContext(cfg config, preset Preset, setupDigest [32]byte, genG1 bls12381.G1Affine, setupLagrangeG1Points []bls12381.G1Affine, setupG2Points []bls12381.G2Affine) (*Context, error) {
 	// Get the generator points and the degree-1 element for G2 points
 	// The generators are the degree-0 elements in the trusted setup
 	//
 	// This will never panic as we checked the minimum SRS size is >= 2
-	// and `ScalarsPerBlob` is 4096
 	genG2 := setupG2Points[0]
 	alphaGenG2 := setupG2Points[1]
 
-	commitKey := kzg.CommitKey{
-		G1: setupLagrangeG1Points,
+	return newContextFromLoader(cfg, preset, setupDigest, genG1, genG2, alphaGenG2, func() ([]bls12381.G1Affine, []bls12381.G2Affine, bool, error) {
+		return setupLagrangeG1Points, setupG2Points, false, nil
+	})
+}
+
+// newContextFromLoader is the same as [newContext], except that the Lagrange G1 points and all of the G2 points are
+// returned by load, along with whether the G1 points are already in bit-reversed order; see [newLoadedSetup]. It is
+// called when the context is created, or when the points are first needed if the context is created with
+// [WithLazyInit]. Everything else that can fail is checked straight away, and load must only fail when it is called
+// from the constructor.
+func newContextFromLoader(cfg config, preset Preset, setupDigest [32]byte, genG1 bls12381.G1Affine, genG2, alphaGenG2 bls12381.G2Affine, load func() ([]bls12381.G1Affine, []bls12381.G2Affine, bool, error)) (*Context, error) {
+	openingKey := kzg.NewOpeningKey(genG1, genG2, alphaGenG2)
+
+	if cfg.domain != nil && cfg.domain.Size() != uint64(preset.ScalarsPerBlob) {
+		return nil, ErrInvalidDomainSize
 	}
-	openingKey := kzg.OpeningKey{
-		GenG1:   genG1,
-		GenG2:   genG2,
-		AlphaG2: alphaGenG2,
+	var sourceIndices []int
+	switch cfg.order {
+	case nil:
+		return nil, ErrInvalidPermutation
+	case NaturalOrder, BitReversedOrder:
+	default:
+		var err error
+		sourceIndices, err = permutationSourceIndices(cfg.order, preset.ScalarsPerBlob)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.precomputeWindowBits != 0 && (cfg.precomputeWindowBits < multiexp.MinWindowBits || cfg.precomputeWindowBits > multiexp.MaxWindowBits) {
+		return nil, multiexp.ErrInvalidWindowBits
 	}
 
-	domain := kzg.NewDomain(ScalarsPerBlob)
-	// Bit-Reverse the roots and the trusted setup according to the specs
-	// The bit reversal is not needed for simple KZG however it was
-	// implemented to make the step for full dank-sharding easier.
-	commitKey.ReversePoints()
-	domain.ReverseRoots()
+	ctx := &Context{
+		openKey:         &openingKey,
+		order:           cfg.order,
+		preset:          preset,
+		numGoRoutines:   cfg.numGoRoutines,
+		setupDigest:     setupDigest,
+		batchRandomness: cfg.batchRandomness,
+		fiatShamirBatch: cfg.fiatShamirBatch,
+		accounting:      cfg.accounting,
+	}
+	ctx.lazy.load = func() (*loadedSetup, error) {
+		setupLagrangeG1Points, setupG2Points, reversed, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return newLoadedSetup(cfg, preset, setupLagrangeG1Points, setupG2Points, reversed, sourceIndices)
+	}
+	if !cfg.lazyInit {
+		if err := ctx.Warm(); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.eagerMonomial {
+		ctx.monomialG1()
+	}
+	if cfg.latencyMode {
+		pool, err := multiexp.NewPool(cfg.numGoRoutines)
+		if err != nil {
+			return nil, err
+		}
+		ctx.latencyPool = pool
+		// The workers do not reference the context, so it can still be garbage collected.
+		runtime.SetFinalizer(ctx, func(c *Context) { c.latencyPool.Close() })
+	}
 
-	return &Context{
-		domain:    domain,
-		commitKey: &commitKey,
-		openKey:   &openingKey,
-	}, nil
+	return ctx, nil
+}
+
+// singleProofKey returns the commit key used by the single blob proving methods. When the context was created with
+// [WithLatencyMode], this is a copy of the commit key which uses the dedicated workers.
+func (c *Context) singleProofKey() *kzg.CommitKey {
+	if c.latencyPool == nil {
+		return c.commitKey()
+	}
+	ck := *c.commitKey()
+	ck.Pool = c.latencyPool
+	return &ck
+}
+
+// goRoutines returns the amount of concurrency that a method should use, given
+// the numGoRoutines argument that it was called with.
+//
+// Non-positive values fall back to the value configured with [WithNumGoRoutines].
+func (c *Context) goRoutines(numGoRoutines int) int {
+	if numGoRoutines > 0 {
+		return numGoRoutines
+	}
+	return c.numGoRoutines
 }
@@ -1,7 +1,12 @@
 package gokzg4844_test
 
 import (
+	"bytes"
+	"context"
 	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
@@ -78,6 +83,338 @@ func TestNonCanonicalSmoke(t *testing.T) {
 	require.Error(t, err, "expected an error since blob was not canonical")
 }
 
+func TestContextWithoutBitReversal(t *testing.T) {
+	ctxNatural, err := gokzg4844.NewContext4096Secure(gokzg4844.WithBitReversalPermutation(false), gokzg4844.WithNumGoRoutines(2))
+	require.NoError(t, err)
+
+	blob := GetRandBlob(123)
+	commitment, err := ctxNatural.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	proof, err := ctxNatural.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+	err = ctxNatural.VerifyBlobKZGProof(blob, commitment, proof)
+	require.NoError(t, err)
+
+	// The blob is interpreted in a different order, so the commitment
+	// should differ from the one computed by the spec compliant context.
+	specCommitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	require.NotEqual(t, specCommitment, commitment)
+}
+
+func TestContextWithPrecompute(t *testing.T) {
+	ctxPrecompute, err := gokzg4844.NewContext4096Secure(gokzg4844.WithPrecompute(8))
+	require.NoError(t, err)
+
+	blob := GetRandBlob(123)
+	commitment, err := ctxPrecompute.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	proof, err := ctxPrecompute.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+
+	// The precomputed table should not change the result
+	expectedCommitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, expectedCommitment, commitment)
+	expectedProof, err := ctx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, expectedProof, proof)
+
+	_, err = gokzg4844.NewContext4096Secure(gokzg4844.WithPrecompute(1))
+	require.Error(t, err, "expected an error since the window size is too small")
+}
+
+func TestContextWithLatencyMode(t *testing.T) {
+	blob := GetRandBlob(123)
+	expectedCommitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	expectedProof, err := ctx.ComputeBlobKZGProof(blob, expectedCommitment, NumGoRoutines)
+	require.NoError(t, err)
+
+	// The dedicated workers should not change the result, with or without a precomputed table
+	for _, opts := range [][]gokzg4844.Option{
+		{gokzg4844.WithLatencyMode()},
+		{gokzg4844.WithLatencyMode(), gokzg4844.WithPrecompute(8), gokzg4844.WithNumGoRoutines(3)},
+	} {
+		ctxLatency, err := gokzg4844.NewContext4096Secure(opts...)
+		require.NoError(t, err)
+
+		commitment, err := ctxLatency.BlobToKZGCommitment(blob, NumGoRoutines)
+		require.NoError(t, err)
+		require.Equal(t, expectedCommitment, commitment)
+		proof, err := ctxLatency.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+		require.NoError(t, err)
+		require.Equal(t, expectedProof, proof)
+		require.NoError(t, ctxLatency.VerifyBlobKZGProof(blob, commitment, proof))
+	}
+
+	_, err = gokzg4844.NewContext4096Secure(gokzg4844.WithLatencyMode(), gokzg4844.WithNumGoRoutines(1024))
+	require.Error(t, err, "expected an error since the pool is too large")
+}
+
+func TestSaveLoadPrecompute(t *testing.T) {
+	ctxPrecompute, err := gokzg4844.NewContext4096Secure(gokzg4844.WithPrecompute(8))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = ctx.SavePrecompute(&buf)
+	require.ErrorIs(t, err, gokzg4844.ErrNoPrecompute)
+	err = ctxPrecompute.SavePrecompute(&buf)
+	require.NoError(t, err)
+	serialized := buf.Bytes()
+
+	ctxLoaded, err := gokzg4844.NewContext4096Secure()
+	require.NoError(t, err)
+	err = ctxLoaded.LoadPrecompute(bytes.NewReader(serialized))
+	require.NoError(t, err)
+
+	blob := GetRandBlob(123)
+	commitment, err := ctxLoaded.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	expectedCommitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, expectedCommitment, commitment)
+
+	// The table depends on the order of the points, so it cannot be loaded
+	// into a context which uses a different order
+	ctxNatural, err := gokzg4844.NewContext4096Secure(gokzg4844.WithBitReversalPermutation(false))
+	require.NoError(t, err)
+	err = ctxNatural.LoadPrecompute(bytes.NewReader(serialized))
+	require.Error(t, err)
+
+	err = ctxLoaded.LoadPrecompute(bytes.NewReader(serialized[:len(serialized)/2]))
+	require.Error(t, err)
+}
+
+func TestLoadPrecomputeMmap(t *testing.T) {
+	ctxPrecompute, err := gokzg4844.NewContext4096Secure(gokzg4844.WithPrecompute(8))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "precompute.bin")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, ctxPrecompute.SavePrecompute(f))
+	require.NoError(t, f.Close())
+
+	ctxMmap, err := gokzg4844.ReadContextFromFileMmap(path)
+	require.NoError(t, err)
+
+	blob := GetRandBlob(123)
+	expectedCommitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	commitment, err := ctxMmap.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, expectedCommitment, commitment)
+
+	// The table depends on the order of the points
+	ctxNatural, err := gokzg4844.NewContext4096Secure(gokzg4844.WithBitReversalPermutation(false))
+	require.NoError(t, err)
+	require.Error(t, ctxNatural.LoadPrecomputeMmap(path))
+
+	_, err = gokzg4844.ReadContextFromFileMmap(filepath.Join(t.TempDir(), "missing.bin"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	empty := filepath.Join(t.TempDir(), "empty.bin")
+	require.NoError(t, os.WriteFile(empty, nil, 0o600))
+	_, err = gokzg4844.ReadContextFromFileMmap(empty)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPrecomputeFile)
+}
+
+func TestCancelledContext(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	blob := GetRandBlob(123)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	proof, err := ctx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+
+	_, err = ctx.BlobToKZGCommitmentCtx(cancelled, blob, NumGoRoutines)
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = ctx.ComputeBlobKZGProofCtx(cancelled, blob, commitment, NumGoRoutines)
+	require.ErrorIs(t, err, context.Canceled)
+	_, _, err = ctx.ComputeKZGProofCtx(cancelled, blob, GetRandFieldElement(1), NumGoRoutines)
+	require.ErrorIs(t, err, context.Canceled)
+
+	blobs := []gokzg4844.Blob{*blob}
+	err = ctx.VerifyBlobKZGProofBatchCtx(cancelled, blobs, []gokzg4844.KZGCommitment{commitment}, []gokzg4844.KZGProof{proof})
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = ctx.BlobsToKZGCommitmentsCtx(cancelled, blobs, NumGoRoutines)
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = ctx.ComputeBlobKZGProofsCtx(cancelled, blobs, []gokzg4844.KZGCommitment{commitment}, NumGoRoutines)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestContextWithBatchRandomness(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x42}, 64)
+	ctxDeterministic, err := gokzg4844.NewContext4096Secure(gokzg4844.WithBatchRandomness(bytes.NewReader(seed)))
+	require.NoError(t, err)
+
+	blobs := []gokzg4844.Blob{*GetRandBlob(1), *GetRandBlob(2)}
+	commitments, err := ctxDeterministic.BlobsToKZGCommitments(blobs, NumGoRoutines)
+	require.NoError(t, err)
+	proofs, err := ctxDeterministic.ComputeBlobKZGProofs(blobs, commitments, NumGoRoutines)
+	require.NoError(t, err)
+
+	err = ctxDeterministic.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	require.NoError(t, err)
+
+	// The reader has been exhausted, so the next batch cannot sample its randomness
+	err = ctxDeterministic.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	require.Error(t, err)
+}
+
+func TestContextWithFiatShamirBatchChallenge(t *testing.T) {
+	// The reader is empty, so any attempt to sample randomness would fail
+	ctxFiatShamir, err := gokzg4844.NewContext4096Secure(
+		gokzg4844.WithFiatShamirBatchChallenge(true),
+		gokzg4844.WithBatchRandomness(bytes.NewReader(nil)),
+	)
+	require.NoError(t, err)
+
+	blobs := []gokzg4844.Blob{*GetRandBlob(1), *GetRandBlob(2), *GetRandBlob(3)}
+	commitments, err := ctxFiatShamir.BlobsToKZGCommitments(blobs, NumGoRoutines)
+	require.NoError(t, err)
+	proofs, err := ctxFiatShamir.ComputeBlobKZGProofs(blobs, commitments, NumGoRoutines)
+	require.NoError(t, err)
+
+	err = ctxFiatShamir.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	require.NoError(t, err)
+
+	verifier := ctxFiatShamir.NewAccumulatingVerifier()
+	for i := range blobs {
+		inputPoint := GetRandFieldElement(int64(i))
+		proof, claimedValue, err := ctxFiatShamir.ComputeKZGProof(&blobs[i], inputPoint, NumGoRoutines)
+		require.NoError(t, err)
+		require.NoError(t, verifier.Add(commitments[i], proof, inputPoint, claimedValue))
+	}
+	require.NoError(t, verifier.Finalize())
+
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+	err = ctxFiatShamir.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	require.Error(t, err)
+}
+
+func TestTypedErrors(t *testing.T) {
+	blob := GetRandBlob(123)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	proof, err := ctx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+
+	// Use the proof for a different blob
+	otherProof, err := ctx.ComputeBlobKZGProof(GetRandBlob(124), commitment, NumGoRoutines)
+	require.NoError(t, err)
+	err = ctx.VerifyBlobKZGProof(blob, commitment, otherProof)
+	require.ErrorIs(t, err, gokzg4844.ErrProofInvalid)
+	err = ctx.VerifyBlobKZGProofBatch([]gokzg4844.Blob{*blob}, []gokzg4844.KZGCommitment{commitment}, []gokzg4844.KZGProof{otherProof})
+	require.ErrorIs(t, err, gokzg4844.ErrProofInvalid)
+
+	// A non-canonical blob matches both the blob specific and the scalar error
+	modifyBlob(blob, nonCanonicalScalar(123), 0)
+	err = ctx.VerifyBlobKZGProof(blob, commitment, proof)
+	require.ErrorIs(t, err, gokzg4844.ErrBlobNotCanonical)
+	require.ErrorIs(t, err, gokzg4844.ErrNonCanonicalScalar)
+}
+
+func TestBatchVerificationErrorIndex(t *testing.T) {
+	const batchSize = 7
+	blobs := make([]gokzg4844.Blob, batchSize)
+	commitments := make([]gokzg4844.KZGCommitment, batchSize)
+	proofs := make([]gokzg4844.KZGProof, batchSize)
+	for i := 0; i < batchSize; i++ {
+		blobs[i] = *GetRandBlob(int64(i))
+		commitment, err := ctx.BlobToKZGCommitment(&blobs[i], NumGoRoutines)
+		require.NoError(t, err)
+		commitments[i] = commitment
+		proof, err := ctx.ComputeBlobKZGProof(&blobs[i], commitment, NumGoRoutines)
+		require.NoError(t, err)
+		proofs[i] = proof
+	}
+
+	// Every position of an invalid proof should be found, and the first one
+	// should be reported if there is more than one.
+	for bad := 0; bad < batchSize; bad++ {
+		badProofs := append([]gokzg4844.KZGProof{}, proofs...)
+		badProofs[bad] = proofs[(bad+1)%batchSize]
+		badProofs[batchSize-1] = proofs[0]
+
+		for _, err := range []error{
+			ctx.VerifyBlobKZGProofBatch(blobs, commitments, badProofs),
+			ctx.VerifyBlobKZGProofBatchPar(blobs, commitments, badProofs),
+		} {
+			var batchErr *gokzg4844.BatchVerificationError
+			require.ErrorAs(t, err, &batchErr)
+			require.Equal(t, bad, batchErr.Index)
+			require.ErrorIs(t, err, gokzg4844.ErrProofInvalid)
+		}
+	}
+
+	// Deserialization errors are reported with their index
+	badBlobs := append([]gokzg4844.Blob{}, blobs...)
+	modifyBlob(&badBlobs[3], nonCanonicalScalar(3), 0)
+	err := ctx.VerifyBlobKZGProofBatch(badBlobs, commitments, proofs)
+	var batchErr *gokzg4844.BatchVerificationError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, 3, batchErr.Index)
+	require.ErrorIs(t, err, gokzg4844.ErrBlobNotCanonical)
+}
+
+// Blobs are 128KB, so the API should never take them by value.
+func TestNoBlobByValue(t *testing.T) {
+	blobType := reflect.TypeOf(gokzg4844.Blob{})
+	contextType := reflect.TypeOf(ctx)
+	for i := 0; i < contextType.NumMethod(); i++ {
+		method := contextType.Method(i)
+		for j := 0; j < method.Type.NumIn(); j++ {
+			require.NotEqual(t, blobType, method.Type.In(j), "%s takes a blob by value", method.Name)
+		}
+	}
+}
+
+func TestSharedContext(t *testing.T) {
+	shared, err := gokzg4844.SharedContext4096Secure()
+	require.NoError(t, err)
+	again, err := gokzg4844.SharedContext4096Secure()
+	require.NoError(t, err)
+	require.Same(t, shared, again)
+
+	blob := GetRandBlob(1)
+	commitment, err := shared.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	expected, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, expected, commitment)
+
+	// The shared context cannot be closed, so that it keeps working for every other caller
+	require.ErrorIs(t, shared.Close(), gokzg4844.ErrSharedContext)
+	_, err = shared.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+}
+
+func TestOneShotFunctions(t *testing.T) {
+	blob := GetRandBlob(2)
+	commitment, err := gokzg4844.BlobToKZGCommitment(blob)
+	require.NoError(t, err)
+	expectedCommitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, expectedCommitment, commitment)
+
+	blobProof, err := gokzg4844.ComputeBlobKZGProof(blob, commitment)
+	require.NoError(t, err)
+	require.NoError(t, gokzg4844.VerifyBlobKZGProof(blob, commitment, blobProof))
+	require.NoError(t, gokzg4844.VerifyBlobKZGProofBatch([]gokzg4844.Blob{*blob}, []gokzg4844.KZGCommitment{commitment}, []gokzg4844.KZGProof{blobProof}))
+
+	inputPoint := GetRandFieldElement(3)
+	proof, claimedValue, err := gokzg4844.ComputeKZGProof(blob, inputPoint)
+	require.NoError(t, err)
+	require.NoError(t, gokzg4844.VerifyKZGProof(commitment, inputPoint, claimedValue, proof))
+
+	err = gokzg4844.VerifyBlobKZGProof(blob, commitment, proof)
+	require.ErrorIs(t, err, gokzg4844.ErrProofInvalid)
+}
+
 // Below are helper methods which allow us to change a serialized element into
 // its non-canonical counterpart by adding the modulus
 func modifyBlob(blob *gokzg4844.Blob, newValue gokzg4844.Scalar, index int) {
@@ -120,3 +457,45 @@ func addModP(x big.Int) big.Int {
 
 	return xPlusModulus
 }
+
+// reversedOrder stores the evaluations in reverse order, to test custom permutations.
+type reversedOrder struct{}
+
+func (reversedOrder) SourceIndex(i, n int) int {
+	return n - 1 - i
+}
+
+// notAPermutation maps every index to the same index.
+type notAPermutation struct{}
+
+func (notAPermutation) SourceIndex(_, _ int) int {
+	return 0
+}
+
+func TestContextWithEvaluationOrder(t *testing.T) {
+	// The built-in permutation should give the same result as the default
+	ctxBitReversed, err := gokzg4844.NewContext4096Secure(gokzg4844.WithEvaluationOrder(gokzg4844.BitReversedOrder))
+	require.NoError(t, err)
+	blob := GetRandBlob(123)
+	commitment, err := ctxBitReversed.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	expectedCommitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, expectedCommitment, commitment)
+
+	// A custom permutation should produce proofs which verify
+	ctxReversed, err := gokzg4844.NewContext4096Secure(gokzg4844.WithEvaluationOrder(reversedOrder{}))
+	require.NoError(t, err)
+	commitment, err = ctxReversed.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	require.NotEqual(t, expectedCommitment, commitment)
+	proof, err := ctxReversed.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+	err = ctxReversed.VerifyBlobKZGProof(blob, commitment, proof)
+	require.NoError(t, err)
+
+	_, err = gokzg4844.NewContext4096Secure(gokzg4844.WithEvaluationOrder(notAPermutation{}))
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPermutation)
+	_, err = gokzg4844.NewContext4096Secure(gokzg4844.WithEvaluationOrder(nil))
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPermutation)
+}
@@ -0,0 +1,228 @@
+package gokzg4844
+
+import (
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+	"golang.org/x/sync/errgroup"
+)
+
+// BackfillItem is a blob along with its commitment and proof, as verified by [Context.VerifyBlobKZGProof].
+type BackfillItem struct {
+	Blob       *Blob
+	Commitment KZGCommitment
+	Proof      KZGProof
+}
+
+// BackfillIterator supplies the items that a [BackfillVerifier] verifies.
+//
+// Next returns the next item, or io.EOF once there are no more items. Any other error stops the verification. Next
+// is never called concurrently. The blob of an item must not be modified until the batch that it is in has been
+// verified, which is signalled by the checkpoint passed to [BackfillVerifier.Run].
+type BackfillIterator interface {
+	Next() (BackfillItem, error)
+}
+
+// BackfillProgress reports how far a [BackfillVerifier] has got.
+type BackfillProgress struct {
+	// Verified is the number of items, counted from the start of the iterator, which
+	// have been verified.
+	Verified uint64
+	// Batches is the number of batches which have been verified.
+	Batches int
+	// Elapsed is the wall-clock time since [BackfillVerifier.Run] was called.
+	Elapsed time.Duration
+}
+
+// Throughput returns the number of items verified per second.
+func (p BackfillProgress) Throughput() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Verified) / p.Elapsed.Seconds()
+}
+
+// backfillItemSize is the number of bytes of memory that a verified item takes up while its batch is being collected.
+const backfillItemSize = int(unsafe.Sizeof(kzg.Commitment{}) + unsafe.Sizeof(kzg.OpeningProof{}) +
+	unsafe.Sizeof(KZGCommitment{}) + unsafe.Sizeof(KZGProof{}))
+
+// BackfillVerifier verifies a large stream of historical blobs, such as when backfilling an archive.
+//
+// Items are read from a [BackfillIterator] and reduced to the points and scalars that are needed for the pairing
+// check, so that no blobs are held in memory once they have been processed. The reduced items are collected into
+// batches which are as large as the memory cap allows, and each batch is verified with a single pairing check, as done
+// by [Context.VerifyBlobKZGProofBatch].
+type BackfillVerifier struct {
+	ctx *Context
+
+	batchSize     int
+	numGoRoutines int
+}
+
+// NewBackfillVerifier returns a [BackfillVerifier] which uses this context to verify proofs.
+//
+// maxMemory is the maximum number of bytes used to hold a batch; each item takes up a few hundred bytes. In addition
+// to this, each go-routine uses the memory of one deserialized blob, about 128KB. A batch always holds at least one
+// item.
+//
+// numGoRoutines is used to configure the amount of concurrency needed. Setting this
+// value to a negative number or 0 will make it default to the value configured with
+// [WithNumGoRoutines], which itself defaults to the number of CPUs.
+func (c *Context) NewBackfillVerifier(maxMemory, numGoRoutines int) *BackfillVerifier {
+	batchSize := maxMemory / backfillItemSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	numGoRoutines = c.goRoutines(numGoRoutines)
+	if numGoRoutines <= 0 {
+		numGoRoutines = runtime.NumCPU()
+	}
+
+	return &BackfillVerifier{
+		ctx:           c,
+		batchSize:     batchSize,
+		numGoRoutines: numGoRoutines,
+	}
+}
+
+// Run verifies every item from the iterator, and returns the progress once all of them have been verified.
+//
+// checkpoint, if it is not nil, is called after each batch has been verified, so that the caller can record the
+// progress. To resume after an interruption, create an iterator which starts after the last Verified count that was
+// recorded. If checkpoint returns an error, Run stops and returns it.
+//
+// If an item is invalid, Run stops and returns a [*BatchVerificationError], whose index is counted from the start of
+// the iterator. Run also stops if the iterator returns an error other than io.EOF, or if ctx is cancelled. In all of
+// these cases, the returned progress covers the batches which were verified before stopping.
+func (v *BackfillVerifier) Run(ctx context.Context, it BackfillIterator, checkpoint func(BackfillProgress) error) (BackfillProgress, error) {
+	if err := v.ctx.checkTypes(); err != nil {
+		return BackfillProgress{}, err
+	}
+
+	var (
+		progress BackfillProgress
+		start    = time.Now()
+
+		commitments    = make([]kzg.Commitment, v.batchSize)
+		openingProofs  = make([]kzg.OpeningProof, v.batchSize)
+		serCommitments = make([]KZGCommitment, v.batchSize)
+		serProofs      = make([]KZGProof, v.batchSize)
+	)
+
+	for {
+		// 1. Collect and reduce a batch of items
+		//
+		n, done, err := v.collectBatch(ctx, it, commitments, openingProofs, serCommitments, serProofs, progress.Verified)
+		if err != nil {
+			progress.Elapsed = time.Since(start)
+			return progress, err
+		}
+
+		// 2. Verify the batch
+		//
+		if n > 0 {
+			if err := ctx.Err(); err != nil {
+				progress.Elapsed = time.Since(start)
+				return progress, err
+			}
+
+			prof := startProfile(ctx, "backfill_verify_batch", n)
+			err := v.ctx.batchVerify(commitments[:n], openingProofs[:n], serCommitments[:n], serProofs[:n])
+			if errors.Is(err, kzg.ErrVerifyOpeningProof) {
+				index := v.ctx.findInvalidProof(commitments[:n], openingProofs[:n], serCommitments[:n], serProofs[:n])
+				err = &BatchVerificationError{Index: int(progress.Verified) + index, Err: err}
+			}
+			prof.end()
+			if err != nil {
+				progress.Elapsed = time.Since(start)
+				return progress, err
+			}
+
+			progress.Verified += uint64(n)
+			progress.Batches++
+			progress.Elapsed = time.Since(start)
+			if checkpoint != nil {
+				if err := checkpoint(progress); err != nil {
+					return progress, err
+				}
+			}
+		}
+
+		if done {
+			progress.Elapsed = time.Since(start)
+			return progress, nil
+		}
+	}
+}
+
+// collectBatch reads up to a batch of items from the iterator and reduces them into the given slices, using a pool of
+// workers. It returns the number of items in the batch, and whether the iterator has been exhausted.
+//
+// offset is the index of the first item of the batch, counted from the start of the iterator.
+func (v *BackfillVerifier) collectBatch(ctx context.Context, it BackfillIterator, commitments []kzg.Commitment, openingProofs []kzg.OpeningProof, serCommitments []KZGCommitment, serProofs []KZGProof, offset uint64) (int, bool, error) {
+	var (
+		mu   sync.Mutex
+		n    int
+		done bool
+	)
+	// next returns the next item from the iterator along with its position in the batch,
+	// or false if the batch is full or the iterator has been exhausted.
+	next := func() (BackfillItem, int, bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if done || n == len(commitments) {
+			return BackfillItem{}, 0, false, nil
+		}
+		item, err := it.Next()
+		if err == io.EOF {
+			done = true
+			return BackfillItem{}, 0, false, nil
+		}
+		if err != nil {
+			return BackfillItem{}, 0, false, err
+		}
+		i := n
+		n++
+		return item, i, true, nil
+	}
+
+	var errG errgroup.Group
+	for w := 0; w < v.numGoRoutines; w++ {
+		errG.Go(func() error {
+			polynomial := make(kzg.Polynomial, ScalarsPerBlob)
+			for {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				item, i, ok, err := next()
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return nil
+				}
+
+				commitment, openingProof, err := v.ctx.blobOpeningProof(item.Blob[:], polynomial, item.Commitment, item.Proof)
+				if err != nil {
+					return &BatchVerificationError{Index: int(offset) + i, Err: err}
+				}
+				commitments[i] = commitment
+				openingProofs[i] = openingProof
+				serCommitments[i] = item.Commitment
+				serProofs[i] = item.Proof
+			}
+		})
+	}
+	if err := errG.Wait(); err != nil {
+		return 0, false, err
+	}
+
+	return n, done, nil
+}
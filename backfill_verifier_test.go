@@ -0,0 +1,102 @@
+package gokzg4844_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+// sliceIterator is a BackfillIterator over a slice of items.
+type sliceIterator struct {
+	items []gokzg4844.BackfillItem
+	next  int
+}
+
+func (it *sliceIterator) Next() (gokzg4844.BackfillItem, error) {
+	if it.next == len(it.items) {
+		return gokzg4844.BackfillItem{}, io.EOF
+	}
+	item := it.items[it.next]
+	it.next++
+	return item, nil
+}
+
+func backfillItems(t *testing.T, n int) []gokzg4844.BackfillItem {
+	items := make([]gokzg4844.BackfillItem, n)
+	for i := range items {
+		blob := GetRandBlob(int64(i))
+		commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+		require.NoError(t, err)
+		proof, err := ctx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+		require.NoError(t, err)
+		items[i] = gokzg4844.BackfillItem{Blob: blob, Commitment: commitment, Proof: proof}
+	}
+	return items
+}
+
+func TestBackfillVerifier(t *testing.T) {
+	items := backfillItems(t, 10)
+
+	// A memory cap which is too small to fit all of the items in one batch
+	verifier := ctx.NewBackfillVerifier(3*1000, 2)
+
+	var checkpoints []gokzg4844.BackfillProgress
+	progress, err := verifier.Run(context.Background(), &sliceIterator{items: items}, func(p gokzg4844.BackfillProgress) error {
+		checkpoints = append(checkpoints, p)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(items)), progress.Verified)
+	require.Equal(t, len(checkpoints), progress.Batches)
+	require.Greater(t, progress.Batches, 1)
+	require.Positive(t, progress.Throughput())
+	for i := 1; i < len(checkpoints); i++ {
+		require.Greater(t, checkpoints[i].Verified, checkpoints[i-1].Verified)
+	}
+
+	// An empty iterator
+	progress, err = verifier.Run(context.Background(), &sliceIterator{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), progress.Verified)
+}
+
+func TestBackfillVerifierInvalid(t *testing.T) {
+	items := backfillItems(t, 10)
+	verifier := ctx.NewBackfillVerifier(4*1000, 2)
+
+	// An invalid proof is reported with its index from the start of the iterator
+	badItems := append([]gokzg4844.BackfillItem{}, items...)
+	badItems[6].Proof = items[7].Proof
+	progress, err := verifier.Run(context.Background(), &sliceIterator{items: badItems}, nil)
+	var batchErr *gokzg4844.BatchVerificationError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, 6, batchErr.Index)
+	require.ErrorIs(t, err, gokzg4844.ErrProofInvalid)
+	require.Less(t, progress.Verified, uint64(7))
+
+	// So is an item which cannot be deserialized
+	badItems = append([]gokzg4844.BackfillItem{}, items...)
+	badItems[3].Commitment = gokzg4844.KZGCommitment{}
+	_, err = verifier.Run(context.Background(), &sliceIterator{items: badItems}, nil)
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, 3, batchErr.Index)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPointEncoding)
+
+	// An error from the checkpoint stops the verification
+	errStop := errors.New("stop")
+	progress, err = verifier.Run(context.Background(), &sliceIterator{items: items}, func(gokzg4844.BackfillProgress) error {
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, 1, progress.Batches)
+
+	// As does cancelling the context
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = verifier.Run(cancelled, &sliceIterator{items: items}, nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,31 @@
+package gokzg4844
+
+import "crypto/subtle"
+
+// VerifyBasisConsistency checks that a commitment which was computed from the evaluations of a polynomial, such as
+// the commitment to a blob, and a commitment which was computed from its coefficients, are commitments to the same
+// polynomial.
+//
+// The Lagrange points of the trusted setup are derived from its monomial points, so both are commitments to
+// p(tau) for the same secret tau. The two commitments are therefore the same point if and only if they are for the same
+// polynomial, and no evaluation or opening proofs are needed. This only holds if the monomial commitment was computed
+// with the monomial points of the same trusted setup as this context.
+//
+// Returns [ErrBasisMismatch] if the commitments are not to the same polynomial, or an error if either of them is not
+// a valid commitment.
+func (c *Context) VerifyBasisConsistency(lagrangeCommitment, monomialCommitment KZGCommitment) error {
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+	if _, err := DeserializeKZGCommitment(lagrangeCommitment); err != nil {
+		return err
+	}
+	if _, err := DeserializeKZGCommitment(monomialCommitment); err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(lagrangeCommitment[:], monomialCommitment[:]) != 1 {
+		return ErrBasisMismatch
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package gokzg4844
+
+import (
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/multiexp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBasisConsistency(t *testing.T) {
+	ctx, err := NewContext4096Secure()
+	require.NoError(t, err)
+
+	// p(X) = 3 + 5X + 7X^2
+	coeffs := []fr.Element{fr.NewElement(3), fr.NewElement(5), fr.NewElement(7)}
+
+	// The monomial points [tau^j] are not part of the context, but they can be derived from the Lagrange points,
+	// since X^j = sum_i w_i^j * L_i(X) for j less than the size of the domain.
+	roots := ctx.domain().Roots
+	powers := make([]fr.Element, len(roots))
+	for i := range powers {
+		powers[i].SetOne()
+	}
+	monomialPoints := make([]bls12381.G1Affine, len(coeffs))
+	for j := range monomialPoints {
+		point, err := multiexp.MultiExp(powers, ctx.commitKey().G1, 0)
+		require.NoError(t, err)
+		monomialPoints[j] = *point
+		for i := range powers {
+			powers[i].Mul(&powers[i], &roots[i])
+		}
+	}
+	monomialPoint, err := multiexp.MultiExp(coeffs, monomialPoints, 0)
+	require.NoError(t, err)
+	monomialCommitment := KZGCommitment(SerializeG1Point(*monomialPoint))
+
+	// The blob holds the evaluations of p(X) over the domain
+	evaluations := make([]fr.Element, ScalarsPerBlob)
+	for i := range evaluations {
+		for j := len(coeffs) - 1; j >= 0; j-- {
+			evaluations[i].Mul(&evaluations[i], &roots[i])
+			evaluations[i].Add(&evaluations[i], &coeffs[j])
+		}
+	}
+	lagrangeCommitment, err := ctx.BlobToKZGCommitment(SerializePoly(evaluations), 0)
+	require.NoError(t, err)
+
+	require.NoError(t, ctx.VerifyBasisConsistency(lagrangeCommitment, monomialCommitment))
+
+	// A different polynomial
+	evaluations[0].Add(&evaluations[0], &coeffs[0])
+	otherCommitment, err := ctx.BlobToKZGCommitment(SerializePoly(evaluations), 0)
+	require.NoError(t, err)
+	err = ctx.VerifyBasisConsistency(otherCommitment, monomialCommitment)
+	require.ErrorIs(t, err, ErrBasisMismatch)
+
+	// An invalid commitment
+	err = ctx.VerifyBasisConsistency(KZGCommitment{}, monomialCommitment)
+	require.ErrorIs(t, err, ErrInvalidPointEncoding)
+}
@@ -76,6 +76,13 @@ func Benchmark(b *testing.B) {
 		}
 	})
 
+	b.Run("BlobsToKZGCommitments(count=6)", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_, _ = ctx.BlobsToKZGCommitments(blobs[:6], NumGoRoutines)
+		}
+	})
+
 	b.Run("ComputeKZGProof", func(b *testing.B) {
 		b.ReportAllocs()
 		for n := 0; n < b.N; n++ {
@@ -90,6 +97,13 @@ func Benchmark(b *testing.B) {
 		}
 	})
 
+	b.Run("ComputeBlobKZGProofs(count=6)", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_, _ = ctx.ComputeBlobKZGProofs(blobs[:6], commitments[:6], NumGoRoutines)
+		}
+	})
+
 	b.Run("VerifyKZGProof", func(b *testing.B) {
 		b.ReportAllocs()
 		for n := 0; n < b.N; n++ {
@@ -144,3 +158,27 @@ func BenchmarkDeserializeBlob(b *testing.B) {
 		b.Fatalf("have %s want %s", have, want)
 	}
 }
+
+func BenchmarkDeserializeBlobPar(b *testing.B) {
+	blob := GetRandBlob(int64(13))
+	for _, numGoRoutines := range []int{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("numGoRoutines=%d", numGoRoutines), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				if _, err := gokzg4844.DeserializeBlobPar(blob, numGoRoutines); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkValidateBlobFast(b *testing.B) {
+	blob := GetRandBlob(int64(13))
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		if err := gokzg4844.ValidateBlobFast(blob); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
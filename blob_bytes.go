@@ -0,0 +1,126 @@
+package gokzg4844
+
+import "context"
+
+// BlobFromBytes returns the [Blob] which is backed by the given slice.
+//
+// The returned blob shares its memory with the slice, so no copy is made.
+//
+// Returns [ErrInvalidBlobLength] if the slice does not have the length of a blob.
+//
+// Note: Callers with their own array type for blobs should use [AsBlob] instead.
+func BlobFromBytes(byts []byte) (*Blob, error) {
+	return mainnetPreset.BlobFromBytes(byts)
+}
+
+// BlobBytes is satisfied by any array type with the size of a blob, such as the blob types of other packages.
+type BlobBytes interface {
+	~[ScalarsPerBlob * SerializedScalarSize]byte
+}
+
+// AsBlob returns the blob as a *[Blob], without copying it.
+//
+// Go does not allow the methods of [Context] to be generic, so packages which define their own blob type can use this
+// to call them directly:
+//
+//	commitment, err := ctx.BlobToKZGCommitment(gokzg4844.AsBlob(&myBlob), 0)
+func AsBlob[B BlobBytes](blob *B) *Blob {
+	return (*Blob)((*blob)[:])
+}
+
+// BlobToKZGCommitmentBytes is the same as [Context.BlobToKZGCommitment], except that the blob is given as a slice.
+//
+// Unlike the methods which take a [Blob], this can be used with a context for any [Preset].
+//
+// Returns [ErrInvalidBlobLength] if the slice does not have the length of a blob of the preset of the context.
+func (c *Context) BlobToKZGCommitmentBytes(blob []byte, numGoRoutines int) (KZGCommitment, error) {
+	if err := c.checkOpen(); err != nil {
+		return KZGCommitment{}, err
+	}
+	if err := c.preset.CheckBlobLength(len(blob)); err != nil {
+		return KZGCommitment{}, err
+	}
+	return c.blobToKZGCommitment(context.Background(), blob, numGoRoutines)
+}
+
+// ComputeBlobKZGProofBytes is the same as [Context.ComputeBlobKZGProof], except that the blob is given as a slice.
+//
+// Unlike the methods which take a [Blob], this can be used with a context for any [Preset].
+//
+// Returns [ErrInvalidBlobLength] if the slice does not have the length of a blob of the preset of the context.
+func (c *Context) ComputeBlobKZGProofBytes(blob []byte, blobCommitment KZGCommitment, numGoRoutines int) (KZGProof, error) {
+	if err := c.checkOpen(); err != nil {
+		return KZGProof{}, err
+	}
+	if err := c.preset.CheckBlobLength(len(blob)); err != nil {
+		return KZGProof{}, err
+	}
+	return c.computeBlobKZGProof(context.Background(), blob, blobCommitment, numGoRoutines)
+}
+
+// ComputeKZGProofBytes is the same as [Context.ComputeKZGProof], except that the blob is given as a slice.
+//
+// Unlike the methods which take a [Blob], this can be used with a context for any [Preset].
+//
+// Returns [ErrInvalidBlobLength] if the slice does not have the length of a blob of the preset of the context.
+func (c *Context) ComputeKZGProofBytes(blob []byte, inputPointBytes Scalar, numGoRoutines int) (KZGProof, Scalar, error) {
+	if err := c.checkOpen(); err != nil {
+		return KZGProof{}, Scalar{}, err
+	}
+	if err := c.preset.CheckBlobLength(len(blob)); err != nil {
+		return KZGProof{}, Scalar{}, err
+	}
+	return c.computeKZGProof(context.Background(), blob, inputPointBytes, numGoRoutines)
+}
+
+// VerifyBlobKZGProofBytes is the same as [Context.VerifyBlobKZGProof], except that the blob is given as a slice.
+//
+// Unlike the methods which take a [Blob], this can be used with a context for any [Preset].
+//
+// Returns [ErrInvalidBlobLength] if the slice does not have the length of a blob of the preset of the context.
+func (c *Context) VerifyBlobKZGProofBytes(blob []byte, blobCommitment KZGCommitment, kzgProof KZGProof) error {
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+	if err := c.preset.CheckBlobLength(len(blob)); err != nil {
+		return err
+	}
+	return c.verifyBlobKZGProof(context.Background(), blob, blobCommitment, kzgProof)
+}
+
+// VerifyBlobKZGProofBatchBytes is the same as [Context.VerifyBlobKZGProofBatch], except that the blobs are given as
+// slices.
+//
+// Unlike the methods which take a [Blob], this can be used with a context for any [Preset].
+//
+// Returns a [*BatchVerificationError] wrapping [ErrInvalidBlobLength] if a slice does not have the length of a blob
+// of the preset of the context.
+func (c *Context) VerifyBlobKZGProofBatchBytes(blobs [][]byte, polynomialCommitments []KZGCommitment, kzgProofs []KZGProof) error {
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+	for i := range blobs {
+		if err := c.preset.CheckBlobLength(len(blobs[i])); err != nil {
+			return &BatchVerificationError{Index: i, Err: err}
+		}
+	}
+	return c.verifyBlobKZGProofBatchBytes(context.Background(), blobs, polynomialCommitments, kzgProofs)
+}
+
+// blobPointers returns pointers to each of the blobs, without copying them.
+func blobPointers(blobs []Blob) []*Blob {
+	pointers := make([]*Blob, len(blobs))
+	for i := range blobs {
+		pointers[i] = &blobs[i]
+	}
+	return pointers
+}
+
+// blobSlices returns the blobs as slices, without copying them.
+func blobSlices(blobs []*Blob) [][]byte {
+	slices := make([][]byte, len(blobs))
+	for i := range blobs {
+		slices[i] = blobs[i][:]
+	}
+	return slices
+}
@@ -0,0 +1,59 @@
+package gokzg4844_test
+
+import (
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobBytesVariants(t *testing.T) {
+	blob := GetRandBlob(1)
+	byts := blob[:]
+
+	fromBytes, err := gokzg4844.BlobFromBytes(byts)
+	require.NoError(t, err)
+	require.Same(t, blob, fromBytes)
+
+	commitment, err := ctx.BlobToKZGCommitmentBytes(byts, NumGoRoutines)
+	require.NoError(t, err)
+	expectedCommitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, expectedCommitment, commitment)
+
+	proof, err := ctx.ComputeBlobKZGProofBytes(byts, commitment, NumGoRoutines)
+	require.NoError(t, err)
+	require.NoError(t, ctx.VerifyBlobKZGProofBytes(byts, commitment, proof))
+
+	inputPoint := GetRandFieldElement(2)
+	_, claimedValue, err := ctx.ComputeKZGProofBytes(byts, inputPoint, NumGoRoutines)
+	require.NoError(t, err)
+	_, expectedValue, err := ctx.ComputeKZGProof(blob, inputPoint, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, expectedValue, claimedValue)
+
+	short := byts[:len(byts)-1]
+	_, err = gokzg4844.BlobFromBytes(short)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidBlobLength)
+	_, err = ctx.BlobToKZGCommitmentBytes(short, NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidBlobLength)
+	_, err = ctx.ComputeBlobKZGProofBytes(short, commitment, NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidBlobLength)
+	_, _, err = ctx.ComputeKZGProofBytes(short, inputPoint, NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidBlobLength)
+	err = ctx.VerifyBlobKZGProofBytes(short, commitment, proof)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidBlobLength)
+}
+
+func TestAsBlob(t *testing.T) {
+	type otherBlob [len(gokzg4844.Blob{})]byte
+
+	blob := GetRandBlob(1)
+	other := otherBlob(*blob)
+	converted := gokzg4844.AsBlob(&other)
+	require.Equal(t, blob, converted)
+
+	// No copy is made
+	converted[0] ^= 1
+	require.Equal(t, converted[0], other[0])
+}
@@ -0,0 +1,64 @@
+package gokzg4844
+
+import (
+	"bytes"
+	"math"
+)
+
+// BlobStats describes the content of a blob, as returned by [AnalyzeBlob].
+type BlobStats struct {
+	// ZeroScalars is the number of scalars which are zero, for example because they are padding.
+	ZeroScalars int
+	// TopByteScalars is the number of scalars whose most significant byte is not zero. Encodings which pack 31 bytes
+	// of data into each scalar leave this byte zero, so that the scalars are always canonical; a blob with many such
+	// scalars is at risk of holding non-canonical ones.
+	TopByteScalars int
+	// NonCanonicalScalars is the number of scalars which are not less than [BlsModulus]. The blob is rejected by
+	// the other functions of this package unless this is zero.
+	NonCanonicalScalars int
+	// ByteCounts holds the number of times that each byte value occurs in the blob.
+	ByteCounts [256]int
+	// Entropy is the Shannon entropy of the bytes of the blob, in bits per byte. This is 8 for random data, and
+	// well below that for data which would benefit from compression.
+	Entropy float64
+}
+
+// ZeroFraction returns the fraction of the scalars in the blob which are zero.
+func (s *BlobStats) ZeroFraction() float64 {
+	return float64(s.ZeroScalars) / ScalarsPerBlob
+}
+
+// AnalyzeBlob returns statistics about the content of the blob, for diagnosing how rollups fill their blobs.
+//
+// The statistics are computed in a single pass over the serialized scalars, in the same way as [ValidateBlobFast],
+// without converting them to field elements or allocating. This makes it cheap enough to call on every blob that a
+// node sees, for monitoring.
+func AnalyzeBlob(blob *Blob) BlobStats {
+	var stats BlobStats
+	var zero [SerializedScalarSize]byte
+	for i := 0; i < ScalarsPerBlob; i++ {
+		chunk := blob[i*SerializedScalarSize : (i+1)*SerializedScalarSize]
+		if bytes.Equal(chunk, zero[:]) {
+			stats.ZeroScalars++
+		}
+		if chunk[0] != 0 {
+			stats.TopByteScalars++
+			// Only scalars with a non-zero top byte can be at least the modulus
+			if bytes.Compare(chunk, BlsModulus[:]) >= 0 {
+				stats.NonCanonicalScalars++
+			}
+		}
+	}
+
+	for _, b := range blob {
+		stats.ByteCounts[b]++
+	}
+	for _, count := range stats.ByteCounts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / float64(len(blob))
+		stats.Entropy -= p * math.Log2(p)
+	}
+	return stats
+}
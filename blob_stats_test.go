@@ -0,0 +1,33 @@
+package gokzg4844_test
+
+import (
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeBlob(t *testing.T) {
+	var blob gokzg4844.Blob
+	stats := gokzg4844.AnalyzeBlob(&blob)
+	require.Equal(t, gokzg4844.ScalarsPerBlob, stats.ZeroScalars)
+	require.Equal(t, 1.0, stats.ZeroFraction())
+	require.Equal(t, len(blob), stats.ByteCounts[0])
+	require.Zero(t, stats.Entropy)
+
+	// One scalar uses its top byte, and another is the modulus itself
+	blob[0] = 0x01
+	copy(blob[2*gokzg4844.SerializedScalarSize:], gokzg4844.BlsModulus[:])
+	stats = gokzg4844.AnalyzeBlob(&blob)
+	require.Equal(t, gokzg4844.ScalarsPerBlob-2, stats.ZeroScalars)
+	require.Equal(t, 2, stats.TopByteScalars)
+	require.Equal(t, 1, stats.NonCanonicalScalars)
+	require.ErrorIs(t, gokzg4844.ValidateBlobFast(&blob), gokzg4844.ErrBlobNotCanonical)
+
+	// Random scalars are canonical, use their top byte, and have close to 8 bits of entropy per byte
+	stats = gokzg4844.AnalyzeBlob(GetRandBlob(5))
+	require.Zero(t, stats.ZeroScalars)
+	require.Zero(t, stats.NonCanonicalScalars)
+	require.Greater(t, stats.TopByteScalars, gokzg4844.ScalarsPerBlob*9/10)
+	require.Greater(t, stats.Entropy, 7.9)
+}
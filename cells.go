@@ -0,0 +1,238 @@
+package gokzg4844
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// ComputeCells extends the blob and splits the extended blob into cells.
+//
+// The extended blob is the blob followed by its extension, as returned by [Context.CommitExtensionCoset]. The first
+// half of the cells therefore holds the blob itself. With the default bit-reversed order, this matches the cells
+// returned by [compute_cells] in the spec.
+//
+// [compute_cells]: https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/polynomial-commitments-sampling.md#compute_cells
+func (c *Context) ComputeCells(blob *Blob) (*ExtBlobCells, error) {
+	if err := c.checkTypes(); err != nil {
+		return nil, err
+	}
+	prof := startProfile(context.Background(), "compute_cells", 1)
+	defer prof.end()
+
+	// 1. Deserialization
+	//
+	prof.phase("deserialize")
+	polynomial, err := DeserializeBlob(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Extend the blob
+	//
+	prof.phase("extend")
+	extension := SerializePoly(c.extendPolynomial(polynomial))
+
+	// 3. Split the extended blob into cells
+	//
+	var cells ExtBlobCells
+	for i := 0; i < CellsPerExtBlob/2; i++ {
+		copy(cells[i][:], blob[i*BytesPerCell:(i+1)*BytesPerCell])
+		copy(cells[CellsPerExtBlob/2+i][:], extension[i*BytesPerCell:(i+1)*BytesPerCell])
+	}
+	return &cells, nil
+}
+
+// VerifyCellsWithBlobProof verifies a set of cells of an extended blob against a single proof for the blob, as
+// returned by [Context.ComputeBlobKZGProof].
+//
+// The blob proof opens the polynomial of the blob at a challenge which is derived from the whole blob, so the blob
+// must be known to verify it. If all of the cells in the first half of the extended blob are given, they make up the
+// blob itself. Otherwise, since the extended blob is a Reed-Solomon code with rate one half, the blob is recovered
+// from any half of the cells. The blob is then verified with [Context.VerifyBlobKZGProof], and every given cell is
+// checked against the extension of the blob. Cells may be given in any order, and a cell may be given more than once
+// as long as every copy is the same.
+//
+// It returns:
+//   - [ErrCellBatchLengthCheck] if the number of cells and cell indices differ.
+//   - [ErrInvalidCellIndex] if a cell index is not less than [CellsPerExtBlob].
+//   - [ErrMissingBlobCells] if fewer than half of the cells of the extended blob are given, so that the blob cannot be
+//     recovered.
+//   - [ErrNonCanonicalScalar] if a cell which is needed to recover the blob holds a scalar which is not canonical.
+//   - [ErrCellMismatch] if a cell does not match the blob.
+//   - Any error returned by [Context.VerifyBlobKZGProof].
+func (c *Context) VerifyCellsWithBlobProof(commitment KZGCommitment, cells []Cell, cellIndices []CellIndex, blobProof KZGProof) error {
+	if err := c.checkTypes(); err != nil {
+		return err
+	}
+	prof := startProfile(context.Background(), "verify_cells_with_blob_proof", len(cells))
+	defer prof.end()
+
+	// 1. Check the cell indices
+	//
+	prof.phase("check")
+	if len(cells) != len(cellIndices) {
+		return ErrCellBatchLengthCheck
+	}
+	for _, cellIndex := range cellIndices {
+		if cellIndex >= CellsPerExtBlob {
+			return ErrInvalidCellIndex
+		}
+	}
+
+	// 2. Collect the distinct cells
+	//
+	prof.phase("reassemble")
+	var (
+		extCells ExtBlobCells
+		present  [CellsPerExtBlob]bool
+		numCells int
+	)
+	for i, cellIndex := range cellIndices {
+		if present[cellIndex] {
+			if subtle.ConstantTimeCompare(extCells[cellIndex][:], cells[i][:]) != 1 {
+				return ErrCellMismatch
+			}
+			continue
+		}
+		extCells[cellIndex] = cells[i]
+		present[cellIndex] = true
+		numCells++
+	}
+	if numCells < CellsPerExtBlob/2 {
+		return ErrMissingBlobCells
+	}
+
+	// 3. Reassemble the blob from the first half, or recover it
+	//
+	var blob *Blob
+	if allPresent(present[:CellsPerExtBlob/2]) {
+		blob = new(Blob)
+		for i := 0; i < CellsPerExtBlob/2; i++ {
+			copy(blob[i*BytesPerCell:(i+1)*BytesPerCell], extCells[i][:])
+		}
+	} else {
+		prof.phase("recover")
+		var err error
+		blob, err = c.recoverBlob(&extCells, &present)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 4. Verify the blob proof
+	//
+	if err := c.checkBlobKZGProof(context.Background(), blob[:], commitment, blobProof); err != nil {
+		return err
+	}
+
+	// 5. Check the cells against the extension of the blob
+	//
+	prof.phase("extend")
+	extendedCells, err := c.ComputeCells(blob)
+	if err != nil {
+		return err
+	}
+	for i, cellIndex := range cellIndices {
+		if subtle.ConstantTimeCompare(cells[i][:], extendedCells[cellIndex][:]) != 1 {
+			return ErrCellMismatch
+		}
+	}
+	return nil
+}
+
+// allPresent reports whether every element of present is true.
+func allPresent(present []bool) bool {
+	for _, ok := range present {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// recoveryCosetShift is the shift of the coset that [Context.recoverBlob] divides over. It generates the
+// multiplicative group of the scalar field, as PRIMITIVE_ROOT_OF_UNITY in the specs, so it is not in any power-of-two
+// subgroup.
+const recoveryCosetShift = 7
+
+// recoverBlob returns the blob whose extended blob holds the given cells, where present reports which of the cells
+// are given. At least half of the cells must be given. If the given cells are not all from the same extended blob,
+// some blob is still returned, whose extension does not match all of the cells.
+//
+// The evaluations E of the polynomial p(X) over the domain of the extended blob are known except at the points of
+// the missing cells. With Z(X) the polynomial which vanishes at those points, E * Z is the evaluation form of p * Z
+// over that domain, since Z is zero wherever E is unknown. p * Z has degree less than the size of the domain, so its
+// coefficients are found with an inverse FFT, and p is found by dividing by Z over a coset, where Z is not zero.
+func (c *Context) recoverBlob(cells *ExtBlobCells, present *[CellsPerExtBlob]bool) (*Blob, error) {
+	extDomain := cachedDomain(ScalarsPerExtBlob, c.numGoRoutines).inner
+	n := int(extDomain.Cardinality)
+
+	// 1. Deserialize the given cells, leaving the evaluations at the
+	// missing points as zero, and collect the missing points
+	//
+	blobHalf := make([]fr.Element, ScalarsPerBlob)
+	extensionHalf := make([]fr.Element, ScalarsPerBlob)
+	var missingPoints []fr.Element
+	cellPoints := c.cellPoints()
+	for i := range cells {
+		if !present[i] {
+			missingPoints = append(missingPoints, cellPoints[i]...)
+			continue
+		}
+		half, offset := blobHalf, i*ScalarsPerCell
+		if i >= CellsPerExtBlob/2 {
+			half, offset = extensionHalf, (i-CellsPerExtBlob/2)*ScalarsPerCell
+		}
+		for j := 0; j < ScalarsPerCell; j++ {
+			scalar, err := DeserializeScalar(cells[i].Scalar(j))
+			if err != nil {
+				return nil, err
+			}
+			half[offset+j] = scalar
+		}
+	}
+
+	// 2. Interleave the halves into the natural order of the domain of the
+	// extended blob: the i'th root of the domain of the blob is the 2i'th
+	// root of the extended domain, and the i'th point of the extension coset
+	// is the (2i+1)'th root
+	//
+	blobHalf, extensionHalf = c.toNaturalOrder(blobHalf), c.toNaturalOrder(extensionHalf)
+	evaluations := make([]fr.Element, n)
+	for i := range blobHalf {
+		evaluations[2*i] = blobHalf[i]
+		evaluations[2*i+1] = extensionHalf[i]
+	}
+
+	// 3. Compute the coefficients of p * Z
+	//
+	vanishing := make([]fr.Element, n)
+	copy(vanishing, kzg.VanishingPolynomialOfPoints(missingPoints))
+	vanishingEvals := extDomain.FftFr(vanishing)
+	for i := range evaluations {
+		evaluations[i].Mul(&evaluations[i], &vanishingEvals[i])
+	}
+	productCoeffs := extDomain.IfftFr(evaluations)
+
+	// 4. Divide by Z over a coset of the extended domain
+	//
+	var shift fr.Element
+	shift.SetUint64(recoveryCosetShift)
+	coset, err := kzg.NewCosetDomain(extDomain, shift)
+	if err != nil {
+		return nil, err
+	}
+	productEvals := coset.FftFr(productCoeffs)
+	vanishingEvals = fr.BatchInvert(coset.FftFr(vanishing))
+	for i := range productEvals {
+		productEvals[i].Mul(&productEvals[i], &vanishingEvals[i])
+	}
+	coeffs := coset.IfftFr(productEvals)[:ScalarsPerBlob]
+
+	// 5. Evaluate p over the domain of the blob
+	//
+	return SerializePoly(c.fromNaturalOrder(c.domain().FftFr(coeffs))), nil
+}
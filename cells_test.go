@@ -0,0 +1,123 @@
+package gokzg4844_test
+
+import (
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeCells(t *testing.T) {
+	blob := GetRandBlob(1)
+	cells, err := ctx.ComputeCells(blob)
+	require.NoError(t, err)
+
+	extension, _, err := ctx.CommitExtensionCoset(blob, NumGoRoutines)
+	require.NoError(t, err)
+
+	for i := 0; i < gokzg4844.CellsPerExtBlob/2; i++ {
+		require.Equal(t, blob[i*gokzg4844.BytesPerCell:(i+1)*gokzg4844.BytesPerCell], cells[i][:])
+		require.Equal(t, extension[i*gokzg4844.BytesPerCell:(i+1)*gokzg4844.BytesPerCell], cells[gokzg4844.CellsPerExtBlob/2+i][:])
+	}
+}
+
+func TestVerifyCellsWithBlobProof(t *testing.T) {
+	blob := GetRandBlob(2)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	proof, err := ctx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+	extCells, err := ctx.ComputeCells(blob)
+	require.NoError(t, err)
+
+	allIndices := make([]gokzg4844.CellIndex, gokzg4844.CellsPerExtBlob)
+	for i := range allIndices {
+		allIndices[i] = gokzg4844.CellIndex(i)
+	}
+	require.NoError(t, ctx.VerifyCellsWithBlobProof(commitment, extCells.Cells(), allIndices, proof))
+
+	// The first half, in reverse order, along with a few cells of the extension
+	var (
+		cells   []gokzg4844.Cell
+		indices []gokzg4844.CellIndex
+	)
+	for i := gokzg4844.CellsPerExtBlob/2 - 1; i >= 0; i-- {
+		cells = append(cells, extCells[i])
+		indices = append(indices, gokzg4844.CellIndex(i))
+	}
+	for _, i := range []int{64, 100, 127, 100} {
+		cells = append(cells, extCells[i])
+		indices = append(indices, gokzg4844.CellIndex(i))
+	}
+	require.NoError(t, ctx.VerifyCellsWithBlobProof(commitment, cells, indices, proof))
+
+	// A wrong proof
+	otherBlob := GetRandBlob(3)
+	otherCommitment, err := ctx.BlobToKZGCommitment(otherBlob, NumGoRoutines)
+	require.NoError(t, err)
+	otherProof, err := ctx.ComputeBlobKZGProof(otherBlob, otherCommitment, NumGoRoutines)
+	require.NoError(t, err)
+	err = ctx.VerifyCellsWithBlobProof(commitment, cells, indices, otherProof)
+	require.ErrorIs(t, err, gokzg4844.ErrProofInvalid)
+
+	// A modified cell in the extension
+	modified := append([]gokzg4844.Cell{}, cells...)
+	modified[len(modified)-2][0] ^= 1
+	err = ctx.VerifyCellsWithBlobProof(commitment, modified, indices, proof)
+	require.ErrorIs(t, err, gokzg4844.ErrCellMismatch)
+
+	// A cell of the blob given twice with different contents
+	modified = append(append([]gokzg4844.Cell{}, cells...), extCells[0])
+	modified[len(modified)-1][gokzg4844.BytesPerCell-1] ^= 1
+	err = ctx.VerifyCellsWithBlobProof(commitment, modified, append(indices, 0), proof)
+	require.ErrorIs(t, err, gokzg4844.ErrCellMismatch)
+
+	// A missing cell of the blob is recovered from the others
+	require.NoError(t, ctx.VerifyCellsWithBlobProof(commitment, cells[1:], indices[1:], proof))
+
+	// Invalid indices
+	err = ctx.VerifyCellsWithBlobProof(commitment, cells, indices[1:], proof)
+	require.ErrorIs(t, err, gokzg4844.ErrCellBatchLengthCheck)
+	badIndices := append([]gokzg4844.CellIndex{}, indices...)
+	badIndices[0] = gokzg4844.CellsPerExtBlob
+	err = ctx.VerifyCellsWithBlobProof(commitment, cells, badIndices, proof)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidCellIndex)
+}
+
+func TestVerifyCellsWithBlobProofRecovers(t *testing.T) {
+	blob := GetRandBlob(4)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	proof, err := ctx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+	extCells, err := ctx.ComputeCells(blob)
+	require.NoError(t, err)
+
+	// Every other cell, which is half of the cells and misses half of the blob
+	var (
+		cells   []gokzg4844.Cell
+		indices []gokzg4844.CellIndex
+	)
+	for i := 1; i < gokzg4844.CellsPerExtBlob; i += 2 {
+		cells = append(cells, extCells[i])
+		indices = append(indices, gokzg4844.CellIndex(i))
+	}
+	require.NoError(t, ctx.VerifyCellsWithBlobProof(commitment, cells, indices, proof))
+
+	// Only the extension
+	cells, indices = cells[:0], indices[:0]
+	for i := gokzg4844.CellsPerExtBlob / 2; i < gokzg4844.CellsPerExtBlob; i++ {
+		cells = append(cells, extCells[i])
+		indices = append(indices, gokzg4844.CellIndex(i))
+	}
+	require.NoError(t, ctx.VerifyCellsWithBlobProof(commitment, cells, indices, proof))
+
+	// A modified cell is not matched by the recovered blob
+	cells[5][gokzg4844.BytesPerCell-1] ^= 1
+	err = ctx.VerifyCellsWithBlobProof(commitment, cells, indices, proof)
+	require.ErrorIs(t, err, gokzg4844.ErrProofInvalid)
+
+	// Fewer than half of the cells
+	err = ctx.VerifyCellsWithBlobProof(commitment, cells[1:], indices[1:], proof)
+	require.ErrorIs(t, err, gokzg4844.ErrMissingBlobCells)
+}
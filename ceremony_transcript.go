@@ -0,0 +1,235 @@
+package gokzg4844
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/utils"
+)
+
+// CeremonyTranscript is the output of the [Ethereum KZG ceremony], as published in its transcript.json file.
+//
+// [Ethereum KZG ceremony]: https://github.com/ethereum/kzg-ceremony-specs
+type CeremonyTranscript struct {
+	Transcripts []SubCeremonyTranscript `json:"transcripts"`
+}
+
+// SubCeremonyTranscript is the transcript of one of the sub-ceremonies, each of which produced a setup of a
+// different size.
+type SubCeremonyTranscript struct {
+	NumG1Powers int `json:"numG1Powers"`
+	NumG2Powers int `json:"numG2Powers"`
+	PowersOfTau struct {
+		G1Powers []G1CompressedHexStr `json:"G1Powers"`
+		G2Powers []G2CompressedHexStr `json:"G2Powers"`
+	} `json:"powersOfTau"`
+	Witness struct {
+		RunningProducts []G1CompressedHexStr `json:"runningProducts"`
+		PotPubkeys      []G2CompressedHexStr `json:"potPubkeys"`
+	} `json:"witness"`
+}
+
+// ParseCeremonyTranscript reads a transcript of the Ethereum KZG ceremony, checks it, and returns the trusted setup
+// with [ScalarsPerBlob] G1 points, which can be passed to [NewContext4096].
+//
+// The sub-ceremony with [ScalarsPerBlob] G1 powers is checked as described in the [ceremony specs]:
+//   - All of the points are in the correct subgroup.
+//   - The witness is a chain of contributions: each running product is the previous one multiplied by the secret of
+//     the participant whose pot pubkey is at the same position, starting at the generator and ending at the
+//     first power of tau.
+//   - The G1 and G2 powers are successive powers of the same secret, starting at the generators.
+//
+// The BLS signatures which bind the contributions to the identities of the participants are not checked.
+//
+// The transcript only holds the G1 powers in monomial form, which are converted to Lagrange form; this takes a few
+// seconds. Returns an error wrapping [ErrInvalidTranscript] if the transcript does not pass the checks.
+//
+// [ceremony specs]: https://github.com/ethereum/kzg-ceremony-specs/blob/master/docs/sequencer/sequencer.md
+func ParseCeremonyTranscript(r io.Reader) (*JSONTrustedSetup, error) {
+	var transcript CeremonyTranscript
+	if err := json.NewDecoder(r).Decode(&transcript); err != nil {
+		return nil, err
+	}
+
+	for i := range transcript.Transcripts {
+		if transcript.Transcripts[i].NumG1Powers == ScalarsPerBlob {
+			return transcript.Transcripts[i].TrustedSetup()
+		}
+	}
+	return nil, fmt.Errorf("%w: no sub-ceremony with %d G1 powers", ErrInvalidTranscript, ScalarsPerBlob)
+}
+
+// TrustedSetup checks the sub-ceremony, as described in [ParseCeremonyTranscript], and returns its trusted setup.
+//
+// Returns an error wrapping [ErrInvalidTranscript] if the sub-ceremony does not have [ScalarsPerBlob] G1 powers or does
+// not pass the checks.
+func (t *SubCeremonyTranscript) TrustedSetup() (*JSONTrustedSetup, error) {
+	// 1. Check the sizes
+	//
+	if t.NumG1Powers != ScalarsPerBlob || len(t.PowersOfTau.G1Powers) != t.NumG1Powers {
+		return nil, fmt.Errorf("%w: expected %d G1 powers", ErrInvalidTranscript, ScalarsPerBlob)
+	}
+	if t.NumG2Powers < 2 || len(t.PowersOfTau.G2Powers) != t.NumG2Powers {
+		return nil, fmt.Errorf("%w: expected %d G2 powers, and at least 2", ErrInvalidTranscript, t.NumG2Powers)
+	}
+	if len(t.Witness.RunningProducts) != len(t.Witness.PotPubkeys)+1 {
+		return nil, fmt.Errorf("%w: expected one more running product than pot pubkeys", ErrInvalidTranscript)
+	}
+
+	// 2. Deserialize the points, which checks that they are in the correct subgroup
+	//
+	g1Powers, err := parseTranscriptPoints("G1 power", t.PowersOfTau.G1Powers, (*bls12381.G1Affine).SetBytes)
+	if err != nil {
+		return nil, err
+	}
+	g2Powers, err := parseTranscriptPoints("G2 power", t.PowersOfTau.G2Powers, (*bls12381.G2Affine).SetBytes)
+	if err != nil {
+		return nil, err
+	}
+	runningProducts, err := parseTranscriptPoints("running product", t.Witness.RunningProducts, (*bls12381.G1Affine).SetBytes)
+	if err != nil {
+		return nil, err
+	}
+	potPubkeys, err := parseTranscriptPoints("pot pubkey", t.Witness.PotPubkeys, (*bls12381.G2Affine).SetBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Check the witness
+	//
+	_, _, genG1, genG2 := bls12381.Generators()
+	if !runningProducts[0].Equal(&genG1) {
+		return nil, fmt.Errorf("%w: the first running product is not the generator", ErrInvalidTranscript)
+	}
+	for i := range potPubkeys {
+		// e(runningProducts[i+1], G2) == e(runningProducts[i], potPubkeys[i])
+		ok, err := pairingsEqual(runningProducts[i+1], genG2, runningProducts[i], potPubkeys[i])
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: contribution %d does not extend the running product", ErrInvalidTranscript, i)
+		}
+	}
+	if !runningProducts[len(runningProducts)-1].Equal(&g1Powers[1]) {
+		return nil, fmt.Errorf("%w: the last running product is not the first G1 power", ErrInvalidTranscript)
+	}
+
+	// 4. Check that the points are successive powers of tau
+	//
+	if err := checkPowersOfTau(g1Powers, g2Powers, ErrInvalidTranscript); err != nil {
+		return nil, err
+	}
+
+	// 5. Convert the G1 powers to Lagrange form
+	//
+	domain := cachedDomain(ScalarsPerBlob, 0).inner
+	lagrange := domain.IfftG1(g1Powers)
+
+	var setup JSONTrustedSetup
+	for i := range lagrange {
+		point := lagrange[i].Bytes()
+		setup.SetupG1Lagrange[i] = "0x" + hex.EncodeToString(point[:])
+	}
+	setup.SetupG2 = make([]G2CompressedHexStr, len(t.PowersOfTau.G2Powers))
+	for i, point := range t.PowersOfTau.G2Powers {
+		setup.SetupG2[i] = strings.ToLower(point)
+	}
+	return &setup, nil
+}
+
+// checkPowersOfTau checks that the G1 and G2 points are [tau^i]G1 and [tau^i]H for some tau, starting at the
+// generators, and that tau is not zero or one. Errors which describe a failed check wrap invalid.
+//
+// The successive pairs of G1 points are checked at once, by checking that a random linear combination of them
+// satisfies e(sum r^i * g1[i+1], H) == e(sum r^i * g1[i], [tau]H); the G2 points are checked in the same way.
+func checkPowersOfTau(g1Powers []bls12381.G1Affine, g2Powers []bls12381.G2Affine, invalid error) error {
+	_, _, genG1, genG2 := bls12381.Generators()
+	if !g1Powers[0].Equal(&genG1) || !g2Powers[0].Equal(&genG2) {
+		return fmt.Errorf("%w: the powers do not start at the generators", invalid)
+	}
+	if g1Powers[1].IsInfinity() || g1Powers[1].Equal(&genG1) {
+		return fmt.Errorf("%w: the secret is zero or one", invalid)
+	}
+
+	// [tau]G1 and [tau]H are for the same tau
+	ok, err := pairingsEqual(g1Powers[1], genG2, genG1, g2Powers[1])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: the first G1 and G2 powers are not for the same secret", invalid)
+	}
+
+	var r fr.Element
+	if _, err := r.SetRandom(); err != nil {
+		return err
+	}
+	config := ecc.MultiExpConfig{}
+
+	n, m := len(g1Powers)-1, len(g2Powers)-1
+	powers := utils.ComputePowers(r, uint(n))
+	if m > n {
+		powers = utils.ComputePowers(r, uint(m))
+	}
+	var g1Low, g1High bls12381.G1Affine
+	if _, err := g1Low.MultiExp(g1Powers[:n], powers[:n], config); err != nil {
+		return err
+	}
+	if _, err := g1High.MultiExp(g1Powers[1:], powers[:n], config); err != nil {
+		return err
+	}
+	ok, err = pairingsEqual(g1High, genG2, g1Low, g2Powers[1])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: the G1 powers are not successive powers", invalid)
+	}
+
+	var g2Low, g2High bls12381.G2Affine
+	if _, err := g2Low.MultiExp(g2Powers[:m], powers[:m], config); err != nil {
+		return err
+	}
+	if _, err := g2High.MultiExp(g2Powers[1:], powers[:m], config); err != nil {
+		return err
+	}
+	ok, err = pairingsEqual(genG1, g2High, g1Powers[1], g2Low)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: the G2 powers are not successive powers", invalid)
+	}
+
+	return nil
+}
+
+// pairingsEqual returns whether e(a1, b1) == e(a2, b2).
+func pairingsEqual(a1 bls12381.G1Affine, b1 bls12381.G2Affine, a2 bls12381.G1Affine, b2 bls12381.G2Affine) (bool, error) {
+	var negA2 bls12381.G1Affine
+	negA2.Neg(&a2)
+	return bls12381.PairingCheck([]bls12381.G1Affine{a1, negA2}, []bls12381.G2Affine{b1, b2})
+}
+
+// parseTranscriptPoints deserializes hex-encoded points with setBytes, which is the SetBytes method of the type of the
+// points, such as (*bls12381.G1Affine).SetBytes, and so checks that they are in the correct subgroup.
+func parseTranscriptPoints[T any](name string, hexStrings []string, setBytes func(*T, []byte) (int, error)) ([]T, error) {
+	points := make([]T, len(hexStrings))
+	for i, hexString := range hexStrings {
+		byts, err := hex.DecodeString(strings.TrimPrefix(hexString, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %d is not hex-encoded", ErrInvalidTranscript, name, i)
+		}
+		if _, err := setBytes(&points[i], byts); err != nil {
+			return nil, fmt.Errorf("%w: %s %d: %v", ErrInvalidTranscript, name, i, err)
+		}
+	}
+	return points, nil
+}
@@ -0,0 +1,126 @@
+package gokzg4844_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+// insecureSubCeremony returns the transcript of a sub-ceremony with the given number of powers, in which each
+// participant contributed one of the secrets.
+func insecureSubCeremony(numG1Powers, numG2Powers int, secrets []int64) gokzg4844.SubCeremonyTranscript {
+	_, _, genG1, genG2 := bls12381.Generators()
+
+	var transcript gokzg4844.SubCeremonyTranscript
+	transcript.NumG1Powers = numG1Powers
+	transcript.NumG2Powers = numG2Powers
+
+	tau := big.NewInt(1)
+	runningProduct := genG1
+	transcript.Witness.RunningProducts = append(transcript.Witness.RunningProducts, hexG1(runningProduct))
+	for _, secret := range secrets {
+		secretBI := big.NewInt(secret)
+		tau.Mul(tau, secretBI)
+
+		var potPubkey bls12381.G2Affine
+		potPubkey.ScalarMultiplication(&genG2, secretBI)
+		runningProduct.ScalarMultiplication(&runningProduct, secretBI)
+		transcript.Witness.PotPubkeys = append(transcript.Witness.PotPubkeys, hexG2(potPubkey))
+		transcript.Witness.RunningProducts = append(transcript.Witness.RunningProducts, hexG1(runningProduct))
+	}
+
+	g1Power, g2Power := genG1, genG2
+	for i := 0; i < numG1Powers; i++ {
+		transcript.PowersOfTau.G1Powers = append(transcript.PowersOfTau.G1Powers, hexG1(g1Power))
+		g1Power.ScalarMultiplication(&g1Power, tau)
+	}
+	for i := 0; i < numG2Powers; i++ {
+		transcript.PowersOfTau.G2Powers = append(transcript.PowersOfTau.G2Powers, hexG2(g2Power))
+		g2Power.ScalarMultiplication(&g2Power, tau)
+	}
+	return transcript
+}
+
+func hexG1(point bls12381.G1Affine) string {
+	byts := point.Bytes()
+	return "0x" + hex.EncodeToString(byts[:])
+}
+
+func hexG2(point bls12381.G2Affine) string {
+	byts := point.Bytes()
+	return "0x" + hex.EncodeToString(byts[:])
+}
+
+func TestParseCeremonyTranscript(t *testing.T) {
+	transcript := gokzg4844.CeremonyTranscript{
+		Transcripts: []gokzg4844.SubCeremonyTranscript{
+			insecureSubCeremony(8, 3, []int64{3, 5}),
+			insecureSubCeremony(gokzg4844.ScalarsPerBlob, 65, []int64{3, 5}),
+		},
+	}
+	encoded, err := json.Marshal(transcript)
+	require.NoError(t, err)
+
+	setup, err := gokzg4844.ParseCeremonyTranscript(bytes.NewReader(encoded))
+	require.NoError(t, err)
+	require.NoError(t, gokzg4844.CheckTrustedSetupIsWellFormed(setup))
+
+	// The setup is usable, and its Lagrange points are consistent with its G2 points
+	insecureCtx, err := gokzg4844.NewContext4096(setup)
+	require.NoError(t, err)
+	blob := GetRandBlob(7)
+	commitment, err := insecureCtx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	proof, err := insecureCtx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+	require.NoError(t, insecureCtx.VerifyBlobKZGProof(blob, commitment, proof))
+}
+
+func TestParseCeremonyTranscriptErrors(t *testing.T) {
+	valid := insecureSubCeremony(gokzg4844.ScalarsPerBlob, 5, []int64{3, 5})
+	_, _, genG1, _ := bls12381.Generators()
+
+	tests := map[string]func(*gokzg4844.SubCeremonyTranscript){
+		"wrong size": func(transcript *gokzg4844.SubCeremonyTranscript) {
+			transcript.NumG1Powers = 8
+		},
+		"missing running product": func(transcript *gokzg4844.SubCeremonyTranscript) {
+			transcript.Witness.RunningProducts = transcript.Witness.RunningProducts[:2]
+		},
+		"invalid point": func(transcript *gokzg4844.SubCeremonyTranscript) {
+			transcript.PowersOfTau.G1Powers[3] = "0x1234"
+		},
+		"wrong pot pubkey": func(transcript *gokzg4844.SubCeremonyTranscript) {
+			transcript.Witness.PotPubkeys[0], transcript.Witness.PotPubkeys[1] = transcript.Witness.PotPubkeys[1], transcript.Witness.PotPubkeys[0]
+		},
+		"wrong G1 power": func(transcript *gokzg4844.SubCeremonyTranscript) {
+			transcript.PowersOfTau.G1Powers[100] = hexG1(genG1)
+		},
+		"wrong G2 power": func(transcript *gokzg4844.SubCeremonyTranscript) {
+			transcript.PowersOfTau.G2Powers[3] = transcript.PowersOfTau.G2Powers[2]
+		},
+	}
+	for name, modify := range tests {
+		transcript := valid
+		transcript.PowersOfTau.G1Powers = append([]string(nil), valid.PowersOfTau.G1Powers...)
+		transcript.PowersOfTau.G2Powers = append([]string(nil), valid.PowersOfTau.G2Powers...)
+		transcript.Witness.RunningProducts = append([]string(nil), valid.Witness.RunningProducts...)
+		transcript.Witness.PotPubkeys = append([]string(nil), valid.Witness.PotPubkeys...)
+		modify(&transcript)
+
+		_, err := transcript.TrustedSetup()
+		require.ErrorIs(t, err, gokzg4844.ErrInvalidTranscript, name)
+	}
+
+	// No sub-ceremony of the right size
+	encoded, err := json.Marshal(gokzg4844.CeremonyTranscript{})
+	require.NoError(t, err)
+	_, err = gokzg4844.ParseCeremonyTranscript(bytes.NewReader(encoded))
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidTranscript)
+}
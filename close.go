@@ -0,0 +1,50 @@
+package gokzg4844
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// Close releases the memory held by the context: the trusted setup, the domain, the monomial points and the
+// precomputed table, which together take tens of megabytes. It also stops the workers started by [WithLatencyMode].
+// This lets short-lived processes and tests release the memory deterministically, rather than when the context is
+// garbage collected.
+//
+// Once the context is closed, all of its methods which return an error return [ErrContextClosed], and the others
+// return nil or do nothing; objects which were created from the context, such as an [AccumulatingVerifier], behave in
+// the same way. A table that was memory mapped with [Context.LoadPrecomputeMmap] stays mapped, since the mapping is
+// kept for the lifetime of the process.
+//
+// Close must not be called concurrently with other methods of the context, or of the objects created from it.
+// Calling Close more than once does nothing. It returns [ErrSharedContext], and does nothing, for the context returned
+// by [SharedContext4096Secure], and nil otherwise.
+func (c *Context) Close() error {
+	if c.shared {
+		return ErrSharedContext
+	}
+	if !atomic.CompareAndSwapInt32(&c.closed, 0, 1) {
+		return nil
+	}
+
+	// Make sure that neither the setup nor the monomial points are loaded again
+	c.lazy.once.Do(func() {})
+	c.lazy.load = nil
+	c.lazy.setup = nil
+	c.monomial.once.Do(func() {})
+	c.monomial.g1 = nil
+
+	if c.latencyPool != nil {
+		c.latencyPool.Close()
+		c.latencyPool = nil
+		runtime.SetFinalizer(c, nil)
+	}
+	return nil
+}
+
+// checkOpen returns [ErrContextClosed] if the context has been closed with [Context.Close].
+func (c *Context) checkOpen() error {
+	if atomic.LoadInt32(&c.closed) != 0 {
+		return ErrContextClosed
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package gokzg4844_test
+
+import (
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClose(t *testing.T) {
+	blob := GetRandBlob(31)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	z := GetRandFieldElement(32)
+	proof, y, err := ctx.ComputeKZGProof(blob, z, NumGoRoutines)
+	require.NoError(t, err)
+
+	for _, opts := range [][]gokzg4844.Option{nil, {gokzg4844.WithLatencyMode()}, {gokzg4844.WithLazyInit(true)}} {
+		closedCtx, err := gokzg4844.NewContext4096Secure(opts...)
+		require.NoError(t, err)
+		verifier := closedCtx.NewAccumulatingVerifier()
+		require.NoError(t, verifier.Add(commitment, proof, z, y))
+
+		require.NoError(t, closedCtx.Close())
+		// Closing again does nothing
+		require.NoError(t, closedCtx.Close())
+
+		_, err = closedCtx.BlobToKZGCommitment(blob, NumGoRoutines)
+		require.ErrorIs(t, err, gokzg4844.ErrContextClosed)
+		_, err = closedCtx.BlobToKZGCommitmentBytes(blob[:], NumGoRoutines)
+		require.ErrorIs(t, err, gokzg4844.ErrContextClosed)
+		_, err = closedCtx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+		require.ErrorIs(t, err, gokzg4844.ErrContextClosed)
+		require.ErrorIs(t, closedCtx.VerifyKZGProof(commitment, z, y, proof), gokzg4844.ErrContextClosed)
+		require.ErrorIs(t, verifier.Finalize(), gokzg4844.ErrContextClosed)
+		require.Nil(t, closedCtx.TrustedSetup())
+		require.Nil(t, closedCtx.MonomialG1Points())
+		require.ErrorIs(t, closedCtx.Warm(), gokzg4844.ErrContextClosed)
+	}
+}
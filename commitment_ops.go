@@ -0,0 +1,132 @@
+package gokzg4844
+
+import (
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+	"github.com/crate-crypto/go-kzg-4844/internal/multiexp"
+)
+
+// Add returns the commitment to the sum of the polynomials with the two commitments. Commitments are additively
+// homomorphic, so this is the commitment that [Context.BlobToKZGCommitment] returns for the blob whose scalars are
+// the sums of the scalars of the two blobs.
+//
+// The result is in the canonical compressed encoding. It returns an error if either commitment is not a valid
+// encoding of a point in the G1 subgroup, as checked by [DeserializeKZGCommitment].
+func (commitment KZGCommitment) Add(other KZGCommitment) (KZGCommitment, error) {
+	a, err := DeserializeKZGCommitment(commitment)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	b, err := DeserializeKZGCommitment(other)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+
+	var sum bls12381.G1Affine
+	sum.Add(&a, &b)
+	return KZGCommitment(SerializeG1Point(sum)), nil
+}
+
+// ScalarMul returns the commitment to the polynomial with the commitment multiplied by the scalar, which is the
+// commitment to the blob whose scalars are each multiplied by it.
+//
+// The result is in the canonical compressed encoding. It returns an error if the commitment is not a valid encoding
+// of a point in the G1 subgroup, or if the scalar is not canonical.
+func (commitment KZGCommitment) ScalarMul(scalar Scalar) (KZGCommitment, error) {
+	point, err := DeserializeKZGCommitment(commitment)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	s, err := DeserializeScalar(scalar)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+
+	var sBigInt big.Int
+	s.BigInt(&sBigInt)
+	var product bls12381.G1Affine
+	product.ScalarMultiplication(&point, &sBigInt)
+	return KZGCommitment(SerializeG1Point(product)), nil
+}
+
+// LinearCombination returns the commitment to sum_i scalars[i] * p_i(X), where p_i is the polynomial with the i'th
+// commitment, using a multi exponentiation. No commitments give the commitment to the zero polynomial, ie
+// [PointAtInfinity].
+//
+// The result is in the canonical compressed encoding. It returns [ErrCombinationLengthCheck] if the number of
+// commitments and scalars differ, and an error if a commitment is not a valid encoding of a point in the G1 subgroup,
+// or if a scalar is not canonical.
+func LinearCombination(commitments []KZGCommitment, scalars []Scalar) (KZGCommitment, error) {
+	if len(commitments) != len(scalars) {
+		return KZGCommitment{}, ErrCombinationLengthCheck
+	}
+	if len(commitments) == 0 {
+		return PointAtInfinity, nil
+	}
+
+	points := make([]bls12381.G1Affine, len(commitments))
+	elements := make([]fr.Element, len(scalars))
+	for i := range commitments {
+		var err error
+		points[i], err = DeserializeKZGCommitment(commitments[i])
+		if err != nil {
+			return KZGCommitment{}, err
+		}
+		elements[i], err = DeserializeScalar(scalars[i])
+		if err != nil {
+			return KZGCommitment{}, err
+		}
+	}
+
+	combination, err := multiexp.MultiExp(elements, points, 0)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	return KZGCommitment(SerializeG1Point(*combination)), nil
+}
+
+// Fold combines claims that the polynomial with the i'th commitment takes the i'th evaluation, using the caller's
+// factors: it returns sum_i factors[i] * commitments[i] and sum_i factors[i] * evaluations[i]. This is the step with
+// which batch verification, such as [Context.VerifyBlobKZGProofBatch], combines many proofs into one check, for
+// callers who build their own batch verification.
+//
+// The folded claim only implies the original claims if the factors are unpredictable to whoever made the claims, for
+// example the powers of a random challenge or of a hash of all of the claims. No claims give [PointAtInfinity] and
+// the zero scalar.
+//
+// The commitment is in the canonical compressed encoding. It returns [ErrCombinationLengthCheck] if the three slices
+// do not have the same length, and an error if a commitment is not a valid encoding of a point in the G1 subgroup, or
+// if a scalar is not canonical.
+func Fold(commitments []KZGCommitment, evaluations, factors []Scalar) (KZGCommitment, Scalar, error) {
+	if len(evaluations) != len(commitments) || len(factors) != len(commitments) {
+		return KZGCommitment{}, Scalar{}, ErrCombinationLengthCheck
+	}
+
+	points := make([]kzg.Commitment, len(commitments))
+	evaluationElements := make([]fr.Element, len(evaluations))
+	factorElements := make([]fr.Element, len(factors))
+	for i := range commitments {
+		var err error
+		points[i], err = DeserializeKZGCommitment(commitments[i])
+		if err != nil {
+			return KZGCommitment{}, Scalar{}, err
+		}
+		evaluationElements[i], err = DeserializeScalar(evaluations[i])
+		if err != nil {
+			return KZGCommitment{}, Scalar{}, err
+		}
+		factorElements[i], err = DeserializeScalar(factors[i])
+		if err != nil {
+			return KZGCommitment{}, Scalar{}, err
+		}
+	}
+
+	foldedCommitment, foldedEvaluation, err := kzg.Fold(points, evaluationElements, factorElements, 0)
+	if err != nil {
+		return KZGCommitment{}, Scalar{}, err
+	}
+	return KZGCommitment(SerializeG1Point(foldedCommitment)), SerializeScalar(foldedEvaluation), nil
+}
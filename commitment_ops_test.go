@@ -0,0 +1,114 @@
+package gokzg4844_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitmentOperations(t *testing.T) {
+	blobs := []*gokzg4844.Blob{GetRandBlob(61), GetRandBlob(62)}
+	polys := make([]gokzg4844.Polynomial, len(blobs))
+	commitments := make([]gokzg4844.KZGCommitment, len(blobs))
+	for i := range blobs {
+		var err error
+		polys[i], err = gokzg4844.DeserializeBlob(blobs[i])
+		require.NoError(t, err)
+		commitments[i], err = ctx.BlobToKZGCommitment(blobs[i], NumGoRoutines)
+		require.NoError(t, err)
+	}
+	scalars := []gokzg4844.Scalar{GetRandFieldElement(63), GetRandFieldElement(64)}
+	s0, err := gokzg4844.DeserializeScalar(scalars[0])
+	require.NoError(t, err)
+	s1, err := gokzg4844.DeserializeScalar(scalars[1])
+	require.NoError(t, err)
+
+	// commitToCombination commits to the blob holding a*p_0 + b*p_1
+	commitToCombination := func(a, b fr.Element) gokzg4844.KZGCommitment {
+		combined := make(gokzg4844.Polynomial, gokzg4844.ScalarsPerBlob)
+		for j := range combined {
+			var term fr.Element
+			combined[j].Mul(&polys[0][j], &a)
+			term.Mul(&polys[1][j], &b)
+			combined[j].Add(&combined[j], &term)
+		}
+		blob, err := combined.ToBlob()
+		require.NoError(t, err)
+		commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+		require.NoError(t, err)
+		return commitment
+	}
+
+	sum, err := commitments[0].Add(commitments[1])
+	require.NoError(t, err)
+	require.Equal(t, commitToCombination(fr.One(), fr.One()), sum)
+
+	product, err := commitments[0].ScalarMul(scalars[0])
+	require.NoError(t, err)
+	require.Equal(t, commitToCombination(s0, fr.Element{}), product)
+
+	combination, err := gokzg4844.LinearCombination(commitments, scalars)
+	require.NoError(t, err)
+	require.Equal(t, commitToCombination(s0, s1), combination)
+
+	empty, err := gokzg4844.LinearCombination(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.KZGCommitment(gokzg4844.PointAtInfinity), empty)
+	zero, err := commitments[0].ScalarMul(gokzg4844.Scalar{})
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.KZGCommitment(gokzg4844.PointAtInfinity), zero)
+
+	_, err = gokzg4844.LinearCombination(commitments, scalars[:1])
+	require.ErrorIs(t, err, gokzg4844.ErrCombinationLengthCheck)
+	_, err = commitments[0].ScalarMul(gokzg4844.Scalar{0xff})
+	require.ErrorIs(t, err, gokzg4844.ErrNonCanonicalScalar)
+	_, err = commitments[0].Add(gokzg4844.KZGCommitment{})
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPointEncoding)
+}
+
+func TestFold(t *testing.T) {
+	// Proofs for several blobs at the same point fold into a single proof
+	z := GetRandFieldElement(71)
+	var commitments []gokzg4844.KZGCommitment
+	var proofs []gokzg4844.KZGCommitment
+	var values, factors []gokzg4844.Scalar
+	for i := int64(0); i < 3; i++ {
+		blob := GetRandBlob(72 + i)
+		commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+		require.NoError(t, err)
+		proof, y, err := ctx.ComputeKZGProof(blob, z, NumGoRoutines)
+		require.NoError(t, err)
+		commitments = append(commitments, commitment)
+		proofs = append(proofs, gokzg4844.KZGCommitment(proof))
+		values = append(values, y)
+		factors = append(factors, GetRandFieldElement(80+i))
+	}
+
+	foldedCommitment, foldedValue, err := gokzg4844.Fold(commitments, values, factors)
+	require.NoError(t, err)
+	combination, err := gokzg4844.LinearCombination(commitments, factors)
+	require.NoError(t, err)
+	require.Equal(t, combination, foldedCommitment)
+
+	foldedProof, err := gokzg4844.LinearCombination(proofs, factors)
+	require.NoError(t, err)
+	require.NoError(t, ctx.VerifyKZGProof(foldedCommitment, z, foldedValue, gokzg4844.KZGProof(foldedProof)))
+
+	// Folding with other factors gives another claim
+	factors[0] = GetRandFieldElement(90)
+	_, otherValue, err := gokzg4844.Fold(commitments, values, factors)
+	require.NoError(t, err)
+	require.ErrorIs(t, ctx.VerifyKZGProof(foldedCommitment, z, otherValue, gokzg4844.KZGProof(foldedProof)), gokzg4844.ErrProofInvalid)
+
+	emptyCommitment, emptyValue, err := gokzg4844.Fold(nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.KZGCommitment(gokzg4844.PointAtInfinity), emptyCommitment)
+	require.Equal(t, gokzg4844.Scalar{}, emptyValue)
+
+	_, _, err = gokzg4844.Fold(commitments, values[:2], factors)
+	require.ErrorIs(t, err, gokzg4844.ErrCombinationLengthCheck)
+	_, _, err = gokzg4844.Fold(commitments, values, []gokzg4844.Scalar{factors[0], factors[1], {0xff}})
+	require.ErrorIs(t, err, gokzg4844.ErrNonCanonicalScalar)
+}
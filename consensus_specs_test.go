@@ -343,12 +343,12 @@ func TestVerifyBlobKZGProofBatch(t *testing.T) {
 			// Test specifically distinguish between the test failing
 			// because of the pairing check and failing because of
 			// validation errors
-			if err != nil && err != kzg.ErrVerifyOpeningProof {
+			if err != nil && !errors.Is(err, kzg.ErrVerifyOpeningProof) {
 				require.False(t, testCaseValid)
 			} else {
 				// Either the error is nil or it is a verification error
 				expectedOutput := *test.ProofIsValid
-				gotOutput := err != kzg.ErrVerifyOpeningProof
+				gotOutput := !errors.Is(err, kzg.ErrVerifyOpeningProof)
 				require.Equal(t, expectedOutput, gotOutput)
 			}
 		})
@@ -0,0 +1,87 @@
+package gokzg4844
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// cosetCache holds the precomputed values needed to move between the coefficient form of a polynomial and its
+// evaluations over a coset `shift * H` of the domain H.
+//
+// Extending a blob, recovering a blob and committing to the extension all need the same twiddle factors, so the
+// [Context] owns a single copy of them instead of each feature computing its own. The values are computed lazily,
+// the first time that they are needed.
+//
+// Evaluations are always given and returned in natural order; callers are responsible for applying
+// the bit-reversal permutation where needed.
+type cosetCache struct {
+	// size is the number of bytes used by the cache once it has been
+	// initialized. It is accessed atomically so that the memory usage can be
+	// queried while another go-routine is initializing the cache.
+	//
+	// Note: This is the first field, so that it is 64-bit aligned on 32-bit platforms.
+	size int64
+
+	once sync.Once
+
+	domain *kzg.Domain
+	shift  fr.Element
+
+	// coset holds the precomputed values. It is nil until the cache is initialized.
+	coset *kzg.CosetDomain
+}
+
+// newCosetCache returns a cache for the coset `shift * H` where H is the multiplicative subgroup of the domain. The
+// shift must not be zero or in H.
+//
+// This method does not compute anything; the values are computed on first use.
+func newCosetCache(domain *kzg.Domain, shift fr.Element) *cosetCache {
+	return &cosetCache{
+		domain: domain,
+		shift:  shift,
+	}
+}
+
+// newExtensionCosetCache returns a cache for the coset which, together with the domain, makes up the domain of twice
+// the size. This is the coset that the second half of an extended blob is evaluated over.
+func newExtensionCosetCache(domain *kzg.Domain) *cosetCache {
+	return newCosetCache(domain, kzg.PrimitiveRootOfUnity(2*domain.Cardinality))
+}
+
+// init computes the values stored in the cache, if they have not been computed already.
+func (cc *cosetCache) init() {
+	cc.once.Do(func() {
+		coset, err := kzg.NewCosetDomain(cc.domain, cc.shift)
+		if err != nil {
+			// The shift was checked by the caller of newCosetCache
+			panic(err)
+		}
+		cc.coset = coset
+
+		atomic.StoreInt64(&cc.size, int64(len(coset.ShiftPowers)+len(coset.ShiftInvPowers))*fr.Bytes)
+	})
+}
+
+// evaluateOnCoset returns the evaluations of the polynomial with the given coefficients over the coset.
+//
+// The coefficients slice must have the same length as the domain.
+func (cc *cosetCache) evaluateOnCoset(coeffs []fr.Element) []fr.Element {
+	cc.init()
+	return cc.coset.FftFr(coeffs)
+}
+
+// interpolateOnCoset returns the coefficients of the polynomial with the given evaluations over the coset.
+//
+// This is the inverse of [cosetCache.evaluateOnCoset].
+func (cc *cosetCache) interpolateOnCoset(evaluations []fr.Element) []fr.Element {
+	cc.init()
+	return cc.coset.IfftFr(evaluations)
+}
+
+// memoryUsage returns the number of bytes used by the cache. This is zero until the cache has been used.
+func (cc *cosetCache) memoryUsage() int64 {
+	return atomic.LoadInt64(&cc.size)
+}
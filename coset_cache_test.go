@@ -0,0 +1,43 @@
+package gokzg4844
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosetCacheEvaluations(t *testing.T) {
+	domain := kzg.NewDomain(16)
+	cache := newExtensionCosetCache(domain)
+	require.Zero(t, cache.memoryUsage())
+
+	poly := make(kzg.Polynomial, domain.Cardinality)
+	for i := range poly {
+		poly[i].SetUint64(uint64(i*i + 7))
+	}
+	coeffs := domain.IfftFr(poly)
+
+	// Evaluating over the coset should agree with evaluating the polynomial
+	// in lagrange form at each of the shifted points
+	cosetEvals := cache.evaluateOnCoset(coeffs)
+	for i := 0; i < int(domain.Cardinality); i++ {
+		var point fr.Element
+		point.Mul(&cache.shift, &domain.Roots[i])
+		expected, err := domain.EvaluateLagrangePolynomial(poly, point)
+		require.NoError(t, err)
+		require.True(t, expected.Equal(&cosetEvals[i]))
+	}
+
+	// The vanishing polynomial of the domain is -2 over the extension coset
+	minusTwo := fr.NewElement(2)
+	minusTwo.Neg(&minusTwo)
+	require.True(t, cache.coset.VanishingEval.Equal(&minusTwo))
+
+	// Interpolating should give back the coefficients
+	gotCoeffs := cache.interpolateOnCoset(cosetEvals)
+	require.Equal(t, coeffs, gotCoeffs)
+
+	require.Equal(t, int64(2*16*fr.Bytes), cache.memoryUsage())
+}
@@ -0,0 +1,82 @@
+//go:build cgo
+
+package main
+
+// #include <stddef.h>
+// #include <stdint.h>
+import "C"
+
+import (
+	"unsafe"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+)
+
+const (
+	blobSize    = gokzg4844.ScalarsPerBlob * gokzg4844.SerializedScalarSize
+	g1Size      = gokzg4844.CompressedG1Size
+	scalarSize  = gokzg4844.SerializedScalarSize
+	extBlobSize = gokzg4844.CellsPerExtBlob * gokzg4844.BytesPerCell
+	cellSize    = gokzg4844.BytesPerCell
+)
+
+// bytesAt returns a slice over n bytes of C memory starting at ptr.
+func bytesAt(ptr *C.uint8_t, n int) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(ptr)), n)
+}
+
+//export gokzg_blob_to_kzg_commitment
+func gokzg_blob_to_kzg_commitment(blob, outCommitment *C.uint8_t) C.int {
+	return C.int(blobToKZGCommitment(bytesAt(blob, blobSize), bytesAt(outCommitment, g1Size)))
+}
+
+//export gokzg_compute_kzg_proof
+func gokzg_compute_kzg_proof(blob, z, outProof, outY *C.uint8_t) C.int {
+	return C.int(computeKZGProof(bytesAt(blob, blobSize), bytesAt(z, scalarSize), bytesAt(outProof, g1Size), bytesAt(outY, scalarSize)))
+}
+
+//export gokzg_compute_blob_kzg_proof
+func gokzg_compute_blob_kzg_proof(blob, commitment, outProof *C.uint8_t) C.int {
+	return C.int(computeBlobKZGProof(bytesAt(blob, blobSize), bytesAt(commitment, g1Size), bytesAt(outProof, g1Size)))
+}
+
+//export gokzg_verify_kzg_proof
+func gokzg_verify_kzg_proof(commitment, z, y, proof *C.uint8_t) C.int {
+	return C.int(verifyKZGProof(bytesAt(commitment, g1Size), bytesAt(z, scalarSize), bytesAt(y, scalarSize), bytesAt(proof, g1Size)))
+}
+
+//export gokzg_verify_blob_kzg_proof
+func gokzg_verify_blob_kzg_proof(blob, commitment, proof *C.uint8_t) C.int {
+	return C.int(verifyBlobKZGProof(bytesAt(blob, blobSize), bytesAt(commitment, g1Size), bytesAt(proof, g1Size)))
+}
+
+//export gokzg_verify_blob_kzg_proof_batch
+func gokzg_verify_blob_kzg_proof_batch(blobs, commitments, proofs *C.uint8_t, n C.size_t) C.int {
+	count := int(n)
+	// The count is checked before the slices over C memory are created, since their lengths could overflow
+	if err := gokzg4844.MainnetPreset().CheckBlobCount(count); err != nil {
+		return C.int(resultCode(err))
+	}
+	if count == 0 {
+		return C.int(verifyBlobKZGProofBatch(nil, nil, nil, 0))
+	}
+	return C.int(verifyBlobKZGProofBatch(bytesAt(blobs, count*blobSize), bytesAt(commitments, count*g1Size), bytesAt(proofs, count*g1Size), count))
+}
+
+//export gokzg_compute_cells
+func gokzg_compute_cells(blob, outCells *C.uint8_t) C.int {
+	return C.int(computeCells(bytesAt(blob, blobSize), bytesAt(outCells, extBlobSize)))
+}
+
+//export gokzg_verify_cells_with_blob_proof
+func gokzg_verify_cells_with_blob_proof(commitment, cells *C.uint8_t, cellIndices *C.uint64_t, n C.size_t, proof *C.uint8_t) C.int {
+	count := int(n)
+	if err := gokzg4844.MainnetPreset().CheckCellCount(count); err != nil {
+		return C.int(resultCode(err))
+	}
+	if count == 0 {
+		return C.int(verifyCellsWithBlobProof(bytesAt(commitment, g1Size), nil, nil, bytesAt(proof, g1Size)))
+	}
+	indices := unsafe.Slice((*uint64)(unsafe.Pointer(cellIndices)), count)
+	return C.int(verifyCellsWithBlobProof(bytesAt(commitment, g1Size), bytesAt(cells, count*cellSize), indices, bytesAt(proof, g1Size)))
+}
@@ -0,0 +1,65 @@
+/*
+ * C interface to go-kzg-4844.
+ *
+ * Build the library with:
+ *
+ *     go build -buildmode=c-shared -o libgokzg4844.so ./cshim
+ *
+ * All byte buffers use the encodings of EIP-4844 and EIP-7594: scalars are 32
+ * byte big-endian integers, and points are compressed G1 points of 48 bytes.
+ * Every function returns one of the GOKZG_* result codes below. Output buffers
+ * are only written when GOKZG_OK is returned.
+ *
+ * The declarations in this file are stable: functions and result codes are
+ * only ever added, never changed or removed.
+ */
+#ifndef GOKZG4844_H
+#define GOKZG4844_H
+
+#include <stddef.h>
+#include <stdint.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+#define GOKZG_BYTES_PER_SCALAR 32
+#define GOKZG_BYTES_PER_G1 48
+#define GOKZG_BYTES_PER_BLOB 131072
+#define GOKZG_BYTES_PER_CELL 2048
+#define GOKZG_CELLS_PER_EXT_BLOB 128
+
+/* The operation succeeded, or the proof is valid. */
+#define GOKZG_OK 0
+/* The inputs are well-formed, but the proof is not valid. */
+#define GOKZG_INVALID_PROOF 1
+/* One of the inputs is malformed. */
+#define GOKZG_BAD_INPUT 2
+/* The library could not be initialized. */
+#define GOKZG_INTERNAL 3
+
+int gokzg_blob_to_kzg_commitment(uint8_t *blob, uint8_t *out_commitment);
+
+int gokzg_compute_kzg_proof(uint8_t *blob, uint8_t *z, uint8_t *out_proof, uint8_t *out_y);
+
+int gokzg_compute_blob_kzg_proof(uint8_t *blob, uint8_t *commitment, uint8_t *out_proof);
+
+int gokzg_verify_kzg_proof(uint8_t *commitment, uint8_t *z, uint8_t *y, uint8_t *proof);
+
+int gokzg_verify_blob_kzg_proof(uint8_t *blob, uint8_t *commitment, uint8_t *proof);
+
+/* blobs, commitments and proofs each hold n consecutive elements. */
+int gokzg_verify_blob_kzg_proof_batch(uint8_t *blobs, uint8_t *commitments, uint8_t *proofs, size_t n);
+
+/* out_cells receives GOKZG_CELLS_PER_EXT_BLOB consecutive cells. */
+int gokzg_compute_cells(uint8_t *blob, uint8_t *out_cells);
+
+/* cells holds n consecutive cells, and cell_indices holds their n indices. */
+int gokzg_verify_cells_with_blob_proof(uint8_t *commitment, uint8_t *cells, uint64_t *cell_indices, size_t n,
+                                       uint8_t *proof);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif /* GOKZG4844_H */
@@ -0,0 +1,169 @@
+// Command cshim exports the core operations of gokzg4844 through a C ABI, so that projects in other languages can
+// use this implementation as an oracle for differential testing.
+//
+// Build it as a shared or static library with cgo enabled:
+//
+//	go build -buildmode=c-shared -o libgokzg4844.so ./cshim
+//
+// The functions are declared in gokzg4844.h, which is kept stable across releases. Every function uses the context
+// returned by [gokzg4844.SharedContext4096Secure], and returns one of the GOKZG_* result codes.
+package main
+
+import (
+	"errors"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+)
+
+// The result codes returned by the exported functions. These must match gokzg4844.h.
+const (
+	// resultOK means that the operation succeeded, or that the proof is valid.
+	resultOK = 0
+	// resultInvalidProof means that the inputs are well-formed, but the proof is not valid.
+	resultInvalidProof = 1
+	// resultBadInput means that one of the inputs is malformed, for example a non-canonical scalar or a point which
+	// is not in the subgroup.
+	resultBadInput = 2
+	// resultInternal means that the context could not be created.
+	resultInternal = 3
+)
+
+func main() {}
+
+// resultCode maps an error returned by gokzg4844 to a result code.
+func resultCode(err error) int {
+	switch {
+	case err == nil:
+		return resultOK
+	case errors.Is(err, gokzg4844.ErrProofInvalid):
+		return resultInvalidProof
+	default:
+		return resultBadInput
+	}
+}
+
+// The functions below implement the exported functions on Go slices, which keeps the cgo layer thin and allows them
+// to be tested without cgo.
+
+func blobToKZGCommitment(blob, outCommitment []byte) int {
+	ctx, err := gokzg4844.SharedContext4096Secure()
+	if err != nil {
+		return resultInternal
+	}
+	commitment, err := ctx.BlobToKZGCommitment((*gokzg4844.Blob)(blob), 0)
+	if err != nil {
+		return resultCode(err)
+	}
+	copy(outCommitment, commitment[:])
+	return resultOK
+}
+
+func computeKZGProof(blob, z, outProof, outY []byte) int {
+	ctx, err := gokzg4844.SharedContext4096Secure()
+	if err != nil {
+		return resultInternal
+	}
+	proof, y, err := ctx.ComputeKZGProof((*gokzg4844.Blob)(blob), *(*gokzg4844.Scalar)(z), 0)
+	if err != nil {
+		return resultCode(err)
+	}
+	copy(outProof, proof[:])
+	copy(outY, y[:])
+	return resultOK
+}
+
+func computeBlobKZGProof(blob, commitment, outProof []byte) int {
+	ctx, err := gokzg4844.SharedContext4096Secure()
+	if err != nil {
+		return resultInternal
+	}
+	proof, err := ctx.ComputeBlobKZGProof((*gokzg4844.Blob)(blob), *(*gokzg4844.KZGCommitment)(commitment), 0)
+	if err != nil {
+		return resultCode(err)
+	}
+	copy(outProof, proof[:])
+	return resultOK
+}
+
+func verifyKZGProof(commitment, z, y, proof []byte) int {
+	ctx, err := gokzg4844.SharedContext4096Secure()
+	if err != nil {
+		return resultInternal
+	}
+	return resultCode(ctx.VerifyKZGProof(
+		*(*gokzg4844.KZGCommitment)(commitment),
+		*(*gokzg4844.Scalar)(z),
+		*(*gokzg4844.Scalar)(y),
+		*(*gokzg4844.KZGProof)(proof),
+	))
+}
+
+func verifyBlobKZGProof(blob, commitment, proof []byte) int {
+	ctx, err := gokzg4844.SharedContext4096Secure()
+	if err != nil {
+		return resultInternal
+	}
+	return resultCode(ctx.VerifyBlobKZGProof(
+		(*gokzg4844.Blob)(blob),
+		*(*gokzg4844.KZGCommitment)(commitment),
+		*(*gokzg4844.KZGProof)(proof),
+	))
+}
+
+func verifyBlobKZGProofBatch(blobs, commitments, proofs []byte, n int) int {
+	if err := gokzg4844.MainnetPreset().CheckBlobCount(n); err != nil {
+		return resultCode(err)
+	}
+	ctx, err := gokzg4844.SharedContext4096Secure()
+	if err != nil {
+		return resultInternal
+	}
+
+	blobSlice := make([]gokzg4844.Blob, n)
+	commitmentSlice := make([]gokzg4844.KZGCommitment, n)
+	proofSlice := make([]gokzg4844.KZGProof, n)
+	for i := 0; i < n; i++ {
+		copy(blobSlice[i][:], blobs[i*len(gokzg4844.Blob{}):])
+		copy(commitmentSlice[i][:], commitments[i*gokzg4844.CompressedG1Size:])
+		copy(proofSlice[i][:], proofs[i*gokzg4844.CompressedG1Size:])
+	}
+	return resultCode(ctx.VerifyBlobKZGProofBatch(blobSlice, commitmentSlice, proofSlice))
+}
+
+func computeCells(blob, outCells []byte) int {
+	ctx, err := gokzg4844.SharedContext4096Secure()
+	if err != nil {
+		return resultInternal
+	}
+	cells, err := ctx.ComputeCells((*gokzg4844.Blob)(blob))
+	if err != nil {
+		return resultCode(err)
+	}
+	for i := range cells {
+		copy(outCells[i*gokzg4844.BytesPerCell:], cells[i][:])
+	}
+	return resultOK
+}
+
+func verifyCellsWithBlobProof(commitment, cells []byte, cellIndices []uint64, proof []byte) int {
+	if err := gokzg4844.MainnetPreset().CheckCellCount(len(cellIndices)); err != nil {
+		return resultCode(err)
+	}
+	ctx, err := gokzg4844.SharedContext4096Secure()
+	if err != nil {
+		return resultInternal
+	}
+
+	cellSlice := make([]gokzg4844.Cell, len(cellIndices))
+	indexSlice := make([]gokzg4844.CellIndex, len(cellIndices))
+	for i, index := range cellIndices {
+		copy(cellSlice[i][:], cells[i*gokzg4844.BytesPerCell:])
+		indexSlice[i] = gokzg4844.CellIndex(index)
+	}
+	return resultCode(ctx.VerifyCellsWithBlobProof(
+		*(*gokzg4844.KZGCommitment)(commitment),
+		cellSlice,
+		indexSlice,
+		*(*gokzg4844.KZGProof)(proof),
+	))
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobProofRoundTrip(t *testing.T) {
+	blob := make([]byte, gokzg4844.ScalarsPerBlob*gokzg4844.SerializedScalarSize)
+	blob[31] = 7
+
+	commitment := make([]byte, gokzg4844.CompressedG1Size)
+	proof := make([]byte, gokzg4844.CompressedG1Size)
+	require.Equal(t, resultOK, blobToKZGCommitment(blob, commitment))
+	require.Equal(t, resultOK, computeBlobKZGProof(blob, commitment, proof))
+	require.Equal(t, resultOK, verifyBlobKZGProof(blob, commitment, proof))
+	require.Equal(t, resultOK, verifyBlobKZGProofBatch(blob, commitment, proof, 1))
+
+	// A valid proof for a different blob
+	otherBlob := make([]byte, len(blob))
+	otherProof := make([]byte, len(proof))
+	require.Equal(t, resultOK, computeBlobKZGProof(otherBlob, commitment, otherProof))
+	require.Equal(t, resultInvalidProof, verifyBlobKZGProof(blob, commitment, otherProof))
+
+	// A non-canonical scalar
+	badBlob := make([]byte, len(blob))
+	for i := 0; i < gokzg4844.SerializedScalarSize; i++ {
+		badBlob[i] = 0xff
+	}
+	require.Equal(t, resultBadInput, blobToKZGCommitment(badBlob, commitment))
+}
+
+func TestKZGProofRoundTrip(t *testing.T) {
+	blob := make([]byte, gokzg4844.ScalarsPerBlob*gokzg4844.SerializedScalarSize)
+	blob[63] = 3
+
+	commitment := make([]byte, gokzg4844.CompressedG1Size)
+	proof := make([]byte, gokzg4844.CompressedG1Size)
+	z := make([]byte, gokzg4844.SerializedScalarSize)
+	y := make([]byte, gokzg4844.SerializedScalarSize)
+	z[31] = 42
+	require.Equal(t, resultOK, blobToKZGCommitment(blob, commitment))
+	require.Equal(t, resultOK, computeKZGProof(blob, z, proof, y))
+	require.Equal(t, resultOK, verifyKZGProof(commitment, z, y, proof))
+
+	y[31] ^= 1
+	require.Equal(t, resultInvalidProof, verifyKZGProof(commitment, z, y, proof))
+}
+
+func TestCellsRoundTrip(t *testing.T) {
+	blob := make([]byte, gokzg4844.ScalarsPerBlob*gokzg4844.SerializedScalarSize)
+	blob[31] = 9
+
+	commitment := make([]byte, gokzg4844.CompressedG1Size)
+	proof := make([]byte, gokzg4844.CompressedG1Size)
+	require.Equal(t, resultOK, blobToKZGCommitment(blob, commitment))
+	require.Equal(t, resultOK, computeBlobKZGProof(blob, commitment, proof))
+
+	cells := make([]byte, gokzg4844.CellsPerExtBlob*gokzg4844.BytesPerCell)
+	require.Equal(t, resultOK, computeCells(blob, cells))
+
+	indices := make([]uint64, gokzg4844.CellsPerExtBlob)
+	for i := range indices {
+		indices[i] = uint64(i)
+	}
+	require.Equal(t, resultOK, verifyCellsWithBlobProof(commitment, cells, indices, proof))
+}
+
+// Counts above the limits of the preset are rejected before the inputs are sliced.
+func TestCountLimits(t *testing.T) {
+	require.Equal(t, resultBadInput, verifyBlobKZGProofBatch(nil, nil, nil, gokzg4844.MainnetPreset().MaxBlobCommitmentsPerBlock+1))
+	require.Equal(t, resultBadInput, verifyBlobKZGProofBatch(nil, nil, nil, -1))
+	indices := make([]uint64, gokzg4844.CellsPerExtBlob+1)
+	require.Equal(t, resultBadInput, verifyCellsWithBlobProof(nil, nil, indices, nil))
+}
+
+// TestHeaderMatches checks that gokzg4844.h declares every exported function and the result codes.
+func TestHeaderMatches(t *testing.T) {
+	header, err := os.ReadFile("gokzg4844.h")
+	require.NoError(t, err)
+	source, err := os.ReadFile("cshim.go")
+	require.NoError(t, err)
+
+	exports := regexp.MustCompile(`//export (\w+)`).FindAllSubmatch(source, -1)
+	require.NotEmpty(t, exports)
+	for _, export := range exports {
+		require.Regexp(t, `int `+string(export[1])+`\(`, string(header))
+	}
+
+	codes := map[string]int{
+		"GOKZG_OK":            resultOK,
+		"GOKZG_INVALID_PROOF": resultInvalidProof,
+		"GOKZG_BAD_INPUT":     resultBadInput,
+		"GOKZG_INTERNAL":      resultInternal,
+	}
+	for name, value := range codes {
+		require.Contains(t, string(header), fmt.Sprintf("#define %s %d\n", name, value))
+	}
+}
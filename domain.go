@@ -0,0 +1,217 @@
+package gokzg4844
+
+import (
+	"math/bits"
+	"sync"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// Domain is the multiplicative subgroup of roots of unity that polynomials are evaluated over, along with values
+// precomputed from it.
+//
+// A Domain is immutable, and can be shared between contexts with [WithDomain] so that it is only computed once per
+// process. The roots are always kept in natural order; each context applies its own evaluation order to a copy.
+type Domain struct {
+	inner *kzg.Domain
+}
+
+// NewDomain returns the domain of the given size. Returns [ErrInvalidDomainSize] if size is not a power of two, or is
+// larger than the largest power-of-two subgroup of the scalar field.
+//
+// Domains of up to 65536 roots are computed once per process and then shared, by every call to NewDomain and by every
+// context, so calling NewDomain for them is cheap. Larger domains are computed on each call.
+func NewDomain(size uint64) (*Domain, error) {
+	if bits.OnesCount64(size) != 1 || size > kzg.MaxDomainSize {
+		return nil, ErrInvalidDomainSize
+	}
+	return cachedDomain(size, 0), nil
+}
+
+// domainCache maps the size of each domain cached by [kzg.CachedDomain] to the Domain which wraps it, so that
+// [NewDomain] returns the same Domain for each size.
+var domainCache sync.Map
+
+// cachedDomain returns the domain of the given size, which must be a valid domain size. Domains of up to
+// [kzg.MaxCachedDomainSize] roots are only computed once per process, and are shared with the other packages of this
+// module. The roots of the returned domain must not be modified; callers which need them in another order use
+// [Domain.clone].
+//
+// If the domain needs to be computed, up to numGoRoutines go-routines are used, or one per CPU if numGoRoutines is
+// not positive.
+func cachedDomain(size uint64, numGoRoutines int) *Domain {
+	if size > kzg.MaxCachedDomainSize {
+		return &Domain{inner: kzg.CachedDomain(size, numGoRoutines)}
+	}
+	if domain, ok := domainCache.Load(size); ok {
+		return domain.(*Domain)
+	}
+	domain, _ := domainCache.LoadOrStore(size, &Domain{inner: kzg.CachedDomain(size, numGoRoutines)})
+	return domain.(*Domain)
+}
+
+// Size returns the number of roots of unity in the domain.
+func (d *Domain) Size() uint64 {
+	return d.inner.Cardinality
+}
+
+// Root returns the i'th root of unity of the domain, in natural order; that is, it returns w^i where w is the
+// generator of the domain.
+//
+// This method panics if i is not less than the size of the domain.
+func (d *Domain) Root(i uint64) fr.Element {
+	return d.inner.Roots[i]
+}
+
+// Roots returns a copy of the roots of unity of the domain in the given order; use [BitReversedOrder] for the order
+// in which the evaluations of a blob are given. Changing the returned slice does not change the domain.
+//
+// Returns [ErrInvalidPermutation] if order is not a bijection.
+func (d *Domain) Roots(order Permutation) ([]fr.Element, error) {
+	sourceIndices, err := permutationSourceIndices(order, int(d.Size()))
+	if err != nil {
+		return nil, err
+	}
+	return permute(d.inner.Roots, sourceIndices), nil
+}
+
+// Generator returns the generator of the domain, which is a primitive root of unity of the same order as the size of
+// the domain.
+func (d *Domain) Generator() fr.Element {
+	return d.inner.Generator
+}
+
+// FFT returns the evaluations over the domain of the polynomial with the given coefficients, where the i'th
+// coefficient is the coefficient of X^i. The evaluations are returned in the given order; use [BitReversedOrder] for
+// the order of a blob.
+//
+// Fewer coefficients than the size of the domain are padded with zeros. Returns [ErrDomainSizeMismatch] if there are
+// more coefficients than the size of the domain, and [ErrInvalidPermutation] if order is not a bijection.
+func (d *Domain) FFT(coefficients []fr.Element, order Permutation) ([]fr.Element, error) {
+	n := int(d.Size())
+	if len(coefficients) > n {
+		return nil, ErrDomainSizeMismatch
+	}
+	sourceIndices, err := permutationSourceIndices(order, n)
+	if err != nil {
+		return nil, err
+	}
+
+	padded := make([]fr.Element, n)
+	copy(padded, coefficients)
+	return permute(d.inner.FftFr(padded), sourceIndices), nil
+}
+
+// IFFT is the inverse of [Domain.FFT]. It returns the coefficients of the polynomial with the given evaluations over
+// the domain, which are in the given order.
+//
+// Returns [ErrDomainSizeMismatch] if the number of evaluations is not the size of the domain, and
+// [ErrInvalidPermutation] if order is not a bijection.
+func (d *Domain) IFFT(evaluations []fr.Element, order Permutation) ([]fr.Element, error) {
+	n := int(d.Size())
+	if len(evaluations) != n {
+		return nil, ErrDomainSizeMismatch
+	}
+	sourceIndices, err := permutationSourceIndices(order, n)
+	if err != nil {
+		return nil, err
+	}
+
+	natural := make([]fr.Element, n)
+	for i, j := range sourceIndices {
+		natural[j] = evaluations[i]
+	}
+	return d.inner.IfftFr(natural), nil
+}
+
+// EvaluateLagrangePolynomialBatch returns the evaluations at each of the points of the polynomial with the given
+// evaluations over the domain, which are in the given order; use [BitReversedOrder] for the evaluations of a blob. The
+// points may be in the domain or outside of it, and the evaluations are returned in the order of the points.
+//
+// This is faster than evaluating each point on its own, since the work which only depends on the polynomial is shared
+// and a single field inversion is done for all of the points. It suits checking many openings of the same blob, such
+// as sampling several positions of a blob in a fraud proof.
+//
+// Returns [ErrDomainSizeMismatch] if the number of evaluations is not the size of the domain, and
+// [ErrInvalidPermutation] if order is not a bijection.
+func (d *Domain) EvaluateLagrangePolynomialBatch(evaluations []fr.Element, order Permutation, points []fr.Element) ([]fr.Element, error) {
+	n := int(d.Size())
+	if len(evaluations) != n {
+		return nil, ErrDomainSizeMismatch
+	}
+	sourceIndices, err := permutationSourceIndices(order, n)
+	if err != nil {
+		return nil, err
+	}
+
+	natural := make(kzg.Polynomial, n)
+	for i, j := range sourceIndices {
+		natural[j] = evaluations[i]
+	}
+	return d.inner.EvaluateLagrangePolynomialBatch(natural, points)
+}
+
+// LagrangeToMonomialG1 converts the Lagrange form of a trusted setup into its monomial form. Given the points
+// [L_i(tau)]G, where L_i is the Lagrange polynomial for the i'th root of unity, it returns the points [tau^i]G in
+// increasing powers of tau. The Lagrange points are in the given order; use [BitReversedOrder] for the order of the
+// trusted setup files used by Ethereum.
+//
+// This is an FFT over the group, which takes a few seconds for a domain of size [ScalarsPerBlob].
+//
+// Returns [ErrDomainSizeMismatch] if the number of points is not the size of the domain, and [ErrInvalidPermutation]
+// if order is not a bijection.
+func (d *Domain) LagrangeToMonomialG1(lagrange []bls12381.G1Affine, order Permutation) ([]bls12381.G1Affine, error) {
+	n := int(d.Size())
+	if len(lagrange) != n {
+		return nil, ErrDomainSizeMismatch
+	}
+	sourceIndices, err := permutationSourceIndices(order, n)
+	if err != nil {
+		return nil, err
+	}
+	return lagrangeToMonomialG1(d.inner, lagrange, sourceIndices), nil
+}
+
+// MonomialToLagrangeG1 is the inverse of [Domain.LagrangeToMonomialG1]. It returns the Lagrange form of the trusted
+// setup with the given monomial points, in the given order.
+//
+// Returns [ErrDomainSizeMismatch] if the number of points is not the size of the domain, and [ErrInvalidPermutation]
+// if order is not a bijection.
+func (d *Domain) MonomialToLagrangeG1(monomial []bls12381.G1Affine, order Permutation) ([]bls12381.G1Affine, error) {
+	n := int(d.Size())
+	if len(monomial) != n {
+		return nil, ErrDomainSizeMismatch
+	}
+	sourceIndices, err := permutationSourceIndices(order, n)
+	if err != nil {
+		return nil, err
+	}
+	return permute(d.inner.IfftG1(monomial), sourceIndices), nil
+}
+
+// lagrangeToMonomialG1 returns the monomial points for the Lagrange points, which are permuted according to
+// sourceIndices.
+//
+// Since X^i = sum_j w^(i*j) * L_j(X), where w is the generator of the domain, the monomial points are the FFT of the
+// Lagrange points in natural order.
+func lagrangeToMonomialG1(domain *kzg.Domain, lagrange []bls12381.G1Affine, sourceIndices []int) []bls12381.G1Affine {
+	natural := make([]bls12381.G1Affine, len(lagrange))
+	for i, j := range sourceIndices {
+		natural[j] = lagrange[i]
+	}
+	return domain.FftG1(natural)
+}
+
+// clone returns a copy of the domain which can be modified, for example to permute its roots. The inverses of the
+// roots are only copied if withInverses is true, and are nil otherwise.
+func (d *Domain) clone(withInverses bool) *kzg.Domain {
+	domain := *d.inner
+	domain.Roots = append([]fr.Element(nil), d.inner.Roots...)
+	domain.PreComputedInverses = nil
+	if withInverses {
+		domain.PreComputedInverses = append([]fr.Element(nil), d.inner.PreComputedInverses...)
+	}
+	return &domain
+}
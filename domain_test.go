@@ -0,0 +1,253 @@
+package gokzg4844_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDomain(t *testing.T) {
+	domain, err := gokzg4844.NewDomain(8)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), domain.Size())
+
+	// The roots are the powers of the generator, in natural order
+	generator := domain.Generator()
+	expected := fr.One()
+	for i := uint64(0); i < domain.Size(); i++ {
+		root := domain.Root(i)
+		require.True(t, expected.Equal(&root))
+		expected.Mul(&expected, &generator)
+	}
+	require.True(t, expected.IsOne())
+
+	for _, size := range []uint64{0, 3, 12, 1 << 33} {
+		_, err := gokzg4844.NewDomain(size)
+		require.ErrorIs(t, err, gokzg4844.ErrInvalidDomainSize)
+	}
+}
+
+func TestDomainRoots(t *testing.T) {
+	domain, err := gokzg4844.NewDomain(8)
+	require.NoError(t, err)
+
+	natural, err := domain.Roots(gokzg4844.NaturalOrder)
+	require.NoError(t, err)
+	reversed, err := domain.Roots(gokzg4844.BitReversedOrder)
+	require.NoError(t, err)
+	for i, j := range []uint64{0, 4, 2, 6, 1, 5, 3, 7} {
+		require.Equal(t, domain.Root(uint64(i)), natural[i])
+		require.Equal(t, domain.Root(j), reversed[i])
+	}
+
+	// The returned roots are a copy
+	natural[1].SetOne()
+	second, generator := domain.Root(1), domain.Generator()
+	require.True(t, second.Equal(&generator))
+
+	_, err = domain.Roots(nil)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPermutation)
+}
+
+func TestNewDomainCached(t *testing.T) {
+	first, err := gokzg4844.NewDomain(gokzg4844.ScalarsPerBlob)
+	require.NoError(t, err)
+	second, err := gokzg4844.NewDomain(gokzg4844.ScalarsPerBlob)
+	require.NoError(t, err)
+	require.Same(t, first, second)
+
+	// Contexts permute their own copy of the shared domain
+	_, err = gokzg4844.NewContext4096Secure(gokzg4844.WithEvaluationOrder(gokzg4844.NaturalOrder))
+	require.NoError(t, err)
+	root, generator := first.Root(1), first.Generator()
+	require.True(t, root.Equal(&generator))
+}
+
+func TestWithDomain(t *testing.T) {
+	domain, err := gokzg4844.NewDomain(gokzg4844.ScalarsPerBlob)
+	require.NoError(t, err)
+
+	blob := GetRandBlob(1)
+	expected, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+
+	// The domain is shared by contexts with different evaluation orders
+	for _, order := range []gokzg4844.Permutation{gokzg4844.BitReversedOrder, gokzg4844.NaturalOrder, gokzg4844.BitReversedOrder} {
+		ctxWithDomain, err := gokzg4844.NewContext4096Secure(gokzg4844.WithDomain(domain), gokzg4844.WithEvaluationOrder(order))
+		require.NoError(t, err)
+
+		commitment, err := ctxWithDomain.BlobToKZGCommitment(blob, NumGoRoutines)
+		require.NoError(t, err)
+		if order == gokzg4844.BitReversedOrder {
+			require.Equal(t, expected, commitment)
+		}
+
+		proof, err := ctxWithDomain.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+		require.NoError(t, err)
+		require.NoError(t, ctxWithDomain.VerifyBlobKZGProof(blob, commitment, proof))
+	}
+
+	// The domain is left in natural order
+	first, second := domain.Root(1), domain.Generator()
+	require.True(t, first.Equal(&second))
+
+	smallDomain, err := gokzg4844.NewDomain(8)
+	require.NoError(t, err)
+	_, err = gokzg4844.NewContext4096Secure(gokzg4844.WithDomain(smallDomain))
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidDomainSize)
+}
+
+func TestWithLowMemoryDomain(t *testing.T) {
+	domain, err := gokzg4844.NewDomain(gokzg4844.ScalarsPerBlob)
+	require.NoError(t, err)
+	blob := GetRandBlob(2)
+
+	for _, order := range []gokzg4844.Permutation{gokzg4844.BitReversedOrder, gokzg4844.NaturalOrder} {
+		ctxOrder, err := gokzg4844.NewContext4096Secure(gokzg4844.WithEvaluationOrder(order))
+		require.NoError(t, err)
+		ctxLowMemory, err := gokzg4844.NewContext4096Secure(gokzg4844.WithEvaluationOrder(order), gokzg4844.WithLowMemoryDomain(true))
+		require.NoError(t, err)
+		commitment, err := ctxLowMemory.BlobToKZGCommitment(blob, NumGoRoutines)
+		require.NoError(t, err)
+
+		// Proofs at points in the domain need the inverse of the point
+		for _, i := range []uint64{0, 1, 5, gokzg4844.ScalarsPerBlob - 1} {
+			z := gokzg4844.SerializeScalar(domain.Root(i))
+			expectedProof, expectedY, err := ctxOrder.ComputeKZGProof(blob, z, NumGoRoutines)
+			require.NoError(t, err)
+			proof, y, err := ctxLowMemory.ComputeKZGProof(blob, z, NumGoRoutines)
+			require.NoError(t, err)
+			require.Equal(t, expectedProof, proof)
+			require.Equal(t, expectedY, y)
+			require.NoError(t, ctxLowMemory.VerifyKZGProof(commitment, z, y, proof))
+		}
+	}
+}
+
+func TestDomainFFT(t *testing.T) {
+	domain, err := gokzg4844.NewDomain(16)
+	require.NoError(t, err)
+
+	// p(X) = 3 + 5X + 7X^2
+	coeffs := []fr.Element{fr.NewElement(3), fr.NewElement(5), fr.NewElement(7)}
+	evaluations, err := domain.FFT(coeffs, gokzg4844.NaturalOrder)
+	require.NoError(t, err)
+	for i := uint64(0); i < domain.Size(); i++ {
+		root := domain.Root(i)
+		var expected fr.Element
+		for j := len(coeffs) - 1; j >= 0; j-- {
+			expected.Mul(&expected, &root)
+			expected.Add(&expected, &coeffs[j])
+		}
+		require.True(t, expected.Equal(&evaluations[i]))
+	}
+
+	for _, order := range []gokzg4844.Permutation{gokzg4844.NaturalOrder, gokzg4844.BitReversedOrder} {
+		evaluations, err := domain.FFT(coeffs, order)
+		require.NoError(t, err)
+		roundTrip, err := domain.IFFT(evaluations, order)
+		require.NoError(t, err)
+		require.Equal(t, coeffs, roundTrip[:len(coeffs)])
+		for _, coeff := range roundTrip[len(coeffs):] {
+			require.True(t, coeff.IsZero())
+		}
+	}
+
+	_, err = domain.FFT(make([]fr.Element, 17), gokzg4844.NaturalOrder)
+	require.ErrorIs(t, err, gokzg4844.ErrDomainSizeMismatch)
+	_, err = domain.IFFT(make([]fr.Element, 15), gokzg4844.NaturalOrder)
+	require.ErrorIs(t, err, gokzg4844.ErrDomainSizeMismatch)
+	_, err = domain.FFT(coeffs, nil)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPermutation)
+}
+
+// The bit-reversed FFT over the blob domain should agree with the polynomials of a context.
+func TestDomainFFTMatchesContext(t *testing.T) {
+	domain, err := gokzg4844.NewDomain(gokzg4844.ScalarsPerBlob)
+	require.NoError(t, err)
+
+	coeffs := []fr.Element{fr.NewElement(3), fr.NewElement(5), fr.NewElement(7)}
+	evaluations, err := domain.FFT(coeffs, gokzg4844.BitReversedOrder)
+	require.NoError(t, err)
+	poly, err := ctx.PolynomialFromCoefficients(coeffs)
+	require.NoError(t, err)
+	require.Equal(t, []fr.Element(poly), evaluations)
+}
+
+func TestDomainEvaluateLagrangePolynomialBatch(t *testing.T) {
+	domain, err := gokzg4844.NewDomain(gokzg4844.ScalarsPerBlob)
+	require.NoError(t, err)
+
+	blob := GetRandBlob(3)
+	poly, err := gokzg4844.DeserializeBlob(blob)
+	require.NoError(t, err)
+	first, err := gokzg4844.DeserializeScalar(GetRandFieldElement(4))
+	require.NoError(t, err)
+	second, err := gokzg4844.DeserializeScalar(GetRandFieldElement(5))
+	require.NoError(t, err)
+
+	points := []fr.Element{first, domain.Root(7), second}
+	evaluations, err := domain.EvaluateLagrangePolynomialBatch(poly, gokzg4844.BitReversedOrder, points)
+	require.NoError(t, err)
+	for i := range points {
+		z := gokzg4844.SerializeScalar(points[i])
+		_, y, err := ctx.ComputeKZGProof(blob, z, NumGoRoutines)
+		require.NoError(t, err)
+		require.Equal(t, y, gokzg4844.SerializeScalar(evaluations[i]))
+	}
+
+	_, err = domain.EvaluateLagrangePolynomialBatch(poly[1:], gokzg4844.BitReversedOrder, points)
+	require.ErrorIs(t, err, gokzg4844.ErrDomainSizeMismatch)
+	_, err = domain.EvaluateLagrangePolynomialBatch(poly, nil, points)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPermutation)
+}
+
+func TestLagrangeToMonomialG1(t *testing.T) {
+	domain, err := gokzg4844.NewDomain(8)
+	require.NoError(t, err)
+
+	// The monomial points for the secret 5
+	_, _, genG1, _ := bls12381.Generators()
+	var secret, power fr.Element
+	secret.SetUint64(5)
+	power.SetOne()
+	monomial := make([]bls12381.G1Affine, domain.Size())
+	for i := range monomial {
+		var powerBI big.Int
+		power.BigInt(&powerBI)
+		monomial[i].ScalarMultiplication(&genG1, &powerBI)
+		power.Mul(&power, &secret)
+	}
+
+	for _, order := range []gokzg4844.Permutation{gokzg4844.NaturalOrder, gokzg4844.BitReversedOrder} {
+		lagrange, err := domain.MonomialToLagrangeG1(monomial, order)
+		require.NoError(t, err)
+
+		// The i'th Lagrange point commits to the polynomial which is 1 at the i'th root, in the given order
+		for i := range lagrange {
+			evaluations := make([]fr.Element, domain.Size())
+			evaluations[i].SetOne()
+			coeffs, err := domain.IFFT(evaluations, order)
+			require.NoError(t, err)
+
+			var expected bls12381.G1Affine
+			_, err = expected.MultiExp(monomial, coeffs, ecc.MultiExpConfig{})
+			require.NoError(t, err)
+			require.True(t, expected.Equal(&lagrange[i]))
+		}
+
+		roundTrip, err := domain.LagrangeToMonomialG1(lagrange, order)
+		require.NoError(t, err)
+		require.Equal(t, monomial, roundTrip)
+	}
+
+	_, err = domain.LagrangeToMonomialG1(monomial[:7], gokzg4844.NaturalOrder)
+	require.ErrorIs(t, err, gokzg4844.ErrDomainSizeMismatch)
+	_, err = domain.MonomialToLagrangeG1(monomial, nil)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPermutation)
+}
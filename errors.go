@@ -1,8 +1,189 @@
 package gokzg4844
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
 
 var (
-	ErrBatchLengthCheck   = errors.New("the number of blobs, commitments, and proofs must be the same")
-	ErrNonCanonicalScalar = errors.New("scalar is not canonical when interpreted as a big integer in big-endian")
+	ErrBatchLengthCheck       = errors.New("the number of blobs, commitments, and proofs must be the same")
+	ErrNonCanonicalScalar     = errors.New("scalar is not canonical when interpreted as a big integer in big-endian")
+	ErrInvalidCellLength      = errors.New("cell does not have the expected number of bytes")
+	ErrNoPrecompute           = errors.New("context was not created with a precomputed table")
+	ErrVerifierClosed         = errors.New("verifier has been closed")
+	ErrVersionedHashMismatch  = errors.New("commitment does not match the versioned hash")
+	ErrInvalidVersionedHash   = errors.New("versioned hash does not have the version of a KZG commitment")
+	ErrReceiptMismatch        = errors.New("receipt does not match the setup or the inputs")
+	ErrInvalidPermutation     = errors.New("evaluation order is not a permutation")
+	ErrStalePrecompute        = errors.New("precomputed table was created with a different library version, constants or setup")
+	ErrCellBatchLengthCheck   = errors.New("the number of cells and cell indices must be the same")
+	ErrMultiPointLengthCheck  = errors.New("the number of input points and claimed values must be the same")
+	ErrCombinationLengthCheck = errors.New("the number of commitments and scalars must be the same")
+	ErrInvalidCellIndex       = errors.New("cell index is not less than the number of cells in an extended blob")
+	ErrMissingBlobCells       = errors.New("fewer than half of the cells of the extended blob are present")
+	ErrCellMismatch           = errors.New("cell does not match the blob")
+	ErrInvalidBlobLength      = errors.New("blob does not have the expected number of bytes")
+	ErrBasisMismatch          = errors.New("the Lagrange and monomial commitments are not to the same polynomial")
+	ErrInvalidHexEncoding     = errors.New("text is not a 0x-prefixed hex string of the expected length")
+
+	// ErrBlobNotCanonical is returned when one of the scalars in a blob is not canonical.
+	// It wraps [ErrNonCanonicalScalar], so errors.Is matches both errors.
+	ErrBlobNotCanonical = fmt.Errorf("blob contains a non-canonical scalar: %w", ErrNonCanonicalScalar)
+
+	// The following errors are returned when a serialized commitment or proof cannot be deserialized.
+	ErrInvalidPointEncoding = errors.New("point is not a valid compressed encoding of a G1 element")
+	ErrPointNotOnCurve      = errors.New("point is not on the curve")
+	ErrPointNotInSubgroup   = errors.New("point is not in the prime order subgroup")
+
+	// ErrInvalidPolynomialLength is returned when a polynomial does not have one evaluation for each scalar in a blob.
+	ErrInvalidPolynomialLength = errors.New("polynomial does not have the same number of evaluations as a blob")
+
+	// ErrInvalidDomainSize is returned when a domain is not of a supported size.
+	ErrInvalidDomainSize = errors.New("domain size is not a supported power of two")
+	// ErrDomainSizeMismatch is returned when the number of values passed to a [Domain] does not match its size.
+	ErrDomainSizeMismatch = errors.New("number of values does not match the size of the domain")
+	// ErrInvalidRootIndex is returned when the index of a root of unity is not less than the size of its [Domain], or
+	// is given more than once.
+	ErrInvalidRootIndex = errors.New("root index is not less than the size of the domain or is repeated")
+
+	// ErrSelfTestFailed is returned when the elliptic curve arithmetic does not give the expected results, see
+	// [SelfTest].
+	ErrSelfTestFailed = errors.New("self-test of the elliptic curve arithmetic failed")
+
+	// ErrInvalidDataLength is returned by [MultiBlobBundle.Data] when the length of the data does not fit in the
+	// blobs of the bundle.
+	ErrInvalidDataLength = errors.New("data length is negative or larger than the blobs can hold")
+
+	// ErrUnsupportedProofKind is returned when a [ProofKind] cannot be computed by this library.
+	ErrUnsupportedProofKind = errors.New("proof kind is not supported")
+
+	// ErrInvalidPairingInputs is returned when a [PairingInputs] does not have the same number of G1 and G2 points.
+	ErrInvalidPairingInputs = errors.New("pairing inputs do not have the same number of G1 and G2 points")
+
+	// ErrInvalidTextTrustedSetup is returned when a trusted setup in the text format cannot be parsed.
+	ErrInvalidTextTrustedSetup = errors.New("trusted setup is not in the expected text format")
+
+	// ErrInvalidTranscript is returned when a transcript of the KZG ceremony does not pass the checks of
+	// [ParseCeremonyTranscript].
+	ErrInvalidTranscript = errors.New("ceremony transcript is not valid")
+
+	// ErrSetupDigestMismatch is returned when a trusted setup does not have the expected digest.
+	ErrSetupDigestMismatch = errors.New("trusted setup does not have the expected digest")
+
+	// ErrPresetMismatch is returned when data of one [Preset] is converted to a type of another preset.
+	ErrPresetMismatch = errors.New("data does not have the sizes of the preset of its type")
+
+	// ErrInvalidSetupEpochs is returned when the epochs given to [NewSetupEpochs] are empty, have a nil context, or
+	// have two epochs which start at the same slot.
+	ErrInvalidSetupEpochs = errors.New("setup epochs are not valid")
+
+	// ErrNoSetupForSlot is returned when a slot is before the first epoch of a [SetupEpochs].
+	ErrNoSetupForSlot = errors.New("no trusted setup is configured for the slot")
+
+	// ErrInvalidUncompressedTrustedSetup is returned when a trusted setup is not in the uncompressed format written
+	// by [WriteUncompressedTrustedSetup].
+	ErrInvalidUncompressedTrustedSetup = errors.New("trusted setup is not in the expected uncompressed format")
+
+	// ErrInvalidPrecomputeFile is returned when a file given to [Context.LoadPrecomputeMmap] is too short to hold a
+	// precomputed table.
+	ErrInvalidPrecomputeFile = errors.New("file does not hold a precomputed table")
+
+	// ErrInvalidMmapTrustedSetup is returned when a file given to [NewContext4096Mmap] is not a trusted setup in the
+	// format written by [WriteMmapTrustedSetup].
+	ErrInvalidMmapTrustedSetup = errors.New("file does not hold a trusted setup in the expected format")
+
+	// ErrMlockUnsupported is returned when [WithMlockPrecompute] is used on a platform which cannot lock memory.
+	ErrMlockUnsupported = errors.New("locking memory is not supported on this platform")
+
+	// ErrInvalidInsecureSecret is returned by [NewInsecureSetup] when the secret is nil or zero, or fewer than two G2
+	// points are requested.
+	ErrInvalidInsecureSecret = errors.New("insecure setup needs a non-zero secret and at least two G2 points")
+
+	// ErrCountLimitExceeded is returned when a count read from untrusted data is larger than the preset allows. See
+	// [CountLimitError].
+	ErrCountLimitExceeded = errors.New("count exceeds the limit of the preset")
+
+	// ErrInvalidSRSStructure is returned by [VerifySRSStructure] when the points of a trusted setup are not successive
+	// powers of a single secret.
+	ErrInvalidSRSStructure = errors.New("trusted setup points are not successive powers of a secret")
+
+	// ErrInvalidPreset is returned when a context is created for a [Preset] whose sizes cannot be used. See
+	// [Preset.Validate].
+	ErrInvalidPreset = errors.New("preset sizes are not powers of two")
+	// ErrSetupTooSmall is returned when a trusted setup does not have enough G1 points for the preset of a context.
+	ErrSetupTooSmall = errors.New("trusted setup has fewer G1 points than the preset needs")
+
+	// ErrInvalidPrecompileInputLength is returned when the input of the point evaluation precompile does not have
+	// [PointEvaluationPrecompileInputSize] bytes.
+	ErrInvalidPrecompileInputLength = errors.New("point evaluation precompile input does not have the expected number of bytes")
+
+	// ErrContextClosed is returned when a context is used after [Context.Close] has been called.
+	ErrContextClosed = errors.New("context has been closed")
+	// ErrSharedContext is returned by [Context.Close] for the context returned by [SharedContext4096Secure].
+	ErrSharedContext = errors.New("the shared context cannot be closed")
+
+	// ErrInvalidMultiPointCount is returned when a multi-point proof is for no points, or for more points than the
+	// trusted setup has G2 points after the first.
+	ErrInvalidMultiPointCount = errors.New("number of input points is zero or larger than the trusted setup supports")
+	// ErrDuplicateInputPoint is returned when the input points of a multi-point proof are not distinct.
+	ErrDuplicateInputPoint = errors.New("input points are not distinct")
+
+	// ErrProofInvalid is returned when the pairing check for a proof, or a batch of proofs, fails.
+	ErrProofInvalid = kzg.ErrVerifyOpeningProof
 )
+
+// BatchVerificationError is returned when an element of a batch fails to verify. It reports which
+// element failed, so that callers can, for example, penalize the peer that sent it.
+//
+// errors.Is and errors.As look through it to the underlying error, such as [ErrProofInvalid].
+type BatchVerificationError struct {
+	// Index is the position of the element in the batch.
+	Index int
+	// Err is the reason that the element failed to verify.
+	Err error
+}
+
+func (e *BatchVerificationError) Error() string {
+	return fmt.Sprintf("batch element %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *BatchVerificationError) Unwrap() error {
+	return e.Err
+}
+
+// CountLimitError is returned when a count, such as the length prefix of a list in untrusted data, is negative or
+// larger than the preset allows. It is returned before anything of that size is allocated, so that a malicious count
+// cannot cause a large allocation.
+//
+// errors.Is matches it with [ErrCountLimitExceeded], and with Err if it is set.
+type CountLimitError struct {
+	// What describes what was counted, for example "blobs".
+	What string
+	// Count is the count that was found.
+	Count int
+	// Max is the largest count that is allowed.
+	Max int
+	// Err is an optional error describing the data that held the count, such as [ErrInvalidTextTrustedSetup].
+	Err error
+}
+
+func (e *CountLimitError) Error() string {
+	msg := fmt.Sprintf("%v: found %d %s, the maximum is %d", ErrCountLimitExceeded, e.Count, e.What, e.Max)
+	if e.Err != nil {
+		return fmt.Sprintf("%v: %s", e.Err, msg)
+	}
+	return msg
+}
+
+// Is makes errors.Is(err, ErrCountLimitExceeded) true for a [CountLimitError].
+func (e *CountLimitError) Is(target error) bool {
+	return target == ErrCountLimitExceeded
+}
+
+// Unwrap returns Err.
+func (e *CountLimitError) Unwrap() error {
+	return e.Err
+}
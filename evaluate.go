@@ -0,0 +1,40 @@
+package gokzg4844
+
+import (
+	"context"
+
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// EvaluateBlobs returns the evaluation at the same point of the polynomial of each of the blobs, which is the claimed
+// value that [Context.ComputeKZGProof] returns for the blob and the point. This suits aggregation schemes which open
+// all of the blobs of a block at one challenge.
+//
+// The work which only depends on the point, including the only field inversion, is done once for all of the blobs,
+// after which each blob is evaluated with one multiplication per scalar. The i'th evaluation in the returned slice is
+// that of the i'th blob. If the point or any of the blobs is invalid, an error is returned and no evaluations are
+// returned.
+//
+// numGoRoutines is used to configure the amount of concurrency needed. Setting this
+// value to a negative number or 0 will make it default to the value configured with
+// [WithNumGoRoutines], which itself defaults to the number of CPUs.
+func (c *Context) EvaluateBlobs(blobs []Blob, inputPointBytes Scalar, numGoRoutines int) ([]Scalar, error) {
+	if err := c.checkTypes(); err != nil {
+		return nil, err
+	}
+	inputPoint, err := DeserializeScalar(inputPointBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := c.domain().LagrangeWeights(inputPoint)
+	evaluations := make([]Scalar, len(blobs))
+	err = c.forEachBlob(context.Background(), blobs, numGoRoutines, func(i int, polynomial kzg.Polynomial, _ int) error {
+		evaluations[i] = SerializeScalar(kzg.InnerProduct(polynomial, weights))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return evaluations, nil
+}
@@ -0,0 +1,36 @@
+package gokzg4844_test
+
+import (
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateBlobs(t *testing.T) {
+	blobs := []gokzg4844.Blob{*GetRandBlob(1), *GetRandBlob(2), *GetRandBlob(3)}
+
+	domain, err := gokzg4844.NewDomain(gokzg4844.ScalarsPerBlob)
+	require.NoError(t, err)
+	for _, z := range []gokzg4844.Scalar{GetRandFieldElement(4), gokzg4844.SerializeScalar(domain.Root(9))} {
+		evaluations, err := ctx.EvaluateBlobs(blobs, z, NumGoRoutines)
+		require.NoError(t, err)
+		require.Len(t, evaluations, len(blobs))
+		for i := range blobs {
+			_, y, err := ctx.ComputeKZGProof(&blobs[i], z, NumGoRoutines)
+			require.NoError(t, err)
+			require.Equal(t, y, evaluations[i])
+		}
+	}
+
+	evaluations, err := ctx.EvaluateBlobs(nil, GetRandFieldElement(4), NumGoRoutines)
+	require.NoError(t, err)
+	require.Empty(t, evaluations)
+
+	nonCanonical := gokzg4844.Scalar{0xff}
+	_, err = ctx.EvaluateBlobs(blobs, nonCanonical, NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrNonCanonicalScalar)
+	blobs[1][0] = 0xff
+	_, err = ctx.EvaluateBlobs(blobs, GetRandFieldElement(4), NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrNonCanonicalScalar)
+}
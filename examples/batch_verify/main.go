@@ -0,0 +1,63 @@
+// Command batch_verify commits to and proves a batch of blobs, verifies them together, and shows how an invalid proof
+// in a batch is reported, using only the public API of gokzg4844.
+//
+// It exits with a non-zero status if any step fails, so that it can be run as an integration test.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+)
+
+const batchSize = 4
+
+func main() {
+	ctx, err := gokzg4844.NewContext4096Secure()
+	if err != nil {
+		log.Fatalf("creating context: %v", err)
+	}
+
+	// 1. Create a batch of blobs. Each scalar is set to a small integer, so that it is canonical.
+	//
+	blobs := make([]gokzg4844.Blob, batchSize)
+	for i := range blobs {
+		for j := 0; j < gokzg4844.ScalarsPerBlob; j++ {
+			blobs[i][(j+1)*gokzg4844.SerializedScalarSize-1] = byte(i + j)
+		}
+	}
+
+	// 2. Commit to and prove all of the blobs
+	//
+	commitments, err := ctx.BlobsToKZGCommitments(blobs, 0)
+	if err != nil {
+		log.Fatalf("committing to blobs: %v", err)
+	}
+	proofs, err := ctx.ComputeBlobKZGProofs(blobs, commitments, 0)
+	if err != nil {
+		log.Fatalf("computing proofs: %v", err)
+	}
+
+	// 3. Verify the whole batch with a single pairing check
+	//
+	if err := ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs); err != nil {
+		log.Fatalf("verifying batch: %v", err)
+	}
+	fmt.Printf("verified a batch of %d blobs\n", batchSize)
+
+	// 4. Swap two proofs, and find out which element of the batch is invalid
+	//
+	proofs[1], proofs[2] = proofs[2], proofs[1]
+	err = ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+
+	var batchErr *gokzg4844.BatchVerificationError
+	if !errors.As(err, &batchErr) || !errors.Is(err, gokzg4844.ErrProofInvalid) {
+		log.Fatalf("expected an invalid proof, got: %v", err)
+	}
+	if batchErr.Index != 1 {
+		log.Fatalf("expected element 1 to be reported, got element %d", batchErr.Index)
+	}
+	fmt.Printf("rejected the batch: element %d has an invalid proof\n", batchErr.Index)
+}
@@ -0,0 +1,112 @@
+// Command blob_lifecycle walks through the life of a blob using only the public API of gokzg4844: encoding data into
+// a blob, committing to it, proving and verifying the commitment, splitting it into cells and recovering the data.
+//
+// It exits with a non-zero status if any step fails, so that it can be run as an integration test.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+)
+
+// usableBytesPerScalar is the number of bytes of data stored in each scalar of a blob. The first byte of each scalar
+// is left as zero, which guarantees that the scalar is canonical.
+const usableBytesPerScalar = gokzg4844.SerializedScalarSize - 1
+
+func main() {
+	ctx, err := gokzg4844.NewContext4096Secure()
+	if err != nil {
+		log.Fatalf("creating context: %v", err)
+	}
+
+	// 1. Encode some data into a blob
+	//
+	data := []byte("The quick brown fox jumps over the lazy dog. ")
+	data = bytes.Repeat(data, 100)
+	blob, err := encodeBlob(data)
+	if err != nil {
+		log.Fatalf("encoding data: %v", err)
+	}
+	fmt.Printf("encoded %d bytes into a blob\n", len(data))
+
+	// 2. Commit to the blob and prove the commitment
+	//
+	commitment, err := ctx.BlobToKZGCommitment(blob, 0)
+	if err != nil {
+		log.Fatalf("committing to blob: %v", err)
+	}
+	proof, err := ctx.ComputeBlobKZGProof(blob, commitment, 0)
+	if err != nil {
+		log.Fatalf("computing proof: %v", err)
+	}
+	fmt.Printf("commitment: %x\n", commitment)
+
+	// 3. Verify the proof, as a node receiving the blob would
+	//
+	if err := ctx.VerifyBlobKZGProof(blob, commitment, proof); err != nil {
+		log.Fatalf("verifying proof: %v", err)
+	}
+	fmt.Println("verified blob proof")
+
+	// 4. Split the extended blob into cells, and verify the cells against the commitment
+	//
+	cells, err := ctx.ComputeCells(blob)
+	if err != nil {
+		log.Fatalf("computing cells: %v", err)
+	}
+	indices := make([]gokzg4844.CellIndex, gokzg4844.CellsPerExtBlob)
+	for i := range indices {
+		indices[i] = gokzg4844.CellIndex(i)
+	}
+	if err := ctx.VerifyCellsWithBlobProof(commitment, cells[:], indices, proof); err != nil {
+		log.Fatalf("verifying cells: %v", err)
+	}
+	fmt.Printf("verified %d cells\n", len(cells))
+
+	// 5. Recover the blob from the cells which hold it, and decode the data
+	//
+	// The first half of the cells of an extended blob is the blob itself.
+	var recovered gokzg4844.Blob
+	for i := 0; i < gokzg4844.CellsPerExtBlob/2; i++ {
+		copy(recovered[i*gokzg4844.BytesPerCell:], cells[i][:])
+	}
+	decoded := decodeBlob(&recovered, len(data))
+	if !bytes.Equal(decoded, data) {
+		log.Fatal("recovered data does not match the original data")
+	}
+	fmt.Printf("recovered %d bytes from the cells\n", len(decoded))
+}
+
+// encodeBlob stores the data in a blob, using the last 31 bytes of each scalar.
+func encodeBlob(data []byte) (*gokzg4844.Blob, error) {
+	if len(data) > gokzg4844.ScalarsPerBlob*usableBytesPerScalar {
+		return nil, fmt.Errorf("data is too large for a blob: %d bytes", len(data))
+	}
+
+	var blob gokzg4844.Blob
+	for i := 0; i*usableBytesPerScalar < len(data); i++ {
+		chunk := data[i*usableBytesPerScalar:]
+		if len(chunk) > usableBytesPerScalar {
+			chunk = chunk[:usableBytesPerScalar]
+		}
+		copy(blob[i*gokzg4844.SerializedScalarSize+1:], chunk)
+	}
+	return &blob, nil
+}
+
+// decodeBlob is the inverse of encodeBlob, for data of the given length.
+func decodeBlob(blob *gokzg4844.Blob, length int) []byte {
+	data := make([]byte, 0, length)
+	for i := 0; len(data) < length; i++ {
+		scalar := blob[i*gokzg4844.SerializedScalarSize+1 : (i+1)*gokzg4844.SerializedScalarSize]
+		remaining := length - len(data)
+		if len(scalar) > remaining {
+			scalar = scalar[:remaining]
+		}
+		data = append(data, scalar...)
+	}
+	return data
+}
@@ -0,0 +1,63 @@
+package gokzg4844
+
+import (
+	"context"
+
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// CommitExtensionCoset computes the extension of a blob and the commitment to it.
+//
+// A blob holds the evaluations of a polynomial f(X) over the domain H of the [ScalarsPerBlob]'th roots of unity.
+// Extending the blob evaluates f(X) over the coset s*H, where s is a primitive 2*[ScalarsPerBlob]'th root of
+// unity, so that H and s*H together make up the domain of size [ScalarsPerExtBlob]. The evaluations over s*H are
+// returned as a blob, in the same order as the blob that was given. With the default bit-reversed order, this is
+// exactly the second half of the extended blob in [EIP-7594].
+//
+// The returned commitment is the commitment to the extension when it is interpreted as a blob; that is, it is the
+// commitment to g(X) = f(s*X). Since the commitment to the original blob is usually already known, this method only
+// does the work for the extension.
+//
+// numGoRoutines is used to configure the amount of concurrency needed. Setting this
+// value to a negative number or 0 will make it default to the value configured with
+// [WithNumGoRoutines], which itself defaults to the number of CPUs.
+//
+// [EIP-7594]: https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/polynomial-commitments-sampling.md#compute_cells_and_kzg_proofs
+func (c *Context) CommitExtensionCoset(blob *Blob, numGoRoutines int) (*Blob, KZGCommitment, error) {
+	if err := c.checkTypes(); err != nil {
+		return nil, KZGCommitment{}, err
+	}
+	prof := startProfile(context.Background(), "commit_extension_coset", 1)
+	defer prof.end()
+
+	// 1. Deserialization
+	//
+	prof.phase("deserialize")
+	polynomial, err := DeserializeBlobPar(blob, c.goRoutines(numGoRoutines))
+	if err != nil {
+		return nil, KZGCommitment{}, err
+	}
+
+	// 2. Evaluate the polynomial over the coset
+	//
+	prof.phase("extend")
+	extension := c.extendPolynomial(polynomial)
+
+	// 3. Commit to the extension
+	prof.phase("commit")
+	commitment, err := kzg.Commit(extension, c.commitKey(), c.goRoutines(numGoRoutines))
+	if err != nil {
+		return nil, KZGCommitment{}, err
+	}
+
+	// 4. Serialization
+	//
+	return SerializePoly(extension), KZGCommitment(SerializeG1Point(*commitment)), nil
+}
+
+// extendPolynomial returns the evaluations of the polynomial over the extension coset, given its evaluations over
+// the domain. Both the input and the output are in the order of the context.
+func (c *Context) extendPolynomial(polynomial kzg.Polynomial) kzg.Polynomial {
+	coeffs := c.domain().IfftFr(c.toNaturalOrder(polynomial))
+	return c.fromNaturalOrder(c.extensionCoset().evaluateOnCoset(coeffs))
+}
@@ -0,0 +1,40 @@
+package gokzg4844
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitExtensionCoset(t *testing.T) {
+	for _, order := range []Permutation{BitReversedOrder, NaturalOrder} {
+		ctx, err := NewContext4096Secure(WithEvaluationOrder(order))
+		require.NoError(t, err)
+
+		polynomial := make([]fr.Element, ScalarsPerBlob)
+		for i := range polynomial {
+			polynomial[i].SetUint64(uint64(i*i + 7))
+		}
+		blob := SerializePoly(polynomial)
+
+		extension, commitment, err := ctx.CommitExtensionCoset(blob, 0)
+		require.NoError(t, err)
+
+		// The i'th evaluation of the extension should be the evaluation
+		// of the original polynomial at shift * w_i
+		extensionPoly, err := DeserializeBlob(extension)
+		require.NoError(t, err)
+		for _, i := range []int{0, 1, 2, 1000, ScalarsPerBlob - 1} {
+			var point fr.Element
+			point.Mul(&ctx.extensionCoset().shift, &ctx.domain().Roots[i])
+			expected, err := ctx.domain().EvaluateLagrangePolynomial(polynomial, point)
+			require.NoError(t, err)
+			require.True(t, expected.Equal(&extensionPoly[i]))
+		}
+
+		expectedCommitment, err := ctx.BlobToKZGCommitment(extension, 0)
+		require.NoError(t, err)
+		require.Equal(t, expectedCommitment, commitment)
+	}
+}
@@ -3,6 +3,7 @@ package gokzg4844
 import (
 	"crypto/sha256"
 	"encoding/binary"
+	"hash"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 )
@@ -14,16 +15,43 @@ import (
 // [FIAT_SHAMIR_PROTOCOL_DOMAIN]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#blob
 const DomSepProtocol = "FSBLOBVERIFY_V1_"
 
+// DomSepBatch is a Domain Separator for the challenge used to combine proofs in batch verification.
+//
+// It matches [RANDOM_CHALLENGE_KZG_BATCH_DOMAIN] in the spec.
+//
+// [RANDOM_CHALLENGE_KZG_BATCH_DOMAIN]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#blob
+const DomSepBatch = "RCKZGBATCH___V1_"
+
+// DomSepAggregate is a Domain Separator for the challenge used to combine the polynomials of an aggregate proof, see
+// [Context.ComputeAggregateKZGProof].
+const DomSepAggregate = "RCKZGAGGREG_V1__"
+
 // computeChallenge is provided to match the spec at [compute_challenge].
 //
 // [compute_challenge]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#compute_challenge
 //
 // [hash_to_bls_field]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#hash_to_bls_field
-func computeChallenge(blob *Blob, commitment KZGCommitment) fr.Element {
+func computeChallenge(blob []byte, commitment KZGCommitment) fr.Element {
+	return finalizeChallenge(newChallengeHasher(blob), commitment)
+}
+
+// newChallengeHasher returns a hasher which has absorbed everything in the transcript of [compute_challenge] that
+// comes before the commitment.
+//
+// The degree in the transcript is the number of scalars in the blob, so that blobs of a [Preset] with another size get
+// their own challenges.
+//
+// [compute_challenge]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#compute_challenge
+func newChallengeHasher(blob []byte) hash.Hash {
 	h := sha256.New()
 	h.Write([]byte(DomSepProtocol))
-	h.Write(u64ToByteArray16(ScalarsPerBlob))
+	h.Write(u64ToByteArray16(uint64(len(blob) / SerializedScalarSize)))
 	h.Write(blob[:])
+	return h
+}
+
+// finalizeChallenge absorbs the commitment into a hasher returned by [newChallengeHasher] and returns the challenge.
+func finalizeChallenge(h hash.Hash, commitment KZGCommitment) fr.Element {
 	h.Write(commitment[:])
 
 	digest := h.Sum(nil)
@@ -32,6 +60,59 @@ func computeChallenge(blob *Blob, commitment KZGCommitment) fr.Element {
 	return challenge
 }
 
+// computeBatchChallenge computes the challenge used to combine proofs in [verify_kzg_proof_batch], from a
+// transcript of all of the commitments, proofs, input points and claimed values.
+//
+// scalarsPerBlob is the number of scalars in a blob of the preset, and all slices must have the same length.
+//
+// [verify_kzg_proof_batch]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#verify_kzg_proof_batch
+func computeBatchChallenge(scalarsPerBlob int, commitments []KZGCommitment, inputPoints, claimedValues []Scalar, proofs []KZGProof) fr.Element {
+	h := sha256.New()
+	h.Write([]byte(DomSepBatch))
+	h.Write(u64ToByteArray8(uint64(scalarsPerBlob)))
+	h.Write(u64ToByteArray8(uint64(len(commitments))))
+	for i := range commitments {
+		h.Write(commitments[i][:])
+		h.Write(inputPoints[i][:])
+		h.Write(claimedValues[i][:])
+		h.Write(proofs[i][:])
+	}
+
+	digest := h.Sum(nil)
+	var challenge fr.Element
+	challenge.SetBytes(digest[:])
+	return challenge
+}
+
+// computeAggregateChallenge computes the challenge used to combine the polynomials of an aggregate proof, from a
+// transcript of all of the commitments, the input point and all of the claimed values.
+//
+// scalarsPerBlob is the number of scalars in a blob of the preset, and commitments and claimedValues must have the
+// same length.
+func computeAggregateChallenge(scalarsPerBlob int, commitments []KZGCommitment, inputPoint Scalar, claimedValues []Scalar) fr.Element {
+	h := sha256.New()
+	h.Write([]byte(DomSepAggregate))
+	h.Write(u64ToByteArray8(uint64(scalarsPerBlob)))
+	h.Write(u64ToByteArray8(uint64(len(commitments))))
+	h.Write(inputPoint[:])
+	for i := range commitments {
+		h.Write(commitments[i][:])
+		h.Write(claimedValues[i][:])
+	}
+
+	digest := h.Sum(nil)
+	var challenge fr.Element
+	challenge.SetBytes(digest[:])
+	return challenge
+}
+
+// u64ToByteArray8 converts a uint64 to a byte slice of length 8 in big endian format.
+func u64ToByteArray8(number uint64) []byte {
+	bytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(bytes, number)
+	return bytes
+}
+
 // u64ToByteArray16 converts a uint64 to a byte slice of length 16 in big endian format. This implies that the first 8 bytes of the result are always 0.
 func u64ToByteArray16(number uint64) []byte {
 	bytes := make([]byte, 16)
@@ -14,7 +14,7 @@ import (
 func TestComputeChallengeInterop(t *testing.T) {
 	blob := &Blob{}
 	commitment := SerializeG1Point(bls12381.G1Affine{})
-	challenge := computeChallenge(blob, KZGCommitment(commitment))
+	challenge := computeChallenge(blob[:], KZGCommitment(commitment))
 	expected := []byte{
 		0x04, 0xb7, 0xb2, 0x2a, 0xf6, 0x3d, 0x2b, 0x2f,
 		0x1c, 0xed, 0x8d, 0x55, 0x05, 0x60, 0xe5, 0xd1,
@@ -51,7 +51,7 @@ func BenchmarkComputeChallenge(b *testing.B) {
 	b.ResetTimer()
 	b.ReportAllocs()
 	for n := 0; n < b.N; n++ {
-		challenge = computeChallenge(blob, KZGCommitment(commitment))
+		challenge = computeChallenge(blob[:], KZGCommitment(commitment))
 	}
 	have := SerializeScalar(challenge)
 	require.Equal(b, want, have[:])
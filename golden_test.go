@@ -0,0 +1,167 @@
+package gokzg4844_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+// goldenDigests holds the SHA256 hash of the outputs of each operation on the inputs of TestGoldenOutputs.
+//
+// These were computed on amd64. TestGoldenOutputsEmulated runs this test on arm64 and wasm as well, so that a
+// dependency whose behavior depends on the platform, for example through assembly or a different word size, fails
+// the tests instead of splitting consensus between nodes.
+var goldenDigests = map[string]string{
+	"blob_to_kzg_commitment":       "84346703d56577f2f4842d06ab930b150bb14f4cf628b22a9234aad98d78b45d",
+	"compute_kzg_proof":            "8ece0437db6b6a14c33d8ebd8e4192525647883cbcc7a13c7542e050d2c58b36",
+	"compute_blob_kzg_proof":       "bb78eda029ef69759669769b300c51e0486a44db35ac203a5a9c9a7023133184",
+	"compute_cells":                "aa4c669061a3b79478f1aa873dac42f303a47531860dfb5da4ae554c3ca35fc5",
+	"verify_kzg_proof":             "ccafb20fbbb565fcd93c68f9c73071ff830b2f78d420fc877970fb2bc76b9a7c",
+	"verify_blob_kzg_proof":        "ccafb20fbbb565fcd93c68f9c73071ff830b2f78d420fc877970fb2bc76b9a7c",
+	"verify_blob_kzg_proof_batch":  "df74499609024db38974f610051744bfaa8a41ec22e61b62aa6210e361d0cda9",
+	"verify_cells_with_blob_proof": "bfddc086d07b6d45df675acd37cf743f91ab4a65874782dfa6e206f3050815b4",
+}
+
+func TestGoldenOutputs(t *testing.T) {
+	const numBlobs = 2
+
+	hashes := make(map[string]hash.Hash)
+	write := func(op string, data ...[]byte) {
+		if hashes[op] == nil {
+			hashes[op] = sha256.New()
+		}
+		for _, d := range data {
+			hashes[op].Write(d)
+		}
+	}
+	// The error itself is hashed, so that a platform which rejects a statement for another reason is caught
+	writeResult := func(op string, err error) {
+		if err == nil {
+			write(op, []byte{1})
+		} else {
+			write(op, []byte{0}, []byte(err.Error()))
+		}
+	}
+
+	blobs := make([]gokzg4844.Blob, numBlobs)
+	commitments := make([]gokzg4844.KZGCommitment, numBlobs)
+	proofs := make([]gokzg4844.KZGProof, numBlobs)
+	for i := range blobs {
+		blobs[i] = *GetRandBlob(int64(i))
+		blob := &blobs[i]
+
+		commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+		require.NoError(t, err)
+		write("blob_to_kzg_commitment", commitment[:])
+		commitments[i] = commitment
+
+		inputPoint := GetRandFieldElement(int64(i))
+		proof, claimedValue, err := ctx.ComputeKZGProof(blob, inputPoint, NumGoRoutines)
+		require.NoError(t, err)
+		write("compute_kzg_proof", proof[:], claimedValue[:])
+
+		blobProof, err := ctx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+		require.NoError(t, err)
+		write("compute_blob_kzg_proof", blobProof[:])
+		proofs[i] = blobProof
+
+		cells, err := ctx.ComputeCells(blob)
+		require.NoError(t, err)
+		for j := range cells {
+			write("compute_cells", cells[j][:])
+		}
+
+		// Both valid and invalid statements, so that a platform which accepts or rejects everything is caught
+		writeResult("verify_kzg_proof", ctx.VerifyKZGProof(commitment, inputPoint, claimedValue, proof))
+		writeResult("verify_kzg_proof", ctx.VerifyKZGProof(commitment, inputPoint, claimedValue, blobProof))
+		writeResult("verify_blob_kzg_proof", ctx.VerifyBlobKZGProof(blob, commitment, blobProof))
+		writeResult("verify_blob_kzg_proof", ctx.VerifyBlobKZGProof(blob, commitment, proof))
+
+		indices := make([]gokzg4844.CellIndex, gokzg4844.CellsPerExtBlob)
+		for j := range indices {
+			indices[j] = gokzg4844.CellIndex(j)
+		}
+		writeResult("verify_cells_with_blob_proof", ctx.VerifyCellsWithBlobProof(commitment, cells[:], indices, blobProof))
+		cells[gokzg4844.CellsPerExtBlob-1][0] ^= 1
+		writeResult("verify_cells_with_blob_proof", ctx.VerifyCellsWithBlobProof(commitment, cells[:], indices, blobProof))
+	}
+
+	writeResult("verify_blob_kzg_proof_batch", ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs))
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+	writeResult("verify_blob_kzg_proof_batch", ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs))
+
+	require.Len(t, hashes, len(goldenDigests))
+	for op, h := range hashes {
+		require.Equal(t, goldenDigests[op], hex.EncodeToString(h.Sum(nil)), "%s on %s/%s", op, runtime.GOOS, runtime.GOARCH)
+	}
+}
+
+// goldenPlatforms are the platforms, other than amd64, that TestGoldenOutputsEmulated runs TestGoldenOutputs on.
+var goldenPlatforms = []struct {
+	goos, goarch string
+	// emulator returns the program which runs test binaries of the platform on the host, or an error if it is not
+	// installed.
+	emulator func(goroot string) (string, error)
+}{
+	{"linux", "arm64", func(string) (string, error) {
+		path, err := exec.LookPath("qemu-aarch64-static")
+		if err != nil {
+			return exec.LookPath("qemu-aarch64")
+		}
+		return path, nil
+	}},
+	{"js", "wasm", func(goroot string) (string, error) {
+		if _, err := exec.LookPath("node"); err != nil {
+			return "", err
+		}
+		// The script moved from misc/wasm to lib/wasm in Go 1.24
+		for _, dir := range []string{"lib", "misc"} {
+			path := filepath.Join(goroot, dir, "wasm", "go_js_wasm_exec")
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			}
+		}
+		return "", os.ErrNotExist
+	}},
+}
+
+// TestGoldenOutputsEmulated cross-compiles TestGoldenOutputs for each of the goldenPlatforms and runs it under an
+// emulator. Platforms whose emulator is not installed are skipped.
+func TestGoldenOutputsEmulated(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compiles the tests for other platforms")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the go command is not available")
+	}
+	goroot, err := exec.Command(goBin, "env", "GOROOT").Output()
+	require.NoError(t, err)
+
+	for _, platform := range goldenPlatforms {
+		platform := platform
+		t.Run(platform.goos+"/"+platform.goarch, func(t *testing.T) {
+			if platform.goos == runtime.GOOS && platform.goarch == runtime.GOARCH {
+				t.Skip("TestGoldenOutputs already runs on this platform")
+			}
+			emulator, err := platform.emulator(strings.TrimSpace(string(goroot)))
+			if err != nil {
+				t.Skipf("no emulator for %s/%s: %v", platform.goos, platform.goarch, err)
+			}
+
+			cmd := exec.Command(goBin, "test", "-count=1", "-exec", emulator, "-run", "^TestGoldenOutputs$", ".")
+			cmd.Env = append(os.Environ(), "GOOS="+platform.goos, "GOARCH="+platform.goarch, "CGO_ENABLED=0")
+			output, err := cmd.CombinedOutput()
+			require.NoError(t, err, "%s", output)
+		})
+	}
+}
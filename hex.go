@@ -0,0 +1,96 @@
+package gokzg4844
+
+import (
+	"encoding/hex"
+)
+
+// The byte types of the API implement [encoding.TextMarshaler] and [encoding.TextUnmarshaler] with the 0x-prefixed
+// hex encoding used by the beacon API and the consensus spec test vectors. encoding/json uses these methods, so the
+// types are encoded as JSON strings.
+
+// MarshalText returns the 0x-prefixed hex encoding of the blob.
+func (b Blob) MarshalText() ([]byte, error) {
+	return marshalHex(b[:]), nil
+}
+
+// UnmarshalText sets the blob to the bytes of the 0x-prefixed hex string.
+//
+// It returns [ErrInvalidHexEncoding] if the text is not a 0x-prefixed hex string with [ScalarsPerBlob] scalars. The
+// scalars are not checked to be canonical.
+func (b *Blob) UnmarshalText(text []byte) error {
+	return unmarshalHex(b[:], text)
+}
+
+// MarshalText returns the 0x-prefixed hex encoding of the commitment.
+func (commitment KZGCommitment) MarshalText() ([]byte, error) {
+	return marshalHex(commitment[:]), nil
+}
+
+// UnmarshalText sets the commitment to the bytes of the 0x-prefixed hex string.
+//
+// It returns [ErrInvalidHexEncoding] if the text is not a 0x-prefixed hex string of [CompressedG1Size] bytes. The
+// commitment is not checked to be a valid point; see [DeserializeKZGCommitment].
+func (commitment *KZGCommitment) UnmarshalText(text []byte) error {
+	return unmarshalHex(commitment[:], text)
+}
+
+// MarshalText returns the 0x-prefixed hex encoding of the proof.
+func (proof KZGProof) MarshalText() ([]byte, error) {
+	return marshalHex(proof[:]), nil
+}
+
+// UnmarshalText sets the proof to the bytes of the 0x-prefixed hex string.
+//
+// It returns [ErrInvalidHexEncoding] if the text is not a 0x-prefixed hex string of [CompressedG1Size] bytes. The
+// proof is not checked to be a valid point; see [DeserializeKZGProof].
+func (proof *KZGProof) UnmarshalText(text []byte) error {
+	return unmarshalHex(proof[:], text)
+}
+
+// MarshalText returns the 0x-prefixed hex encoding of the scalar.
+func (s Scalar) MarshalText() ([]byte, error) {
+	return marshalHex(s[:]), nil
+}
+
+// UnmarshalText sets the scalar to the bytes of the 0x-prefixed hex string.
+//
+// It returns [ErrInvalidHexEncoding] if the text is not a 0x-prefixed hex string of [SerializedScalarSize] bytes.
+// The scalar is not checked to be canonical; see [DeserializeScalar].
+func (s *Scalar) UnmarshalText(text []byte) error {
+	return unmarshalHex(s[:], text)
+}
+
+// MarshalText returns the 0x-prefixed hex encoding of the versioned hash.
+func (h VersionedHash) MarshalText() ([]byte, error) {
+	return marshalHex(h[:]), nil
+}
+
+// UnmarshalText sets the versioned hash to the bytes of the 0x-prefixed hex string.
+//
+// It returns [ErrInvalidHexEncoding] if the text is not a 0x-prefixed hex string of 32 bytes. The version is not
+// checked; see [VersionedHash.Validate].
+func (h *VersionedHash) UnmarshalText(text []byte) error {
+	return unmarshalHex(h[:], text)
+}
+
+// marshalHex returns the hex encoding of the bytes with the 0x prefix.
+func marshalHex(byts []byte) []byte {
+	text := make([]byte, 2+hex.EncodedLen(len(byts)))
+	copy(text, "0x")
+	hex.Encode(text[2:], byts)
+	return text
+}
+
+// unmarshalHex decodes the 0x-prefixed hex string into dst, which it must fill exactly. dst is left unchanged if the
+// text is invalid.
+func unmarshalHex(dst []byte, text []byte) error {
+	if len(text) != 2+hex.EncodedLen(len(dst)) || string(text[:2]) != "0x" {
+		return ErrInvalidHexEncoding
+	}
+	decoded := make([]byte, len(dst))
+	if _, err := hex.Decode(decoded, text[2:]); err != nil {
+		return ErrInvalidHexEncoding
+	}
+	copy(dst, decoded)
+	return nil
+}
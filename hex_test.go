@@ -0,0 +1,59 @@
+package gokzg4844_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHexRoundTrip(t *testing.T) {
+	blob := GetRandBlob(1)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	proof, err := ctx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+
+	type sidecar struct {
+		Blob       gokzg4844.Blob          `json:"blob"`
+		Commitment gokzg4844.KZGCommitment `json:"kzg_commitment"`
+		Proof      gokzg4844.KZGProof      `json:"kzg_proof"`
+		Point      gokzg4844.Scalar        `json:"z"`
+	}
+	expected := sidecar{Blob: *blob, Commitment: commitment, Proof: proof, Point: GetRandFieldElement(2)}
+	encoded, err := json.Marshal(expected)
+	require.NoError(t, err)
+
+	var fields map[string]string
+	require.NoError(t, json.Unmarshal(encoded, &fields))
+	require.Len(t, fields["blob"], 2+2*len(blob))
+	require.True(t, strings.HasPrefix(fields["kzg_commitment"], "0x"))
+
+	var decoded sidecar
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, expected, decoded)
+}
+
+func TestHexUnmarshalErrors(t *testing.T) {
+	valid := "0x" + strings.Repeat("ab", gokzg4844.CompressedG1Size)
+	var commitment gokzg4844.KZGCommitment
+	require.NoError(t, commitment.UnmarshalText([]byte(valid)))
+
+	for _, text := range []string{
+		"",
+		strings.Repeat("ab", gokzg4844.CompressedG1Size),
+		valid[:len(valid)-2],
+		valid + "ab",
+		"0X" + valid[2:],
+		valid[:len(valid)-1] + "g",
+	} {
+		var proof gokzg4844.KZGProof
+		require.ErrorIs(t, proof.UnmarshalText([]byte(text)), gokzg4844.ErrInvalidHexEncoding, text)
+		require.Equal(t, gokzg4844.KZGProof{}, proof)
+	}
+
+	var blob gokzg4844.Blob
+	require.ErrorIs(t, json.Unmarshal([]byte(`"0x00"`), &blob), gokzg4844.ErrInvalidHexEncoding)
+}
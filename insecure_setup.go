@@ -0,0 +1,161 @@
+package gokzg4844
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/utils"
+)
+
+// insecureSeedDomain is the domain separator for [InsecureSecretFromSeed].
+const insecureSeedDomain = "GOKZG_INSECURE_SEED_V1__"
+
+// InsecureSetup is a trusted setup which was generated from a known secret by [NewInsecureSetup].
+//
+// Anyone who knows the secret can create proofs for false statements, so an InsecureSetup must only be used for
+// testing; for example, for differential tests against other libraries which use the same toy secret.
+type InsecureSetup struct {
+	// G1Monomial holds [secret^i]G for i in [0, size).
+	G1Monomial []bls12381.G1Affine
+	// G1Lagrange holds the Lagrange form of G1Monomial over the domain of the same size, in natural order.
+	G1Lagrange []bls12381.G1Affine
+	// G2Monomial holds [secret^i]H for i in [0, numG2).
+	G2Monomial []bls12381.G2Affine
+}
+
+// NewInsecureSetup generates a trusted setup of the given size from a known secret, which is reduced modulo the
+// order of the scalar field. The same secret and sizes always give the same setup.
+//
+// size must be a power of two, and is the number of G1 points; numG2 is the number of G2 points, which must be at
+// least two. The setups of [c-kzg-4844] have 65 G2 points.
+//
+// Returns [ErrInvalidDomainSize] if size is not a power of two, and [ErrInvalidInsecureSecret] if the secret is nil
+// or reduces to zero, or numG2 is less than two.
+//
+// This function must not be used to create a setup for production use.
+//
+// [c-kzg-4844]: https://github.com/ethereum/c-kzg-4844
+func NewInsecureSetup(secret *big.Int, size uint64, numG2 int) (*InsecureSetup, error) {
+	if secret == nil || numG2 < 2 {
+		return nil, ErrInvalidInsecureSecret
+	}
+	var alpha fr.Element
+	alpha.SetBigInt(secret)
+	if alpha.IsZero() {
+		return nil, ErrInvalidInsecureSecret
+	}
+	domain, err := NewDomain(size)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, genG1, genG2 := bls12381.Generators()
+
+	// The powers start at alpha^0, whose multiple is the generator itself
+	numG1 := uint(size)
+	if uint(numG2) > numG1 {
+		numG1 = uint(numG2)
+	}
+	powers := utils.ComputePowers(alpha, numG1)
+
+	g1Monomial := make([]bls12381.G1Affine, size)
+	g1Monomial[0] = genG1
+	copy(g1Monomial[1:], bls12381.BatchScalarMultiplicationG1(&genG1, powers[1:size]))
+	g2Monomial := make([]bls12381.G2Affine, numG2)
+	g2Monomial[0] = genG2
+	copy(g2Monomial[1:], bls12381.BatchScalarMultiplicationG2(&genG2, powers[1:numG2]))
+
+	g1Lagrange, err := domain.MonomialToLagrangeG1(g1Monomial, NaturalOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InsecureSetup{
+		G1Monomial: g1Monomial,
+		G1Lagrange: g1Lagrange,
+		G2Monomial: g2Monomial,
+	}, nil
+}
+
+// InsecureSecretFromSeed derives a secret for [NewInsecureSetup] from a seed, by hashing it with SHA-256. This lets
+// tests name a setup by a short seed instead of a number.
+//
+// This function must not be used to create a setup for production use.
+func InsecureSecretFromSeed(seed []byte) *big.Int {
+	h := sha256.New()
+	h.Write([]byte(insecureSeedDomain))
+	h.Write(seed)
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
+
+// JSON returns the setup as a [JSONTrustedSetup], so that it can be written out with [JSONTrustedSetup.WriteJSON] or
+// given to [NewContext4096].
+//
+// Returns [ErrInvalidDomainSize] if the setup does not have [ScalarsPerBlob] G1 points.
+func (s *InsecureSetup) JSON() (*JSONTrustedSetup, error) {
+	if len(s.G1Lagrange) != ScalarsPerBlob {
+		return nil, ErrInvalidDomainSize
+	}
+
+	var setup JSONTrustedSetup
+	for i := range s.G1Lagrange {
+		compressed := s.G1Lagrange[i].Bytes()
+		setup.SetupG1Lagrange[i] = "0x" + hex.EncodeToString(compressed[:])
+	}
+	setup.SetupG2 = make([]G2CompressedHexStr, len(s.G2Monomial))
+	for i := range s.G2Monomial {
+		compressed := s.G2Monomial[i].Bytes()
+		setup.SetupG2[i] = "0x" + hex.EncodeToString(compressed[:])
+	}
+	return &setup, nil
+}
+
+// NewContext4096Insecure creates a context from the setup generated by [NewInsecureSetup] with the given secret,
+// [ScalarsPerBlob] G1 points and 65 G2 points, as used by the test setups of [c-kzg-4844].
+//
+// This function must not be used to create a context for production use.
+//
+// [c-kzg-4844]: https://github.com/ethereum/c-kzg-4844
+func NewContext4096Insecure(secret *big.Int, opts ...Option) (*Context, error) {
+	setup, err := NewInsecureSetup(secret, ScalarsPerBlob, 65)
+	if err != nil {
+		return nil, err
+	}
+
+	// The points are known to be in the subgroup, so there is no need to encode and check them
+	cfg := newConfig(opts)
+	if err := SelfTest(); err != nil {
+		return nil, err
+	}
+	cfg.setupChecks = SetupChecksNever
+	return newContext4096FromPoints(cfg, setup.G1Lagrange, setup.G2Monomial)
+}
+
+// specsInsecureSecret is the secret of the insecure setups which the consensus specs generate for their tests.
+const specsInsecureSecret = 1337
+
+// NewContextMinimalInsecure creates a context for the [MinimalPreset] from the setup generated by [NewInsecureSetup]
+// with the secret used by the consensus spec tests, 4 G1 points and 65 G2 points. This lets the minimal preset spec
+// tests run against this library.
+//
+// Only the methods which take slices, such as [Context.BlobToKZGCommitmentBytes], can be used with the context; see
+// [NewContextWithPreset].
+//
+// This function must not be used to create a context for production use.
+func NewContextMinimalInsecure(opts ...Option) (*Context, error) {
+	setup, err := NewInsecureSetup(big.NewInt(specsInsecureSecret), uint64(minimalPreset.ScalarsPerBlob), 65)
+	if err != nil {
+		return nil, err
+	}
+
+	// The points are known to be in the subgroup, so there is no need to check them
+	cfg := newConfig(opts)
+	if err := SelfTest(); err != nil {
+		return nil, err
+	}
+	cfg.setupChecks = SetupChecksNever
+	return newContextFromPoints(cfg, minimalPreset, setup.G1Lagrange, setup.G2Monomial)
+}
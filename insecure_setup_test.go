@@ -0,0 +1,117 @@
+package gokzg4844_test
+
+import (
+	"math/big"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInsecureSetup(t *testing.T) {
+	secret := big.NewInt(1337)
+	setup, err := gokzg4844.NewInsecureSetup(secret, 8, 3)
+	require.NoError(t, err)
+	require.Len(t, setup.G1Monomial, 8)
+	require.Len(t, setup.G1Lagrange, 8)
+	require.Len(t, setup.G2Monomial, 3)
+
+	again, err := gokzg4844.NewInsecureSetup(secret, 8, 3)
+	require.NoError(t, err)
+	require.Equal(t, setup, again)
+
+	_, _, genG1, genG2 := bls12381.Generators()
+	var expected bls12381.G1Affine
+	expected.ScalarMultiplication(&genG1, big.NewInt(1337*1337))
+	require.True(t, expected.Equal(&setup.G1Monomial[2]))
+
+	// The G1 and G2 points use the same secret
+	ok, err := bls12381.PairingCheck(
+		[]bls12381.G1Affine{setup.G1Monomial[1], *new(bls12381.G1Affine).Neg(&genG1)},
+		[]bls12381.G2Affine{genG2, setup.G2Monomial[1]},
+	)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The Lagrange basis polynomials sum to one, so the Lagrange points sum to the generator
+	var sum bls12381.G1Jac
+	for i := range setup.G1Lagrange {
+		sum.AddMixed(&setup.G1Lagrange[i])
+	}
+	require.True(t, new(bls12381.G1Affine).FromJacobian(&sum).Equal(&genG1))
+}
+
+func TestNewInsecureSetupInvalid(t *testing.T) {
+	_, err := gokzg4844.NewInsecureSetup(nil, 8, 2)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidInsecureSecret)
+	_, err = gokzg4844.NewInsecureSetup(big.NewInt(0), 8, 2)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidInsecureSecret)
+	_, err = gokzg4844.NewInsecureSetup(big.NewInt(5), 8, 1)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidInsecureSecret)
+	_, err = gokzg4844.NewInsecureSetup(big.NewInt(5), 6, 2)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidDomainSize)
+
+	setup, err := gokzg4844.NewInsecureSetup(big.NewInt(5), 8, 2)
+	require.NoError(t, err)
+	_, err = setup.JSON()
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidDomainSize)
+}
+
+func TestNewContext4096Insecure(t *testing.T) {
+	secret := gokzg4844.InsecureSecretFromSeed([]byte("differential"))
+	insecureCtx, err := gokzg4844.NewContext4096Insecure(secret)
+	require.NoError(t, err)
+	require.NotEqual(t, gokzg4844.MainnetSetupDigest, insecureCtx.SetupDigest())
+
+	// The JSON form of the setup gives the same context
+	setup, err := gokzg4844.NewInsecureSetup(secret, gokzg4844.ScalarsPerBlob, 65)
+	require.NoError(t, err)
+	jsonSetup, err := setup.JSON()
+	require.NoError(t, err)
+	require.Equal(t, jsonSetup, insecureCtx.TrustedSetup())
+
+	blob := GetRandBlob(7)
+	commitment, err := insecureCtx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	proof, err := insecureCtx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+	require.NoError(t, insecureCtx.VerifyBlobKZGProof(blob, commitment, proof))
+
+	// A proof for one setup does not verify with another
+	err = ctx.VerifyBlobKZGProof(blob, commitment, proof)
+	require.ErrorIs(t, err, gokzg4844.ErrProofInvalid)
+}
+
+func TestNewContextMinimalInsecure(t *testing.T) {
+	minimalCtx, err := gokzg4844.NewContextMinimalInsecure()
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.MinimalPreset(), minimalCtx.Preset())
+	require.NoError(t, gokzg4844.MinimalPreset().Validate())
+
+	blobSize := gokzg4844.MinimalPreset().BytesPerBlob()
+	blobs := [][]byte{GetRandBlob(31)[:blobSize], GetRandBlob(32)[:blobSize]}
+	commitments := make([]gokzg4844.KZGCommitment, len(blobs))
+	proofs := make([]gokzg4844.KZGProof, len(blobs))
+	for i, blob := range blobs {
+		commitments[i], err = minimalCtx.BlobToKZGCommitmentBytes(blob, NumGoRoutines)
+		require.NoError(t, err)
+		proofs[i], err = minimalCtx.ComputeBlobKZGProofBytes(blob, commitments[i], NumGoRoutines)
+		require.NoError(t, err)
+		require.NoError(t, minimalCtx.VerifyBlobKZGProofBytes(blob, commitments[i], proofs[i]))
+	}
+	require.NoError(t, minimalCtx.VerifyBlobKZGProofBatchBytes(blobs, commitments, proofs))
+
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+	err = minimalCtx.VerifyBlobKZGProofBatchBytes(blobs, commitments, proofs)
+	require.ErrorIs(t, err, gokzg4844.ErrProofInvalid)
+	var batchErr *gokzg4844.BatchVerificationError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, 0, batchErr.Index)
+
+	// Mainnet blobs cannot be used with the minimal context
+	err = minimalCtx.VerifyBlobKZGProofBatchBytes([][]byte{GetRandBlob(33)[:]}, commitments[:1], proofs[:1])
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidBlobLength)
+	_, err = minimalCtx.BlobToKZGCommitment(GetRandBlob(33), NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrPresetMismatch)
+}
@@ -0,0 +1,105 @@
+package kzg
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/utils"
+)
+
+// CosetDomain is the coset `shift * H` of the multiplicative subgroup H of a [Domain], along with the values needed
+// to move between the coefficient form of a polynomial and its evaluations over the coset.
+//
+// Evaluations over the coset are always in natural order: the i'th evaluation is at shift * w^i, where w is the
+// generator of the domain.
+type CosetDomain struct {
+	// Domain is the domain whose subgroup is shifted.
+	Domain *Domain
+	// Shift is the element that the subgroup is multiplied by.
+	Shift fr.Element
+	// ShiftInv is the inverse of Shift.
+	ShiftInv fr.Element
+
+	// ShiftPowers[i] = Shift^i for 0 <= i < n.
+	ShiftPowers []fr.Element
+	// ShiftInvPowers[i] = Shift^-i for 0 <= i < n.
+	ShiftInvPowers []fr.Element
+
+	// VanishingEval is the evaluation of the vanishing polynomial of the
+	// domain, Z(X) = X^n - 1, over the coset. Since (shift * w)^n = shift^n
+	// for every w in the domain, this value is the same for every point in the
+	// coset.
+	VanishingEval fr.Element
+	// VanishingEvalInv is the inverse of VanishingEval.
+	VanishingEvalInv fr.Element
+}
+
+// NewCosetDomain returns the coset `shift * H` of the subgroup H of the domain.
+//
+// Returns [ErrInvalidCosetShift] if shift is in H, including if it is one, since the coset is then H itself and the
+// vanishing polynomial of the domain is zero over it, or if shift is zero.
+func NewCosetDomain(domain *Domain, shift fr.Element) (*CosetDomain, error) {
+	vanishingEval := domain.EvaluateVanishingPolynomial(shift)
+	if vanishingEval.IsZero() || shift.IsZero() {
+		return nil, ErrInvalidCosetShift
+	}
+
+	var shiftInv fr.Element
+	shiftInv.Inverse(&shift)
+	coset := &CosetDomain{
+		Domain:         domain,
+		Shift:          shift,
+		ShiftInv:       shiftInv,
+		ShiftPowers:    utils.ComputePowers(shift, uint(domain.Cardinality)),
+		ShiftInvPowers: utils.ComputePowers(shiftInv, uint(domain.Cardinality)),
+		VanishingEval:  vanishingEval,
+	}
+	coset.VanishingEvalInv.Inverse(&coset.VanishingEval)
+	return coset, nil
+}
+
+// EvaluateVanishingPolynomial returns the evaluation at x of the vanishing polynomial of the domain,
+// Z(X) = X^n - 1, which is zero exactly at the roots of unity of the domain.
+func (domain *Domain) EvaluateVanishingPolynomial(x fr.Element) fr.Element {
+	var result fr.Element
+	result.Exp(x, new(big.Int).SetUint64(domain.Cardinality))
+	one := fr.One()
+	result.Sub(&result, &one)
+	return result
+}
+
+// Roots returns the points of the coset, shift * r for each root r of the domain, in the order in which the domain
+// stores its roots. This is the natural order of [CosetDomain.FftFr], unless the roots of the domain have been
+// reversed or permuted.
+func (coset *CosetDomain) Roots() []fr.Element {
+	roots := make([]fr.Element, len(coset.Domain.Roots))
+	for i := range roots {
+		roots[i].Mul(&coset.Shift, &coset.Domain.Roots[i])
+	}
+	return roots
+}
+
+// FftFr returns the evaluations over the coset of the polynomial with the given coefficients, whose number must be
+// the size of the domain.
+func (coset *CosetDomain) FftFr(coeffs []fr.Element) []fr.Element {
+	// f(shift * X) has coefficients c_i * shift^i, so evaluating it
+	// over the domain gives the evaluations of f(X) over the coset.
+	scaled := make([]fr.Element, len(coeffs))
+	for i := 0; i < len(coeffs); i++ {
+		scaled[i].Mul(&coeffs[i], &coset.ShiftPowers[i])
+	}
+
+	return coset.Domain.FftFr(scaled)
+}
+
+// IfftFr returns the coefficients of the polynomial with the given evaluations over the coset.
+//
+// This is the inverse of [CosetDomain.FftFr].
+func (coset *CosetDomain) IfftFr(evaluations []fr.Element) []fr.Element {
+	coeffs := coset.Domain.IfftFr(evaluations)
+	for i := 0; i < len(coeffs); i++ {
+		coeffs[i].Mul(&coeffs[i], &coset.ShiftInvPowers[i])
+	}
+
+	return coeffs
+}
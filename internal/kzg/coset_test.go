@@ -0,0 +1,44 @@
+package kzg
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosetDomain(t *testing.T) {
+	domain := NewDomain(16)
+	shift := fr.NewElement(7)
+	coset, err := NewCosetDomain(domain, shift)
+	require.NoError(t, err)
+
+	poly := make(Polynomial, domain.Cardinality)
+	for i := range poly {
+		poly[i].SetUint64(uint64(3*i + 1))
+	}
+	coeffs := domain.IfftFr(poly)
+
+	// The evaluations over the coset are at its roots, where the vanishing
+	// polynomial of the domain takes the same value
+	evaluations := coset.FftFr(coeffs)
+	for i, root := range coset.Roots() {
+		expected, err := domain.EvaluateLagrangePolynomial(poly, root)
+		require.NoError(t, err)
+		require.True(t, expected.Equal(&evaluations[i]))
+
+		vanishing := domain.EvaluateVanishingPolynomial(root)
+		require.True(t, vanishing.Equal(&coset.VanishingEval))
+	}
+	var product fr.Element
+	product.Mul(&coset.VanishingEval, &coset.VanishingEvalInv)
+	require.True(t, product.IsOne())
+
+	require.Equal(t, coeffs, coset.IfftFr(evaluations))
+
+	// The coset must be disjoint from the domain
+	for _, shift := range []fr.Element{{}, fr.One(), domain.Roots[3]} {
+		_, err := NewCosetDomain(domain, shift)
+		require.ErrorIs(t, err, ErrInvalidCosetShift)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 	"math/bits"
+	"sync"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/crate-crypto/go-kzg-4844/internal/utils"
@@ -31,16 +32,35 @@ type Domain struct {
 	GeneratorInv fr.Element
 
 	// Roots of unity for the multiplicative subgroup
-	// Note that these may or may not be in bit-reversed order.
+	// Note that these may or may not be in bit-reversed order;
+	// see Order.
 	Roots []fr.Element
+	// Order is the order in which Roots and PreComputedInverses are stored.
+	// It is changed by [Domain.ReverseRoots] and [Domain.PermuteRoots].
+	Order RootOrder
 
 	// Precomputed inverses of the domain which
 	// we will use to speed up the computation of
 	// f(x)/g(x) where g(x) is a linear polynomial
 	// which vanishes on a point on the domain
+	//
+	// This may be nil to save memory, in which case
+	// the inverse is computed when it is needed.
 	PreComputedInverses []fr.Element
 }
 
+// RootOrder is the order in which the roots of a [Domain] are stored.
+type RootOrder int
+
+const (
+	// NaturalRootOrder stores the i'th power of the generator at index i. This is the order of a new domain.
+	NaturalRootOrder RootOrder = iota
+	// BitReversedRootOrder stores the roots in natural order after the bit-reversal permutation.
+	BitReversedRootOrder
+	// PermutedRootOrder stores the roots in an order set with [Domain.PermuteRoots].
+	PermutedRootOrder
+)
+
 // NewDomain returns a new domain with the desired number of points x.
 //
 // We only support powers of 2 for x.
@@ -49,12 +69,74 @@ type Domain struct {
 //
 // [gnark-crypto]: https://github.com/ConsenSys/gnark-crypto/blob/8f7ca09273c24ed9465043566906cbecf5dcee91/ecc/bls12-381/fr/fft/domain.go#L66
 func NewDomain(x uint64) *Domain {
+	return NewDomainPar(x, 1)
+}
+
+// NewDomainPar is the same as [NewDomain], except that the roots are computed by up to numGoRoutines go-routines.
+// Setting numGoRoutines to a negative number or 0 will make it default to the number of CPUs.
+func NewDomainPar(x uint64, numGoRoutines int) *Domain {
 	if bits.OnesCount64(x) != 1 {
 		panic(fmt.Sprintf("x (%d) is not a power of 2. This library only supports domain sizes that are powers of two", x))
 	}
 	domain := &Domain{}
 	domain.Cardinality = x
 
+	domain.Generator = PrimitiveRootOfUnity(x) // Domain.Generator has order x now.
+
+	// Store Inverse of the generator and inverse of the domain size (as field elements).
+	domain.GeneratorInv.Inverse(&domain.Generator)
+	domain.CardinalityInv.SetUint64(x)
+	domain.CardinalityInv.Inverse(&domain.CardinalityInv)
+
+	// Compute all relevant roots of unity, i.e. the multiplicative subgroup of size x.
+	domain.Roots = naturalRoots(domain.Generator, x, numGoRoutines)
+
+	// Compute precomputed inverses: 1 / w^i
+	// Note: domain.PreComputedInverses[i] == domain.Roots[x-i mod x], so
+	// these are redundant, but simplify writing down some algorithms
+	// and not deal with the case where the roots are bit-reversed.
+	// We use BatchInvert instead of the above for clarity.
+	domain.PreComputedInverses = fr.BatchInvert(domain.Roots)
+
+	return domain
+}
+
+// MaxDomainSize is the size of the largest subgroup of the scalar field whose order is a power of two, and so of the
+// largest domain.
+const MaxDomainSize = 1 << 32
+
+// MaxCachedDomainSize is the size of the largest domain that is kept by [CachedDomain], so that a larger domain, which
+// is only created at the request of the caller, is not kept for the lifetime of the process.
+const MaxCachedDomainSize = 1 << 16
+
+// domainCache maps the size of each domain computed by [CachedDomain] to the domain.
+var domainCache sync.Map
+
+// CachedDomain is the same as [NewDomainPar], except that domains of up to [MaxCachedDomainSize] roots are only
+// computed once per process and then shared. The returned domain must therefore not be modified; callers which need
+// its roots in another order must permute a copy.
+func CachedDomain(x uint64, numGoRoutines int) *Domain {
+	if x > MaxCachedDomainSize {
+		return NewDomainPar(x, numGoRoutines)
+	}
+	if domain, ok := domainCache.Load(x); ok {
+		return domain.(*Domain)
+	}
+	// Another go-routine may compute the same domain concurrently, in which
+	// case only one of them is kept.
+	domain, _ := domainCache.LoadOrStore(x, NewDomainPar(x, numGoRoutines))
+	return domain.(*Domain)
+}
+
+// PrimitiveRootOfUnity returns a primitive x'th root of unity, that is, an element
+// of order x. This is the generator used by [NewDomain] for a domain of size x.
+//
+// x must be a power of two which is at most 2^32, otherwise this method will panic.
+func PrimitiveRootOfUnity(x uint64) fr.Element {
+	if bits.OnesCount64(x) != 1 {
+		panic(fmt.Sprintf("x (%d) is not a power of 2. This library only supports domain sizes that are powers of two", x))
+	}
+
 	// Generator of the largest 2-adic subgroup.
 	// This particular element has order 2^maxOrderRoot == 2^32.
 	var rootOfUnity fr.Element
@@ -72,29 +154,10 @@ func NewDomain(x uint64) *Domain {
 		panic(fmt.Sprintf("x (%d) is too big: the required root of unity does not exist", x))
 	}
 	expo := uint64(1 << (maxOrderRoot - logx))
-	domain.Generator.Exp(rootOfUnity, big.NewInt(int64(expo))) // Domain.Generator has order x now.
-
-	// Store Inverse of the generator and inverse of the domain size (as field elements).
-	domain.GeneratorInv.Inverse(&domain.Generator)
-	domain.CardinalityInv.SetUint64(x)
-	domain.CardinalityInv.Inverse(&domain.CardinalityInv)
-
-	// Compute all relevant roots of unity, i.e. the multiplicative subgroup of size x.
-	domain.Roots = make([]fr.Element, x)
-	current := fr.One()
-	for i := uint64(0); i < x; i++ {
-		domain.Roots[i] = current
-		current.Mul(&current, &domain.Generator)
-	}
 
-	// Compute precomputed inverses: 1 / w^i
-	// Note: domain.PreComputedInverses[i] == domain.Roots[x-i mod x], so
-	// these are redundant, but simplify writing down some algorithms
-	// and not deal with the case where the roots are bit-reversed.
-	// We use BatchInvert instead of the above for clarity.
-	domain.PreComputedInverses = fr.BatchInvert(domain.Roots)
-
-	return domain
+	var generator fr.Element
+	generator.Exp(rootOfUnity, big.NewInt(int64(expo)))
+	return generator
 }
 
 /*
@@ -109,54 +172,115 @@ once you introduce sharding. So best to use it from the start and not have
 to think about all these when you add DAS.
 */
 
-// bitReverse applies the bit-reversal permutation to `list`.
-// `len(list)` must be a power of 2
-//
-// This means that for post-state list output and pre-state list input,
-// we have output[i] == input[bitreverse(i)], where bitreverse reverses the bit-pattern
-// of i, interpreted as a log2(len(list))-bit integer.
+// ReverseRoots applies the bit-reversal permutation to the list of precomputed roots of unity and their inverses in the
+// domain, if they are in natural order. It does nothing if they are already in bit-reversed order, so that calling it
+// twice does not undo it.
 //
-// This is in no way needed for basic KZG and is included in this library as
-// a stepping-stone to full Dank-sharding.
+// This method panics if the roots have been permuted with [Domain.PermuteRoots].
 //
-// Modified from [gnark-crypto].
+// [bit_reversal_permutation]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#bit_reversal_permutation
+func (domain *Domain) ReverseRoots() {
+	switch domain.Order {
+	case BitReversedRootOrder:
+		return
+	case NaturalRootOrder:
+	default:
+		panic("roots which have been permuted cannot be bit-reversed")
+	}
+	utils.BitReverse(domain.Roots)
+	if domain.PreComputedInverses != nil {
+		utils.BitReverse(domain.PreComputedInverses)
+	}
+	domain.Order = BitReversedRootOrder
+}
+
+// PermuteRoots permutes the roots of unity in the domain and their inverses, which must be in natural order, so that
+// the i'th root is the sourceIndices[i]'th one in natural order. sourceIndices must be a permutation of the indices of
+// the domain.
 //
-// [gnark-crypto]: https://github.com/ConsenSys/gnark-crypto/blob/8f7ca09273c24ed9465043566906cbecf5dcee91/ecc/bls12-381/fr/fft/fft.go#L245
+// This method panics if the roots are not in natural order.
+func (domain *Domain) PermuteRoots(sourceIndices []int) {
+	if domain.Order != NaturalRootOrder {
+		panic("only roots in natural order can be permuted")
+	}
+	domain.Roots = permute(domain.Roots, sourceIndices)
+	if domain.PreComputedInverses != nil {
+		domain.PreComputedInverses = permute(domain.PreComputedInverses, sourceIndices)
+	}
+	domain.Order = PermutedRootOrder
+}
+
+// RootsInOrder returns a copy of the roots of unity of the domain in the given order, whatever the order that they
+// are stored in. The domain is not modified.
 //
-// [reverse_bits]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#reverse_bits
-func bitReverse[K interface{}](list []K) {
-	n := uint64(len(list))
-	if !utils.IsPowerOfTwo(n) {
-		panic("size of list given to bitReverse must be a power of two")
+// This method panics if order is [PermutedRootOrder], since the permutation is not known to the domain.
+func (domain *Domain) RootsInOrder(order RootOrder) []fr.Element {
+	if order == domain.Order && order != PermutedRootOrder {
+		return append([]fr.Element(nil), domain.Roots...)
 	}
 
-	// The standard library's bits.Reverse64 inverts its input as a 64-bit unsigned integer.
-	// However, we need to invert it as a log2(len(list))-bit integer, so we need to correct this by
-	// shifting appropriately.
-	shiftCorrection := uint64(64 - bits.TrailingZeros64(n))
+	roots := naturalRoots(domain.Generator, domain.Cardinality, 1)
+	switch order {
+	case NaturalRootOrder:
+	case BitReversedRootOrder:
+		utils.BitReverse(roots)
+	default:
+		panic("roots can only be returned in natural or bit-reversed order")
+	}
+	return roots
+}
 
-	for i := uint64(0); i < n; i++ {
-		// Find index irev, such that i and irev get swapped
-		irev := bits.Reverse64(i) >> shiftCorrection
-		if irev > i {
-			list[i], list[irev] = list[irev], list[i]
+// naturalRoots returns the x powers of the generator, in natural order, computed by up to numGoRoutines go-routines.
+// Each go-routine computes a contiguous chunk of the powers, starting from generator^start.
+func naturalRoots(generator fr.Element, x uint64, numGoRoutines int) []fr.Element {
+	roots := make([]fr.Element, x)
+	utils.Parallelize(int(x), numGoRoutines, func(start, end int) {
+		var current fr.Element
+		current.Exp(generator, new(big.Int).SetInt64(int64(start)))
+		for i := start; i < end; i++ {
+			roots[i] = current
+			current.Mul(&current, &generator)
 		}
-	}
+	})
+	return roots
 }
 
-// ReverseRoots applies the bit-reversal permutation to the list of precomputed roots of unity and their inverses in the domain.
-//
-// [bit_reversal_permutation]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#bit_reversal_permutation
-func (domain *Domain) ReverseRoots() {
-	bitReverse(domain.Roots)
-	bitReverse(domain.PreComputedInverses)
+// permute returns the elements of list in the order given by sourceIndices, such that the i'th element of the result
+// is list[sourceIndices[i]].
+func permute(list []fr.Element, sourceIndices []int) []fr.Element {
+	permuted := make([]fr.Element, len(list))
+	for i, j := range sourceIndices {
+		permuted[i] = list[j]
+	}
+	return permuted
 }
 
 // findRootIndex returns the index of the element in the domain or -1 if not found.
 //
 //   - If point is in the domain (meaning that point is a domain.Cardinality'th root of unity), returns the index of the point in the domain.
 //   - If point is not in the domain, returns -1.
+//
+// The roots of unity are exactly the points whose order divides the size of the domain, so a point which is not in the
+// domain, such as a random evaluation point, is detected with log2(domain.Cardinality) squarings. Only the index of a
+// point which is in the domain is found by scanning the roots, since they may be in any order.
 func (domain *Domain) findRootIndex(point fr.Element) int64 {
+	if !domain.isRoot(point) {
+		return -1
+	}
+	return domain.scanRoots(point)
+}
+
+// isRoot reports whether point is a domain.Cardinality'th root of unity, that is, whether point^domain.Cardinality = 1.
+func (domain *Domain) isRoot(point fr.Element) bool {
+	// The cardinality is a power of two, so the exponentiation is a sequence of squarings
+	for i := uint64(1); i < domain.Cardinality; i <<= 1 {
+		point.Square(&point)
+	}
+	return point.IsOne()
+}
+
+// scanRoots is the same as [Domain.findRootIndex], except that it compares the point with each of the roots.
+func (domain *Domain) scanRoots(point fr.Element) int64 {
 	for i := int64(0); i < int64(domain.Cardinality); i++ {
 		if point.Equal(&domain.Roots[i]) {
 			return i
@@ -220,12 +344,122 @@ func (domain *Domain) evaluateLagrangePolynomial(poly Polynomial, evalPoint fr.E
 	}
 
 	// result * (x^width - 1) * 1/width
-	var tmp fr.Element
-	tmp.Exp(evalPoint, big.NewInt(0).SetUint64(domain.Cardinality))
-	one := fr.One()
-	tmp.Sub(&tmp, &one)
+	tmp := domain.EvaluateVanishingPolynomial(evalPoint)
 	tmp.Mul(&tmp, &domain.CardinalityInv)
 	result.Mul(&tmp, &result)
 
 	return &result, indexInDomain, nil
 }
+
+// LagrangeWeights returns the weights w_i such that p(evalPoint) = sum_i w_i * poly[i] for every polynomial p with
+// the evaluations poly over the domain, in the order of domain.Roots. They are the evaluations at evalPoint of the
+// Lagrange polynomials of the domain:
+//
+//	w_i = (x^width - 1) / width * domain.Roots[i] / (x - domain.Roots[i])
+//
+// If evalPoint is in the domain, the weight of its index is one and the others are zero.
+func (domain *Domain) LagrangeWeights(evalPoint fr.Element) []fr.Element {
+	weights := make([]fr.Element, domain.Cardinality)
+	if index := domain.findRootIndex(evalPoint); index != -1 {
+		weights[index].SetOne()
+		return weights
+	}
+
+	for i := range weights {
+		weights[i].Sub(&evalPoint, &domain.Roots[i])
+	}
+	weights = fr.BatchInvert(weights)
+
+	scale := domain.EvaluateVanishingPolynomial(evalPoint)
+	scale.Mul(&scale, &domain.CardinalityInv)
+	for i := range weights {
+		weights[i].Mul(&weights[i], &domain.Roots[i])
+		weights[i].Mul(&weights[i], &scale)
+	}
+	return weights
+}
+
+// EvaluateLagrangePolynomials is the same as [Domain.EvaluateLagrangePolynomial], except that it evaluates each of
+// the polynomials at the same point and returns the evaluations in the same order.
+//
+// The denominators, which only depend on the point, are inverted once for all of the polynomials using
+// [Domain.LagrangeWeights], after which each evaluation takes one multiplication per element.
+func (domain *Domain) EvaluateLagrangePolynomials(polys []Polynomial, evalPoint fr.Element) ([]fr.Element, error) {
+	for _, poly := range polys {
+		if domain.Cardinality != uint64(len(poly)) {
+			return nil, ErrPolynomialMismatchedSizeDomain
+		}
+	}
+
+	weights := domain.LagrangeWeights(evalPoint)
+	evaluations := make([]fr.Element, len(polys))
+	for k, poly := range polys {
+		evaluations[k] = InnerProduct(poly, weights)
+	}
+	return evaluations, nil
+}
+
+// InnerProduct returns sum_i a[i] * b[i], where a and b have the same length.
+func InnerProduct(a, b []fr.Element) fr.Element {
+	var result fr.Element
+	for i := range a {
+		var term fr.Element
+		term.Mul(&a[i], &b[i])
+		result.Add(&result, &term)
+	}
+	return result
+}
+
+// EvaluateLagrangePolynomialBatch is the same as [Domain.EvaluateLagrangePolynomial], except that it evaluates the
+// polynomial at each of the given points and returns the evaluations in the same order.
+//
+// The products poly[i] * domain.Roots[i] are computed once for all of the points, and the denominators of all of the
+// points are inverted together, so that only one field inversion is needed. The vanishing polynomial at each point
+// also tells whether the point is in the domain, in which case its evaluation is looked up instead.
+func (domain *Domain) EvaluateLagrangePolynomialBatch(poly Polynomial, points []fr.Element) ([]fr.Element, error) {
+	if domain.Cardinality != uint64(len(poly)) {
+		return nil, ErrPolynomialMismatchedSizeDomain
+	}
+	n := int(domain.Cardinality)
+
+	evaluations := make([]fr.Element, len(points))
+	vanishing := make([]fr.Element, len(points))
+	outside := make([]int, 0, len(points))
+	for i := range points {
+		vanishing[i] = domain.EvaluateVanishingPolynomial(points[i])
+		if vanishing[i].IsZero() {
+			evaluations[i] = poly[domain.scanRoots(points[i])]
+			continue
+		}
+		outside = append(outside, i)
+	}
+	if len(outside) == 0 {
+		return evaluations, nil
+	}
+
+	numerators := make([]fr.Element, n)
+	for j := range numerators {
+		numerators[j].Mul(&poly[j], &domain.Roots[j])
+	}
+	denom := make([]fr.Element, len(outside)*n)
+	for k, i := range outside {
+		for j := 0; j < n; j++ {
+			denom[k*n+j].Sub(&points[i], &domain.Roots[j])
+		}
+	}
+	invDenom := fr.BatchInvert(denom)
+
+	for k, i := range outside {
+		var result fr.Element
+		for j := 0; j < n; j++ {
+			var div fr.Element
+			div.Mul(&numerators[j], &invDenom[k*n+j])
+			result.Add(&result, &div)
+		}
+
+		// result * (x^width - 1) * 1/width
+		vanishing[i].Mul(&vanishing[i], &domain.CardinalityInv)
+		evaluations[i].Mul(&vanishing[i], &result)
+	}
+	return evaluations, nil
+}
@@ -57,7 +57,7 @@ func TestBitReversal(t *testing.T) {
 		scalars := testScalars(size)
 		reversed := bitReversalPermutation(scalars)
 
-		bitReverse(scalars)
+		utils.BitReverse(scalars)
 
 		for i := 0; i < size; i++ {
 			if !reversed[i].Equal(&scalars[i]) {
@@ -67,6 +67,77 @@ func TestBitReversal(t *testing.T) {
 	}
 }
 
+func TestNewDomainPar(t *testing.T) {
+	for _, size := range []uint64{1, 2, 64, 4096} {
+		expected := NewDomain(size)
+		for _, numGoRoutines := range []int{0, 1, 3, 16} {
+			domain := NewDomainPar(size, numGoRoutines)
+			for i := range expected.Roots {
+				if !domain.Roots[i].Equal(&expected.Roots[i]) || !domain.PreComputedInverses[i].Equal(&expected.PreComputedInverses[i]) {
+					t.Fatalf("root %d of the domain of size %d differs with %d go-routines", i, size, numGoRoutines)
+				}
+			}
+		}
+	}
+}
+
+func TestCachedDomain(t *testing.T) {
+	if CachedDomain(64, 0) != CachedDomain(64, 3) {
+		t.Fatal("the domain of size 64 was computed twice")
+	}
+	if CachedDomain(2*MaxCachedDomainSize, 0) == CachedDomain(2*MaxCachedDomainSize, 0) {
+		t.Fatal("a domain larger than the largest cached size was cached")
+	}
+}
+
+func TestRootOrder(t *testing.T) {
+	domain := NewDomain(16)
+	natural := domain.RootsInOrder(NaturalRootOrder)
+	reversed := bitReversalPermutation(natural)
+
+	equal := func(lhs, rhs []fr.Element) bool {
+		for i := range lhs {
+			if !lhs[i].Equal(&rhs[i]) {
+				return false
+			}
+		}
+		return len(lhs) == len(rhs)
+	}
+
+	// Reversing the roots twice leaves them reversed
+	for i := 0; i < 2; i++ {
+		domain.ReverseRoots()
+		if domain.Order != BitReversedRootOrder || !equal(domain.Roots, reversed) {
+			t.Error("roots are not in bit-reversed order")
+		}
+		for j := range domain.Roots {
+			var inverse fr.Element
+			inverse.Inverse(&domain.Roots[j])
+			if !inverse.Equal(&domain.PreComputedInverses[j]) {
+				t.Error("inverses are not in the order of the roots")
+			}
+		}
+	}
+
+	if !equal(domain.RootsInOrder(NaturalRootOrder), natural) || !equal(domain.RootsInOrder(BitReversedRootOrder), reversed) {
+		t.Error("roots are not returned in the requested order")
+	}
+	// The returned roots are a copy
+	domain.RootsInOrder(BitReversedRootOrder)[0].SetUint64(2)
+	if !domain.Roots[0].IsOne() {
+		t.Error("returned roots share memory with the domain")
+	}
+
+	permuted := NewDomain(4)
+	permuted.PermuteRoots([]int{2, 0, 3, 1})
+	if permuted.Order != PermutedRootOrder || !permuted.Roots[1].IsOne() {
+		t.Error("roots were not permuted")
+	}
+	if !equal(permuted.RootsInOrder(NaturalRootOrder), NewDomain(4).Roots) {
+		t.Error("permuted roots are not returned in natural order")
+	}
+}
+
 // This is simply another way to do the bit reversal,
 // if these were incorrect then integration tests would
 // fail.
@@ -156,6 +227,142 @@ func TestEvalPolynomialSmoke(t *testing.T) {
 	}
 }
 
+func TestFindRootIndex(t *testing.T) {
+	for _, size := range []uint64{1, 2, 16, 4096} {
+		domain := NewDomain(size)
+		domain.ReverseRoots()
+
+		for i := range domain.Roots {
+			if index := domain.findRootIndex(domain.Roots[i]); index != int64(i) {
+				t.Errorf("root %d of the domain of size %d was found at index %d", i, size, index)
+			}
+		}
+
+		// The roots of the domain of twice the size are only half in the domain
+		larger := NewDomain(2 * size)
+		for i := range larger.Roots {
+			expected := domain.scanRoots(larger.Roots[i])
+			if index := domain.findRootIndex(larger.Roots[i]); index != expected {
+				t.Errorf("root %d of the larger domain was found at index %d instead of %d", i, index, expected)
+			}
+			if (expected != -1) != (i%2 == 0) {
+				t.Errorf("root %d of the larger domain is at index %d", i, expected)
+			}
+		}
+
+		var zero fr.Element
+		for _, point := range []fr.Element{zero, *samplePointOutsideDomain(*domain)} {
+			if index := domain.findRootIndex(point); index != -1 {
+				t.Errorf("point outside of the domain was found at index %d", index)
+			}
+		}
+	}
+}
+
+func TestEvaluateLagrangePolynomialBatch(t *testing.T) {
+	domain := NewDomain(16)
+	domain.ReverseRoots()
+	poly := Polynomial(testScalars(16))
+
+	// A mix of points in and outside of the domain
+	points := []fr.Element{domain.Roots[3], *samplePointOutsideDomain(*domain), domain.Roots[0], *samplePointOutsideDomain(*domain)}
+	evaluations, err := domain.EvaluateLagrangePolynomialBatch(poly, points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range points {
+		expected, err := domain.EvaluateLagrangePolynomial(poly, points[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !expected.Equal(&evaluations[i]) {
+			t.Errorf("evaluation %d does not match the evaluation of a single point", i)
+		}
+	}
+
+	if evaluations, err := domain.EvaluateLagrangePolynomialBatch(poly, nil); err != nil || len(evaluations) != 0 {
+		t.Errorf("evaluating at no points returned %v, %v", evaluations, err)
+	}
+	if _, err := domain.EvaluateLagrangePolynomialBatch(poly[:15], points); err != ErrPolynomialMismatchedSizeDomain {
+		t.Errorf("expected ErrPolynomialMismatchedSizeDomain, got %v", err)
+	}
+}
+
+func TestEvaluateLagrangePolynomials(t *testing.T) {
+	domain := NewDomain(16)
+	domain.ReverseRoots()
+	polys := []Polynomial{testScalars(16), testScalars(16), testScalars(16)}
+	for k := range polys {
+		polys[k][k].SetUint64(uint64(100 + k))
+	}
+
+	for _, point := range []fr.Element{*samplePointOutsideDomain(*domain), domain.Roots[5]} {
+		evaluations, err := domain.EvaluateLagrangePolynomials(polys, point)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range polys {
+			expected, err := domain.EvaluateLagrangePolynomial(polys[i], point)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !expected.Equal(&evaluations[i]) {
+				t.Errorf("evaluation of polynomial %d does not match the evaluation of a single polynomial", i)
+			}
+		}
+	}
+
+	if _, err := domain.EvaluateLagrangePolynomials([]Polynomial{polys[0], polys[1][:15]}, domain.Roots[0]); err != ErrPolynomialMismatchedSizeDomain {
+		t.Errorf("expected ErrPolynomialMismatchedSizeDomain, got %v", err)
+	}
+}
+
+func BenchmarkEvaluateLagrangePolynomialBatch(b *testing.B) {
+	domain := NewDomain(4096)
+	poly := Polynomial(testScalars(4096))
+	points := make([]fr.Element, 16)
+	for i := range points {
+		points[i] = *samplePointOutsideDomain(*domain)
+	}
+
+	b.Run("single", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for i := range points {
+				_, _ = domain.EvaluateLagrangePolynomial(poly, points[i])
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			_, _ = domain.EvaluateLagrangePolynomialBatch(poly, points)
+		}
+	})
+}
+
+func BenchmarkFindRootIndex(b *testing.B) {
+	domain := NewDomain(4096)
+	domain.ReverseRoots()
+	outside := *samplePointOutsideDomain(*domain)
+	root := domain.Roots[len(domain.Roots)/2]
+
+	for _, bench := range []struct {
+		name  string
+		point fr.Element
+	}{{"outside", outside}, {"root", root}} {
+		point := bench.point
+		b.Run(bench.name+"/scan", func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_ = domain.scanRoots(point)
+			}
+		})
+		b.Run(bench.name+"/find", func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_ = domain.findRootIndex(point)
+			}
+		})
+	}
+}
+
 func samplePointOutsideDomain(domain Domain) *fr.Element {
 	var randElement fr.Element
 
@@ -8,4 +8,5 @@ var (
 	ErrVerifyOpeningProof             = errors.New("can't verify opening proof")
 	ErrPolynomialMismatchedSizeDomain = errors.New("domain size does not equal the number of evaluations in the polynomial")
 	ErrMinSRSSize                     = errors.New("minimum srs size is 2")
+	ErrInvalidCosetShift              = errors.New("coset shift must not be zero or in the domain")
 )
@@ -13,6 +13,10 @@ import (
 // of the SRS, this can be done once at startup. Even if not cached,
 // this process takes two to three seconds.
 //
+// The FFT over field elements is used to move between the evaluation
+// form and the coefficient form of a polynomial, for example when we
+// need to evaluate a polynomial over a coset of the domain.
+//
 // See: https://faculty.sites.iastate.edu/jia/files/inline-files/polymultiply.pdf
 // for a reference.
 
@@ -42,6 +46,30 @@ func (domain *Domain) IfftG1(values []bls12381.G1Affine) []bls12381.G1Affine {
 	return inverseFFT
 }
 
+// FftFr computes an FFT (Fast Fourier Transform) of the field elements.
+//
+// Given the coefficients of a polynomial, this returns the evaluations of the polynomial
+// over the domain. The elements are returned in order as opposed to being returned in
+// bit-reversed order.
+func (domain *Domain) FftFr(values []fr.Element) []fr.Element {
+	return fftFr(values, domain.Generator)
+}
+
+// IfftFr computes an IFFT (Inverse Fast Fourier Transform) of the field elements.
+//
+// Given the evaluations of a polynomial over the domain, in order, this returns
+// the coefficients of the polynomial.
+func (domain *Domain) IfftFr(values []fr.Element) []fr.Element {
+	inverseFFT := fftFr(values, domain.GeneratorInv)
+
+	// scale by the inverse of the domain size
+	for i := 0; i < len(inverseFFT); i++ {
+		inverseFFT[i].Mul(&inverseFFT[i], &domain.CardinalityInv)
+	}
+
+	return inverseFFT
+}
+
 // fftG1 computes an FFT (Fast Fourier Transform) of the G1 elements.
 //
 // This is the actual implementation of [FftG1] with the same convention.
@@ -92,6 +120,39 @@ func fftG1(values []bls12381.G1Affine, nthRootOfUnity fr.Element) []bls12381.G1A
 	return evaluations
 }
 
+// fftFr computes an FFT (Fast Fourier Transform) of the field elements.
+//
+// This is the same algorithm as [fftG1], with the group operations replaced by field operations.
+// The returned slice is a new slice in "normal" order; the input slice is not modified.
+func fftFr(values []fr.Element, nthRootOfUnity fr.Element) []fr.Element {
+	n := len(values)
+	if n == 1 {
+		return []fr.Element{values[0]}
+	}
+
+	var generatorSquared fr.Element
+	generatorSquared.Square(&nthRootOfUnity) // generator with order n/2
+
+	even, odd := takeEvenOdd(values)
+
+	fftEven := fftFr(even, generatorSquared)
+	fftOdd := fftFr(odd, generatorSquared)
+
+	inputPoint := fr.One()
+	evaluations := make([]fr.Element, n)
+	for k := 0; k < n/2; k++ {
+		var tmp fr.Element
+		tmp.Mul(&inputPoint, &fftOdd[k])
+
+		evaluations[k].Add(&fftEven[k], &tmp)
+		evaluations[k+n/2].Sub(&fftEven[k], &tmp)
+
+		inputPoint.Mul(&inputPoint, &nthRootOfUnity)
+	}
+
+	return evaluations
+}
+
 // takeEvenOdd Takes a slice and return two slices
 // The first slice contains (a copy of) all of the elements
 // at even indices, the second slice contains
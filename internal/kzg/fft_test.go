@@ -3,6 +3,8 @@ package kzg
 import (
 	"math/big"
 	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 )
 
 func TestSRSConversion(t *testing.T) {
@@ -26,3 +28,39 @@ func TestSRSConversion(t *testing.T) {
 		}
 	}
 }
+
+func TestFftFrSmoke(t *testing.T) {
+	domain := NewDomain(16)
+
+	coeffs := make([]fr.Element, domain.Cardinality)
+	for i := range coeffs {
+		_, _ = coeffs[i].SetRandom()
+	}
+
+	// The FFT of the coefficients should be the evaluations of the polynomial at the roots of unity
+	evaluations := domain.FftFr(coeffs)
+	for i := 0; i < int(domain.Cardinality); i++ {
+		expected := evalMonomial(coeffs, domain.Roots[i])
+		if !expected.Equal(&evaluations[i]) {
+			t.Fatalf("fft evaluation at index %d is incorrect", i)
+		}
+	}
+
+	// The IFFT should recover the coefficients
+	gotCoeffs := domain.IfftFr(evaluations)
+	for i := range coeffs {
+		if !gotCoeffs[i].Equal(&coeffs[i]) {
+			t.Fatalf("ifft did not recover the coefficient at index %d", i)
+		}
+	}
+}
+
+// evalMonomial evaluates a polynomial in monomial form using Horner's method.
+func evalMonomial(coeffs []fr.Element, point fr.Element) fr.Element {
+	var result fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		result.Mul(&result, &point)
+		result.Add(&result, &coeffs[i])
+	}
+	return result
+}
@@ -117,7 +117,6 @@ func (domain *Domain) computeQuotientPolyOutsideDomain(f Polynomial, fz, z fr.El
 func (domain *Domain) computeQuotientPolyOnDomain(f Polynomial, index uint64) (Polynomial, error) {
 	fz := f[index]
 	z := domain.Roots[index]
-	invZ := domain.PreComputedInverses[index]
 
 	// Compute the evaluation of X - z at every point in the domain.
 	rootsMinusZ := make([]fr.Element, domain.Cardinality)
@@ -131,10 +130,22 @@ func (domain *Domain) computeQuotientPolyOnDomain(f Polynomial, index uint64) (P
 	// Note: The underlying gnark-crypto library will not panic if
 	// one of the elements is zero, but this is not common across libraries so we just set it to one.
 	rootsMinusZ[index].SetOne()
+	// If the inverses of the roots are not precomputed, z is put in that slot
+	// instead, so that its inverse is computed by the same batch inversion.
+	if domain.PreComputedInverses == nil {
+		rootsMinusZ[index] = z
+	}
 
 	// Evaluation of 1/(X-z) at every point of the domain, except for index.
 	invRootsMinusZ := fr.BatchInvert(rootsMinusZ)
 
+	var invZ fr.Element
+	if domain.PreComputedInverses == nil {
+		invZ = invRootsMinusZ[index]
+	} else {
+		invZ = domain.PreComputedInverses[index]
+	}
+
 	// The rootsMinusZ is now free to reuse, since BatchInvert returned
 	// a fresh slice. But we need to ensure to set the value for 'index' to zero
 	quotientPoly := rootsMinusZ
@@ -1,6 +1,8 @@
 package kzg
 
 import (
+	"bytes"
+	"io"
 	"math/big"
 	"testing"
 
@@ -40,17 +42,91 @@ func TestBatchVerifySmoke(t *testing.T) {
 	}
 
 	// Check that these verify successfully.
-	err := BatchVerifyMultiPoints(commitments, proofs, &srs.OpeningKey)
+	err := BatchVerifyMultiPoints(commitments, proofs, &srs.OpeningKey, nil, 0)
 	require.NoError(t, err)
 
 	// Add an invalid proof, to ensure that it fails
 	proof, _ := randValidOpeningProof(t, *domain, *srs)
 	commitments = append(commitments, bls12381.G1Affine{})
 	proofs = append(proofs, proof)
-	err = BatchVerifyMultiPoints(commitments, proofs, &srs.OpeningKey)
+	err = BatchVerifyMultiPoints(commitments, proofs, &srs.OpeningKey, nil, 0)
 	require.Error(t, err, "An invalid proof was added to the list, however verification returned true")
 }
 
+func TestBatchVerifyMultiPointsWithRandomness(t *testing.T) {
+	domain := NewDomain(4)
+	srs, _ := newLagrangeSRSInsecure(*domain, big.NewInt(1234))
+
+	numProofs := 3
+	commitments := make([]Commitment, 0, numProofs)
+	proofs := make([]OpeningProof, 0, numProofs)
+	for i := 0; i < numProofs; i++ {
+		proof, commitment := randValidOpeningProof(t, *domain, *srs)
+		commitments = append(commitments, commitment)
+		proofs = append(proofs, proof)
+	}
+
+	seed := bytes.Repeat([]byte{0x42}, 64)
+	err := BatchVerifyMultiPoints(commitments, proofs, &srs.OpeningKey, bytes.NewReader(seed), 0)
+	require.NoError(t, err)
+
+	// The randomness is read in full, so a short reader should return an error
+	err = BatchVerifyMultiPoints(commitments, proofs, &srs.OpeningKey, bytes.NewReader(seed[:63]), 0)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestBatchVerifyNumGoRoutines(t *testing.T) {
+	domain := NewDomain(4)
+	srs, _ := newLagrangeSRSInsecure(*domain, big.NewInt(1234))
+
+	commitments := make([]Commitment, 3)
+	proofs := make([]OpeningProof, 3)
+	for i := range proofs {
+		proofs[i], commitments[i] = randValidOpeningProof(t, *domain, *srs)
+	}
+	challenge := fr.NewElement(7)
+
+	// The folded points do not depend on the amount of concurrency
+	expected, _, err := FoldMultiPoints(commitments, proofs, &srs.OpeningKey, challenge, 1)
+	require.NoError(t, err)
+	for _, numGoRoutines := range []int{-1, 0, 2, 16} {
+		g1Points, _, err := FoldMultiPoints(commitments, proofs, &srs.OpeningKey, challenge, numGoRoutines)
+		require.NoError(t, err)
+		require.Equal(t, expected, g1Points)
+		require.NoError(t, BatchVerifyMultiPointsWithChallenge(commitments, proofs, &srs.OpeningKey, challenge, numGoRoutines))
+	}
+
+	_, _, err = FoldMultiPoints(commitments, proofs, &srs.OpeningKey, challenge, 1025)
+	require.Error(t, err)
+}
+
+func TestBatchVerifyPrecomputedLines(t *testing.T) {
+	domain := NewDomain(4)
+	srs, _ := newLagrangeSRSInsecure(*domain, big.NewInt(1234))
+	require.NotNil(t, srs.OpeningKey.lines)
+
+	// A key without precomputed lines computes them in the pairing check
+	withoutLines := OpeningKey{GenG1: srs.OpeningKey.GenG1, GenG2: srs.OpeningKey.GenG2, AlphaG2: srs.OpeningKey.AlphaG2}
+
+	commitments := make([]Commitment, 3)
+	proofs := make([]OpeningProof, 3)
+	for i := range proofs {
+		proofs[i], commitments[i] = randValidOpeningProof(t, *domain, *srs)
+	}
+	challenge := fr.NewElement(7)
+	for _, openKey := range []*OpeningKey{&srs.OpeningKey, &withoutLines} {
+		require.NoError(t, BatchVerifyMultiPointsWithChallenge(commitments, proofs, openKey, challenge, 0))
+
+		invalidProofs := append([]OpeningProof(nil), proofs...)
+		invalidProofs[1].ClaimedValue.SetOne()
+		err := BatchVerifyMultiPointsWithChallenge(commitments, invalidProofs, openKey, challenge, 0)
+		require.ErrorIs(t, err, ErrVerifyOpeningProof)
+
+		// The precomputed lines are not changed by a pairing check
+		require.NoError(t, BatchVerifyMultiPointsWithChallenge(commitments, proofs, openKey, challenge, 0))
+	}
+}
+
 func TestComputeQuotientPolySmoke(t *testing.T) {
 	numEvaluations := 128
 	domain := NewDomain(uint64(numEvaluations))
@@ -80,6 +156,17 @@ func TestComputeQuotientPolySmoke(t *testing.T) {
 		}
 	}
 
+	// The result is the same without the precomputed inverses
+	lowMemoryDomain := *domain
+	lowMemoryDomain.PreComputedInverses = nil
+	for i := 0; i < int(domain.Cardinality); i++ {
+		computedQuotientLagrange, err := lowMemoryDomain.computeQuotientPolyOnDomain(polyLagrange, uint64(i))
+		require.NoError(t, err)
+		expectedQuotientLagrange, err := domain.computeQuotientPolyOnDomain(polyLagrange, uint64(i))
+		require.NoError(t, err)
+		require.True(t, polyEqual(computedQuotientLagrange, expectedQuotientLagrange))
+	}
+
 	// Compute quotient polynomial for values not in the domain
 	numRandomEvaluations := 10
 
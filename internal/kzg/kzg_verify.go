@@ -1,11 +1,12 @@
 package kzg
 
 import (
+	"io"
 	"math/big"
 
-	"github.com/consensys/gnark-crypto/ecc"
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/multiexp"
 	"github.com/crate-crypto/go-kzg-4844/internal/utils"
 )
 
@@ -104,11 +105,16 @@ func Verify(commitment *Commitment, proof *OpeningProof, openKey *OpeningKey) er
 //   - This method is more efficient than calling [Verify] multiple times.
 //   - Randomness is used to combine multiple proofs into one.
 //
+// The randomness is read from randomness. If randomness is nil, it is read from crypto/rand.
+//
+// numGoRoutines is used to configure the amount of concurrency of the multi-exponentiations. Setting this
+// value to a negative number or 0 will make it default to the number of CPUs.
+//
 // Modified from [gnark-crypto].
 //
 // [verify_kzg_proof_batch]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#verify_kzg_proof_batch
 // [gnark-crypto]: https://github.com/ConsenSys/gnark-crypto/blob/8f7ca09273c24ed9465043566906cbecf5dcee91/ecc/bls12-381/fr/kzg/kzg.go#L367)
-func BatchVerifyMultiPoints(commitments []Commitment, proofs []OpeningProof, openKey *OpeningKey) error {
+func BatchVerifyMultiPoints(commitments []Commitment, proofs []OpeningProof, openKey *OpeningKey, randomness io.Reader, numGoRoutines int) error {
 	// Check consistency number of proofs is equal to the number of commitments.
 	if len(commitments) != len(proofs) {
 		return ErrInvalidNumDigests
@@ -133,23 +139,87 @@ func BatchVerifyMultiPoints(commitments []Commitment, proofs []OpeningProof, ope
 	// compute powers of that random number. This works
 	// since powers will produce a vandermonde matrix
 	// which is linearly independent.
-	var randomNumber fr.Element
-	_, err := randomNumber.SetRandom()
+	randomNumber, err := SampleRandomNumber(randomness)
 	if err != nil {
 		return err
 	}
+
+	return BatchVerifyMultiPointsWithChallenge(commitments, proofs, openKey, randomNumber, numGoRoutines)
+}
+
+// BatchVerifyMultiPointsWithChallenge is the same as [BatchVerifyMultiPoints], except that the proofs are combined
+// using the powers of the given challenge, instead of a randomly sampled one.
+//
+// The challenge must be unpredictable to whoever created the proofs, for example because it is derived from a
+// hash of all of the proofs, as is done in [verify_kzg_proof_batch].
+//
+// [verify_kzg_proof_batch]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#verify_kzg_proof_batch
+func BatchVerifyMultiPointsWithChallenge(commitments []Commitment, proofs []OpeningProof, openKey *OpeningKey, randomNumber fr.Element, numGoRoutines int) error {
+	// Check consistency number of proofs is equal to the number of commitments.
+	if len(commitments) != len(proofs) {
+		return ErrInvalidNumDigests
+	}
+	batchSize := len(commitments)
+
+	// If there is nothing to verify, we return nil
+	// to signal that verification was true.
+	//
+	if batchSize == 0 {
+		return nil
+	}
+
+	// If batch size is `1`, call Verify
+	if batchSize == 1 {
+		return Verify(&commitments[0], &proofs[0], openKey)
+	}
+
+	// The G2 points are always GenG2 and AlphaG2, whose lines are precomputed
+	g1Points, _, err := FoldMultiPoints(commitments, proofs, openKey, randomNumber, numGoRoutines)
+	if err != nil {
+		return err
+	}
+
+	check, err := openKey.pairingCheckFixedG2(g1Points)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+
+	return nil
+}
+
+// FoldMultiPoints combines multiple KZG proofs into a single pairing check, using the powers of the given challenge,
+// as done by [BatchVerifyMultiPointsWithChallenge]. It returns the points whose pairings are multiplied together: the
+// proofs are valid if e(g1Points[0], g2Points[0]) * e(g1Points[1], g2Points[1]) is the identity.
+//
+// Unlike [BatchVerifyMultiPointsWithChallenge], this does not special-case a batch with a single proof, so the pairs
+// have the same shape for every batch size. The points for an empty batch are all the point at infinity.
+//
+// numGoRoutines is used to configure the amount of concurrency of the multi-exponentiations, as in [Fold].
+func FoldMultiPoints(commitments []Commitment, proofs []OpeningProof, openKey *OpeningKey, randomNumber fr.Element, numGoRoutines int) ([2]bls12381.G1Affine, [2]bls12381.G2Affine, error) {
+	// Check consistency number of proofs is equal to the number of commitments.
+	if len(commitments) != len(proofs) {
+		return [2]bls12381.G1Affine{}, [2]bls12381.G2Affine{}, ErrInvalidNumDigests
+	}
+	batchSize := len(commitments)
+	g2Points := [2]bls12381.G2Affine{openKey.GenG2, openKey.AlphaG2}
+
+	if batchSize == 0 {
+		return [2]bls12381.G1Affine{}, g2Points, nil
+	}
+
 	randomNumbers := utils.ComputePowers(randomNumber, uint(batchSize))
 
 	// Combine random_i*quotient_i
-	var foldedQuotients bls12381.G1Affine
 	quotients := make([]bls12381.G1Affine, len(proofs))
 	for i := 0; i < batchSize; i++ {
 		quotients[i].Set(&proofs[i].QuotientCommitment)
 	}
-	config := ecc.MultiExpConfig{}
-	_, err = foldedQuotients.MultiExp(quotients, randomNumbers, config)
+	foldedQuotients, err := multiexp.MultiExp(randomNumbers, quotients, numGoRoutines)
 	if err != nil {
-		return err
+		return [2]bls12381.G1Affine{}, [2]bls12381.G2Affine{}, err
 	}
 
 	// Fold commitments and evaluations using randomness
@@ -157,9 +227,9 @@ func BatchVerifyMultiPoints(commitments []Commitment, proofs []OpeningProof, ope
 	for i := 0; i < len(randomNumbers); i++ {
 		evaluations[i].Set(&proofs[i].ClaimedValue)
 	}
-	foldedCommitments, foldedEvaluations, err := fold(commitments, evaluations, randomNumbers)
+	foldedCommitments, foldedEvaluations, err := Fold(commitments, evaluations, randomNumbers, numGoRoutines)
 	if err != nil {
-		return err
+		return [2]bls12381.G1Affine{}, [2]bls12381.G2Affine{}, err
 	}
 
 	// Compute commitment to folded Eval
@@ -172,46 +242,64 @@ func BatchVerifyMultiPoints(commitments []Commitment, proofs []OpeningProof, ope
 	foldedCommitments.Sub(&foldedCommitments, &foldedEvaluationsCommit)
 
 	// Combine random_i*(point_i*quotient_i)
-	var foldedPointsQuotients bls12381.G1Affine
 	for i := 0; i < batchSize; i++ {
 		randomNumbers[i].Mul(&randomNumbers[i], &proofs[i].InputPoint)
 	}
-	_, err = foldedPointsQuotients.MultiExp(quotients, randomNumbers, config)
+	foldedPointsQuotients, err := multiexp.MultiExp(randomNumbers, quotients, numGoRoutines)
 	if err != nil {
-		return err
+		return [2]bls12381.G1Affine{}, [2]bls12381.G2Affine{}, err
 	}
 
 	// `lhs` first pairing
-	foldedCommitments.Add(&foldedCommitments, &foldedPointsQuotients)
+	foldedCommitments.Add(&foldedCommitments, foldedPointsQuotients)
 
 	// `lhs` second pairing
-	foldedQuotients.Neg(&foldedQuotients)
+	foldedQuotients.Neg(foldedQuotients)
 
-	check, err := bls12381.PairingCheck(
-		[]bls12381.G1Affine{foldedCommitments, foldedQuotients},
-		[]bls12381.G2Affine{openKey.GenG2, openKey.AlphaG2},
-	)
-	if err != nil {
-		return err
-	}
-	if !check {
-		return ErrVerifyOpeningProof
+	return [2]bls12381.G1Affine{foldedCommitments, *foldedQuotients}, g2Points, nil
+}
+
+// SampleRandomNumber returns a random field element read from randomness, or from crypto/rand if randomness is nil.
+//
+// We read 64 bytes and reduce them modulo the field order, so that the bias of the result is negligible.
+func SampleRandomNumber(randomness io.Reader) (fr.Element, error) {
+	var randomNumber fr.Element
+	if randomness == nil {
+		_, err := randomNumber.SetRandom()
+		return randomNumber, err
 	}
 
-	return nil
+	var buf [64]byte
+	_, err := io.ReadFull(randomness, buf[:])
+	if err != nil {
+		return fr.Element{}, err
+	}
+	randomNumber.SetBytes(buf[:])
+	return randomNumber, nil
 }
 
-// fold computes two inner products with the same factors:
+// Fold computes two inner products with the same factors:
 //
 //   - Between commitments and factors; This is a multi-exponentiation.
 //   - Between evaluations and factors; This is a dot product.
 //
+// Returns [ErrInvalidNumDigests] if the three slices do not have the same length. The fold of empty slices is the
+// point at infinity and zero.
+//
+// numGoRoutines is used to configure the amount of concurrency of the multi-exponentiation. Setting this
+// value to a negative number or 0 will make it default to the number of CPUs.
+//
 // Modified slightly from [gnark-crypto].
 //
 // [gnark-crypto]: https://github.com/ConsenSys/gnark-crypto/blob/8f7ca09273c24ed9465043566906cbecf5dcee91/ecc/bls12-381/fr/kzg/kzg.go#L464
-func fold(commitments []Commitment, evaluations, factors []fr.Element) (Commitment, fr.Element, error) {
-	// Length inconsistency between commitments and evaluations should have been done before calling this function
+func Fold(commitments []Commitment, evaluations, factors []fr.Element, numGoRoutines int) (Commitment, fr.Element, error) {
 	batchSize := len(commitments)
+	if len(evaluations) != batchSize || len(factors) != batchSize {
+		return Commitment{}, fr.Element{}, ErrInvalidNumDigests
+	}
+	if batchSize == 0 {
+		return Commitment{}, fr.Element{}, nil
+	}
 
 	// Fold the claimed values
 	var foldedEvaluations, tmp fr.Element
@@ -221,11 +309,10 @@ func fold(commitments []Commitment, evaluations, factors []fr.Element) (Commitme
 	}
 
 	// Fold the commitments
-	var foldedCommitments Commitment
-	_, err := foldedCommitments.MultiExp(commitments, factors, ecc.MultiExpConfig{})
+	foldedCommitments, err := multiexp.MultiExp(factors, commitments, numGoRoutines)
 	if err != nil {
-		return foldedCommitments, foldedEvaluations, err
+		return Commitment{}, foldedEvaluations, err
 	}
 
-	return foldedCommitments, foldedEvaluations, nil
+	return *foldedCommitments, foldedEvaluations, nil
 }
@@ -3,6 +3,7 @@ package kzg
 import (
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/crate-crypto/go-kzg-4844/internal/multiexp"
+	"github.com/crate-crypto/go-kzg-4844/internal/utils"
 )
 
 // OpeningKey is the key used to verify opening proofs
@@ -16,6 +17,39 @@ type OpeningKey struct {
 	// This is the degree-1 G_2 element in the trusted setup.
 	// In the specs, this is denoted as `KZG_SETUP_G2[1]`
 	AlphaG2 bls12381.G2Affine
+
+	// lines holds the precomputed lines of the Miller loop for GenG2 and
+	// AlphaG2, in that order, which are the G2 points of every batch
+	// verification. It is nil for a key which was not created with
+	// [NewOpeningKey], in which case each pairing check computes them.
+	lines *[2][2][len(bls12381.LoopCounter) - 1]bls12381.LineEvaluationAff
+}
+
+// NewOpeningKey returns the opening key with the given points, with the lines of the Miller loop for both G2 points
+// precomputed so that batch verification does not compute them again for every batch.
+func NewOpeningKey(genG1 bls12381.G1Affine, genG2, alphaG2 bls12381.G2Affine) OpeningKey {
+	lines := [2][2][len(bls12381.LoopCounter) - 1]bls12381.LineEvaluationAff{
+		bls12381.PrecomputeLines(genG2),
+		bls12381.PrecomputeLines(alphaG2),
+	}
+	return OpeningKey{
+		GenG1:   genG1,
+		GenG2:   genG2,
+		AlphaG2: alphaG2,
+		lines:   &lines,
+	}
+}
+
+// pairingCheckFixedG2 returns whether e(g1Points[0], GenG2) * e(g1Points[1], AlphaG2) is the identity, using the
+// precomputed lines of the key if it has them.
+func (openKey *OpeningKey) pairingCheckFixedG2(g1Points [2]bls12381.G1Affine) (bool, error) {
+	if openKey.lines == nil {
+		return bls12381.PairingCheck(g1Points[:], []bls12381.G2Affine{openKey.GenG2, openKey.AlphaG2})
+	}
+	// The Miller loop scales the lines by the G1 points in place, so it is
+	// given a copy to keep the key intact and safe for concurrent use.
+	lines := *openKey.lines
+	return bls12381.PairingCheckFixedQ(g1Points[:], lines[:])
 }
 
 // CommitKey holds the data needed to commit to polynomials and by proxy make opening proofs
@@ -25,12 +59,41 @@ type CommitKey struct {
 	// we processed it with `ifftG1`. Once we compute `ifftG1`
 	// then this list is denoted as `KZG_SETUP_LAGRANGE` in the specs.
 	G1 []bls12381.G1Affine
+
+	// FixedBase optionally holds precomputed multiples of the G1 points.
+	// When it is not nil, it is used to speed up commitments.
+	//
+	// Note: The table depends on the order of the G1 points, so it must be
+	// created after the points have been permuted.
+	FixedBase *multiexp.FixedBaseTable
+
+	// Pool optionally holds dedicated workers for commitments where latency matters
+	// more than throughput. When it is not nil, commitments are split over its workers
+	// and the numGoRoutines argument to [Commit] is ignored.
+	Pool *multiexp.Pool
 }
 
 // ReversePoints applies the bit reversal permutation
 // to the G1 points stored inside the CommitKey c.
+//
+// Any precomputed table is discarded, since it no longer matches the order of the points.
 func (c *CommitKey) ReversePoints() {
-	bitReverse(c.G1)
+	utils.BitReverse(c.G1)
+	c.FixedBase = nil
+}
+
+// Precompute creates a table of multiples of the G1 points, using windows of windowBits bits,
+// which is then used to compute commitments.
+//
+// numGoRoutines is used to configure the amount of concurrency needed. Setting this
+// value to a negative number or 0 will make it default to the number of CPUs.
+func (c *CommitKey) Precompute(windowBits uint, numGoRoutines int) error {
+	table, err := multiexp.NewFixedBaseTable(c.G1, windowBits, numGoRoutines)
+	if err != nil {
+		return err
+	}
+	c.FixedBase = table
+	return nil
 }
 
 // SRS holds the structured reference string (SRS) for making
@@ -55,5 +118,16 @@ func Commit(p Polynomial, ck *CommitKey, numGoRoutines int) (*Commitment, error)
 		return nil, ErrInvalidPolynomialSize
 	}
 
+	if ck.Pool != nil {
+		if ck.FixedBase != nil {
+			return ck.Pool.MultiExpTable(ck.FixedBase, p)
+		}
+		return ck.Pool.MultiExp(p, ck.G1[:len(p)])
+	}
+
+	if ck.FixedBase != nil {
+		return ck.FixedBase.MultiExp(p, numGoRoutines)
+	}
+
 	return multiexp.MultiExp(p, ck.G1[:len(p)], numGoRoutines)
 }
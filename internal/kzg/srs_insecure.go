@@ -63,7 +63,6 @@ func newMonomialSRSInsecureUint64(size uint64, bAlpha *big.Int) (*SRS, error) {
 	}
 
 	var commitKey CommitKey
-	var openKey OpeningKey
 	commitKey.G1 = make([]bls12381.G1Affine, size)
 
 	var alpha fr.Element
@@ -71,9 +70,9 @@ func newMonomialSRSInsecureUint64(size uint64, bAlpha *big.Int) (*SRS, error) {
 
 	_, _, gen1Aff, gen2Aff := bls12381.Generators()
 	commitKey.G1[0] = gen1Aff
-	openKey.GenG1 = gen1Aff
-	openKey.GenG2 = gen2Aff
-	openKey.AlphaG2.ScalarMultiplication(&gen2Aff, bAlpha)
+	var alphaG2 bls12381.G2Affine
+	alphaG2.ScalarMultiplication(&gen2Aff, bAlpha)
+	openKey := NewOpeningKey(gen1Aff, gen2Aff, alphaG2)
 
 	alphas := make([]fr.Element, size-1)
 	alphas[0] = alpha
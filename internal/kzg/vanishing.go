@@ -0,0 +1,95 @@
+package kzg
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// fftMulThreshold is the number of coefficients below which two polynomials
+// are multiplied directly, instead of by evaluating them over a domain.
+const fftMulThreshold = 64
+
+// VanishingPolynomial returns the coefficients of the vanishing polynomial of the domain,
+// Z(X) = X^n - 1, where n is the size of the domain. The i'th element is the coefficient of X^i.
+func (domain *Domain) VanishingPolynomial() []fr.Element {
+	coeffs := make([]fr.Element, domain.Cardinality+1)
+	one := fr.One()
+	coeffs[0].Neg(&one)
+	coeffs[domain.Cardinality] = one
+	return coeffs
+}
+
+// VanishingPolynomialOfPoints returns the coefficients of the polynomial
+// Z(X) = (X - x_0)(X - x_1)...(X - x_(k-1)), which is zero at exactly the given points.
+// The i'th element is the coefficient of X^i.
+//
+// The linear factors are multiplied together in pairs, so that large products are
+// computed with FFTs in O(k log^2 k) time.
+func VanishingPolynomialOfPoints(points []fr.Element) []fr.Element {
+	if len(points) == 0 {
+		return []fr.Element{fr.One()}
+	}
+
+	polys := make([][]fr.Element, len(points))
+	for i := range points {
+		polys[i] = make([]fr.Element, 2)
+		polys[i][0].Neg(&points[i])
+		polys[i][1].SetOne()
+	}
+
+	for len(polys) > 1 {
+		next := make([][]fr.Element, 0, (len(polys)+1)/2)
+		for i := 0; i+1 < len(polys); i += 2 {
+			next = append(next, mulPolys(polys[i], polys[i+1]))
+		}
+		if len(polys)%2 == 1 {
+			next = append(next, polys[len(polys)-1])
+		}
+		polys = next
+	}
+	return polys[0]
+}
+
+// MulPolynomials returns the coefficients of the product of the polynomials with
+// coefficients a and b, where the i'th element is the coefficient of X^i. Large
+// polynomials are multiplied with FFTs. The product with an empty slice is empty.
+func MulPolynomials(a, b []fr.Element) []fr.Element {
+	if len(a) == 0 || len(b) == 0 {
+		return []fr.Element{}
+	}
+	return mulPolys(a, b)
+}
+
+// mulPolys returns the product of the polynomials with coefficients a and b.
+//
+// Large polynomials are multiplied by evaluating them over a domain which
+// is large enough to hold the product, which is taken from [CachedDomain].
+func mulPolys(a, b []fr.Element) []fr.Element {
+	n := len(a) + len(b) - 1
+	if len(a) < fftMulThreshold || len(b) < fftMulThreshold {
+		product := make([]fr.Element, n)
+		for i := range a {
+			for j := range b {
+				var term fr.Element
+				term.Mul(&a[i], &b[j])
+				product[i+j].Add(&product[i+j], &term)
+			}
+		}
+		return product
+	}
+
+	size := uint64(1) << bits.Len64(uint64(n-1))
+	domain := CachedDomain(size, 0)
+
+	paddedA := make([]fr.Element, size)
+	copy(paddedA, a)
+	paddedB := make([]fr.Element, size)
+	copy(paddedB, b)
+	evalsA := domain.FftFr(paddedA)
+	evalsB := domain.FftFr(paddedB)
+	for i := range evalsA {
+		evalsA[i].Mul(&evalsA[i], &evalsB[i])
+	}
+	return domain.IfftFr(evalsA)[:n]
+}
@@ -0,0 +1,33 @@
+package kzg
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVanishingPolynomialOfPoints(t *testing.T) {
+	// Enough points for the products to be computed with FFTs
+	points := make([]fr.Element, 3*fftMulThreshold+5)
+	for i := range points {
+		points[i].SetUint64(uint64(i*i + 3))
+	}
+
+	// Multiply the linear factors one at a time
+	expected := []fr.Element{fr.One()}
+	for i := range points {
+		var negated fr.Element
+		negated.Neg(&points[i])
+		expected = mulPolys(expected, []fr.Element{negated, fr.One()})
+	}
+
+	coeffs := VanishingPolynomialOfPoints(points)
+	require.Equal(t, expected, coeffs)
+	require.Equal(t, []fr.Element{fr.One()}, VanishingPolynomialOfPoints(nil))
+}
+
+func TestVanishingPolynomial(t *testing.T) {
+	domain := NewDomain(8)
+	require.Equal(t, VanishingPolynomialOfPoints(domain.Roots), domain.VanishingPolynomial())
+}
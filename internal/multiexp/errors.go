@@ -2,4 +2,12 @@ package multiexp
 
 import "errors"
 
-var ErrTooManyGoRoutines = errors.New("cannot configure more than 1024 go routines")
+var (
+	ErrTooManyGoRoutines = errors.New("cannot configure more than 1024 go routines")
+	ErrInvalidWindowBits = errors.New("window size for the fixed base table is out of range")
+	ErrTooManyScalars    = errors.New("number of scalars exceeds the number of points in the fixed base table")
+	ErrLengthMismatch    = errors.New("number of scalars does not match the number of points")
+
+	ErrInvalidFixedBaseTable  = errors.New("serialized fixed base table is malformed")
+	ErrFixedBaseTableMismatch = errors.New("fixed base table was not created from the expected points")
+)
@@ -0,0 +1,340 @@
+package multiexp
+
+import (
+	"sync"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/utils"
+)
+
+// MinWindowBits is the smallest window size that can be used for a [FixedBaseTable].
+const MinWindowBits = 2
+
+// MaxWindowBits is the largest window size that can be used for a [FixedBaseTable].
+const MaxWindowBits = 16
+
+// FixedBaseTable holds precomputed multiples of a fixed list of points.
+//
+// When we repeatedly compute a multi exponentiation against the same points, as is
+// the case when committing to a polynomial, we can precompute 2^(c*j) * P_i for every
+// point P_i and every window j. A scalar is then split into signed c-bit digits and
+// the multi exponentiation becomes a single bucket accumulation over all (point, window)
+// pairs, instead of one bucket accumulation per window followed by doublings.
+//
+// The table uses len(points) * (255/c + 1) affine points, where c is the window size in bits. Each affine point
+// takes 96 bytes, so for 4096 points and c = 12 the table is about 8.3MB.
+type FixedBaseTable struct {
+	// windowBits is the size of each window in bits.
+	windowBits uint
+	// numWindows is the number of windows needed to cover a scalar
+	// and a possible carry from its most significant window.
+	numWindows uint
+	// numBases is the number of points that the table was created from.
+	numBases int
+	// points[i*numWindows + j] = 2^(windowBits * j) * bases[i]
+	points []bls12381.G1Affine
+}
+
+// NewFixedBaseTable precomputes the multiples of bases needed to compute a multi exponentiation
+// using windows of windowBits bits.
+//
+// numGoRoutines is used to configure the amount of concurrency needed. Setting this
+// value to a negative number or 0 will make it default to the number of CPUs.
+//
+// Returns an error if windowBits is not in the range [MinWindowBits, MaxWindowBits].
+func NewFixedBaseTable(bases []bls12381.G1Affine, windowBits uint, numGoRoutines int) (*FixedBaseTable, error) {
+	if windowBits < MinWindowBits || windowBits > MaxWindowBits {
+		return nil, ErrInvalidWindowBits
+	}
+
+	numWindows := numWindowsFor(windowBits)
+
+	table := &FixedBaseTable{
+		windowBits: windowBits,
+		numWindows: numWindows,
+		numBases:   len(bases),
+		points:     make([]bls12381.G1Affine, len(bases)*int(numWindows)),
+	}
+
+	utils.Parallelize(len(bases), numGoRoutines, func(start, end int) {
+		multiples := make([]bls12381.G1Jac, numWindows)
+		for i := start; i < end; i++ {
+			multiples[0].FromAffine(&bases[i])
+			for j := uint(1); j < numWindows; j++ {
+				multiples[j].Set(&multiples[j-1])
+				for k := uint(0); k < windowBits; k++ {
+					multiples[j].DoubleAssign()
+				}
+			}
+			affine := bls12381.BatchJacobianToAffineG1(multiples)
+			copy(table.points[i*int(numWindows):], affine)
+		}
+	})
+
+	return table, nil
+}
+
+// numWindowsFor returns the number of windows of windowBits bits needed to cover a scalar.
+func numWindowsFor(windowBits uint) uint {
+	// Scalars are at most fr.Bits bits long. Since we use signed digits,
+	// the most significant window can carry into an extra window.
+	return uint(fr.Bits)/windowBits + 1
+}
+
+// NumBases returns the number of points that the table was created from.
+func (t *FixedBaseTable) NumBases() int {
+	return t.numBases
+}
+
+// WindowBits returns the size of the windows used by the table, in bits.
+func (t *FixedBaseTable) WindowBits() uint {
+	return t.windowBits
+}
+
+// MultiExp computes scalars[0]*bases[0] + ... + scalars[n-1]*bases[n-1] where bases are the points that the table was
+// created from.
+//
+// The number of scalars can be less than the number of bases, in which case only the first len(scalars) bases are
+// used. If there are more scalars than bases, this function returns an error.
+//
+// numGoRoutines is used to configure the amount of concurrency needed. Setting this
+// value to a negative number or 0 will make it default to the number of CPUs.
+//
+// Returns an error if the numGoRoutines exceeds 1024.
+func (t *FixedBaseTable) MultiExp(scalars []fr.Element, numGoRoutines int) (*bls12381.G1Affine, error) {
+	err := isValidNumGoRoutines(numGoRoutines)
+	if err != nil {
+		return nil, err
+	}
+	return t.multiExp(scalars, func(n int, work func(start, end int)) {
+		utils.Parallelize(n, numGoRoutines, work)
+	})
+}
+
+// multiExp computes the multi exponentiation, using split to run the chunks of the scalars.
+func (t *FixedBaseTable) multiExp(scalars []fr.Element, split func(n int, work func(start, end int))) (*bls12381.G1Affine, error) {
+	if len(scalars) > t.numBases {
+		return nil, ErrTooManyScalars
+	}
+
+	var (
+		mu     sync.Mutex
+		result bls12381.G1Jac
+	)
+	split(len(scalars), func(start, end int) {
+		partial := t.multiExpRange(scalars, start, end)
+
+		mu.Lock()
+		result.AddAssign(&partial)
+		mu.Unlock()
+	})
+
+	var resultAff bls12381.G1Affine
+	resultAff.FromJacobian(&result)
+	return &resultAff, nil
+}
+
+// multiExpRange computes the multi exponentiation for the scalars in the range [start, end).
+func (t *FixedBaseTable) multiExpRange(scalars []fr.Element, start, end int) bls12381.G1Jac {
+	// Since the digits are signed, we only need buckets for the absolute values 1..2^(c-1)
+	numBuckets := 1 << (t.windowBits - 1)
+	acc := newBucketAccumulator(numBuckets)
+
+	digits := make([]int, t.numWindows)
+	var neg bls12381.G1Affine
+	for i := start; i < end; i++ {
+		t.signedDigits(&scalars[i], digits)
+
+		points := t.points[i*int(t.numWindows) : (i+1)*int(t.numWindows)]
+		for j, digit := range digits {
+			switch {
+			case digit > 0:
+				acc.add(digit-1, &points[j])
+			case digit < 0:
+				neg.Neg(&points[j])
+				acc.add(-digit-1, &neg)
+			}
+		}
+	}
+
+	return acc.reduce()
+}
+
+// signedDigits writes the signed base 2^c digits of the scalar into digits, where c is the window size of the table.
+//
+// Each digit is in the range [-2^(c-1), 2^(c-1)].
+func (t *FixedBaseTable) signedDigits(scalar *fr.Element, digits []int) {
+	// Bits returns the regular (non-montgomery) form of the scalar in little-endian limbs
+	limbs := scalar.Bits()
+
+	c := t.windowBits
+	mask := uint64(1)<<c - 1
+	half := 1 << (c - 1)
+	carry := 0
+	for j := uint(0); j < t.numWindows; j++ {
+		bitOffset := j * c
+		limbIndex := bitOffset / 64
+		bitInLimb := bitOffset % 64
+
+		var window uint64
+		if limbIndex < uint(len(limbs)) {
+			window = limbs[limbIndex] >> bitInLimb
+			// The window may straddle two limbs
+			if bitInLimb+c > 64 && limbIndex+1 < uint(len(limbs)) {
+				window |= limbs[limbIndex+1] << (64 - bitInLimb)
+			}
+		}
+
+		digit := int(window&mask) + carry
+		carry = 0
+		if digit > half {
+			digit -= 1 << c
+			carry = 1
+		}
+		digits[j] = digit
+	}
+}
+
+// bucketAccumulator accumulates points into buckets using batched affine additions.
+//
+// An affine addition needs a field inversion, which is expensive on its own. By collecting
+// additions into distinct buckets and inverting all of the denominators at once with
+// Montgomery's trick, an affine addition becomes cheaper than a mixed Jacobian addition.
+//
+// Additions which cannot be batched, because the bucket is already part of the current batch
+// or because the addition is a doubling, are added into Jacobian overflow buckets instead.
+type bucketAccumulator struct {
+	bucketsAff []bls12381.G1Affine
+	bucketsJac []bls12381.G1Jac
+
+	// inBatch[k] is true if bucket k has a pending addition in the current batch
+	inBatch []bool
+	// The pending additions are bucketsAff[batchBuckets[i]] += batchPoints[i]
+	batchBuckets []int
+	batchPoints  []bls12381.G1Affine
+
+	// scratch space for the batch inversion
+	denominators []fp.Element
+	prefixes     []fp.Element
+}
+
+// newBucketAccumulator returns an accumulator with numBuckets buckets, all set to the identity.
+func newBucketAccumulator(numBuckets int) *bucketAccumulator {
+	batchSize := numBuckets / 8
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	return &bucketAccumulator{
+		bucketsAff:   make([]bls12381.G1Affine, numBuckets),
+		bucketsJac:   make([]bls12381.G1Jac, numBuckets),
+		inBatch:      make([]bool, numBuckets),
+		batchBuckets: make([]int, 0, batchSize),
+		batchPoints:  make([]bls12381.G1Affine, 0, batchSize),
+		denominators: make([]fp.Element, batchSize),
+		prefixes:     make([]fp.Element, batchSize),
+	}
+}
+
+// add adds the point to the bucket with the given index.
+func (acc *bucketAccumulator) add(bucket int, point *bls12381.G1Affine) {
+	bucketAff := &acc.bucketsAff[bucket]
+
+	// Note: The affine identity is encoded as (0,0).
+	// A bucket in the current batch is never the identity.
+	if bucketAff.IsInfinity() {
+		bucketAff.Set(point)
+		return
+	}
+
+	// Equal x-coordinates means that we either need to double or the result is the identity,
+	// neither of which are handled by the affine addition formula in flush.
+	if acc.inBatch[bucket] || bucketAff.X.Equal(&point.X) {
+		acc.bucketsJac[bucket].AddMixed(point)
+		return
+	}
+
+	acc.inBatch[bucket] = true
+	acc.batchBuckets = append(acc.batchBuckets, bucket)
+	acc.batchPoints = append(acc.batchPoints, *point)
+	if len(acc.batchBuckets) == cap(acc.batchBuckets) {
+		acc.flush()
+	}
+}
+
+// flush computes all of the pending affine additions, using a single field inversion.
+func (acc *bucketAccumulator) flush() {
+	n := len(acc.batchBuckets)
+	if n == 0 {
+		return
+	}
+
+	// Compute the denominators x_2 - x_1 and their prefix products
+	for k := 0; k < n; k++ {
+		bucketAff := &acc.bucketsAff[acc.batchBuckets[k]]
+		acc.denominators[k].Sub(&acc.batchPoints[k].X, &bucketAff.X)
+		if k == 0 {
+			acc.prefixes[0].Set(&acc.denominators[0])
+		} else {
+			acc.prefixes[k].Mul(&acc.prefixes[k-1], &acc.denominators[k])
+		}
+	}
+
+	// Invert the product of all denominators and walk back to find
+	// the inverse of each one of them.
+	var inv fp.Element
+	inv.Inverse(&acc.prefixes[n-1])
+	for k := n - 1; k > 0; k-- {
+		var denominatorInv fp.Element
+		denominatorInv.Mul(&inv, &acc.prefixes[k-1])
+		inv.Mul(&inv, &acc.denominators[k])
+		acc.denominators[k].Set(&denominatorInv)
+	}
+	acc.denominators[0].Set(&inv)
+
+	// Affine addition: lambda = (y_2 - y_1) / (x_2 - x_1)
+	// x_3 = lambda^2 - x_1 - x_2
+	// y_3 = lambda * (x_1 - x_3) - y_1
+	for k := 0; k < n; k++ {
+		bucket := acc.batchBuckets[k]
+		bucketAff := &acc.bucketsAff[bucket]
+		point := &acc.batchPoints[k]
+
+		var lambda, x3, y3 fp.Element
+		lambda.Sub(&point.Y, &bucketAff.Y)
+		lambda.Mul(&lambda, &acc.denominators[k])
+
+		x3.Square(&lambda)
+		x3.Sub(&x3, &bucketAff.X)
+		x3.Sub(&x3, &point.X)
+
+		y3.Sub(&bucketAff.X, &x3)
+		y3.Mul(&y3, &lambda)
+		y3.Sub(&y3, &bucketAff.Y)
+
+		bucketAff.X.Set(&x3)
+		bucketAff.Y.Set(&y3)
+
+		acc.inBatch[bucket] = false
+	}
+
+	acc.batchBuckets = acc.batchBuckets[:0]
+	acc.batchPoints = acc.batchPoints[:0]
+}
+
+// reduce returns sum_k (k+1) * bucket_k, where bucket_k is the sum of all points added to the k'th bucket.
+func (acc *bucketAccumulator) reduce() bls12381.G1Jac {
+	acc.flush()
+
+	// Compute the sum using a running sum, starting from the largest bucket
+	var runningSum, total bls12381.G1Jac
+	for k := len(acc.bucketsAff) - 1; k >= 0; k-- {
+		acc.bucketsJac[k].AddMixed(&acc.bucketsAff[k])
+		runningSum.AddAssign(&acc.bucketsJac[k])
+		total.AddAssign(&runningSum)
+	}
+
+	return total
+}
@@ -0,0 +1,171 @@
+package multiexp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"unsafe"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// fixedBaseTableMagic identifies a serialized [FixedBaseTable] and the version of its format.
+var fixedBaseTableMagic = [8]byte{'G', 'K', 'Z', 'G', 'F', 'B', '0', '1'}
+
+// fixedBaseTableHeader is written before the points of a serialized [FixedBaseTable].
+type fixedBaseTableHeader struct {
+	Magic      [8]byte
+	WindowBits uint32
+	NumBases   uint32
+}
+
+// WriteTo writes the table to w.
+//
+// The points are written in the internal (Montgomery, little-endian) representation used by gnark-crypto, so that
+// they can be loaded without any conversion. The format is therefore only meant to be read back by this library.
+func (t *FixedBaseTable) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	header := fixedBaseTableHeader{
+		Magic:      fixedBaseTableMagic,
+		WindowBits: uint32(t.windowBits),
+		NumBases:   uint32(t.numBases),
+	}
+	if err := binary.Write(bw, binary.LittleEndian, &header); err != nil {
+		return 0, err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, t.points); err != nil {
+		return 0, err
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, err
+	}
+
+	return int64(binary.Size(header) + binary.Size(t.points)), nil
+}
+
+// ReadFixedBaseTable reads a table which was written using [FixedBaseTable.WriteTo].
+//
+// bases are the points that the table is expected to have been created from. Returns an error if the table was
+// created from different points, or if any of the points in the table is not on the curve.
+//
+// Note: The points are not checked to be in the correct subgroup, nor to be the correct multiples of the bases,
+// since this would be as expensive as computing the table. The input should come from a trusted source, such as
+// a file previously written by the same process.
+func ReadFixedBaseTable(r io.Reader, bases []bls12381.G1Affine) (*FixedBaseTable, error) {
+	br := bufio.NewReader(r)
+
+	var header fixedBaseTableHeader
+	if err := binary.Read(br, binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+	if header.Magic != fixedBaseTableMagic {
+		return nil, ErrInvalidFixedBaseTable
+	}
+	windowBits := uint(header.WindowBits)
+	if windowBits < MinWindowBits || windowBits > MaxWindowBits {
+		return nil, ErrInvalidWindowBits
+	}
+	if int(header.NumBases) != len(bases) {
+		return nil, ErrFixedBaseTableMismatch
+	}
+
+	numWindows := numWindowsFor(windowBits)
+	table := &FixedBaseTable{
+		windowBits: windowBits,
+		numWindows: numWindows,
+		numBases:   len(bases),
+		points:     make([]bls12381.G1Affine, len(bases)*int(numWindows)),
+	}
+	if err := binary.Read(br, binary.LittleEndian, table.points); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(table.points); i++ {
+		if !table.points[i].IsOnCurve() {
+			return nil, ErrInvalidFixedBaseTable
+		}
+	}
+	// The first window of each base is the base itself
+	for i := 0; i < len(bases); i++ {
+		if !table.points[i*int(numWindows)].Equal(&bases[i]) {
+			return nil, ErrFixedBaseTableMismatch
+		}
+	}
+
+	return table, nil
+}
+
+// FixedBaseTableFromBytes is the same as [ReadFixedBaseTable], except that the serialized table is given as a byte
+// slice, such as a memory-mapped file, and the points of the returned table use the memory of data instead of a copy
+// of it. data must not be modified or released while the table is in use.
+//
+// The points can only share the memory of data on a little-endian machine, and if they are aligned to 8 bytes in
+// memory; otherwise they are copied. The returned boolean reports whether the table uses the memory of data.
+//
+// Unlike [ReadFixedBaseTable], only the first window of the first, second and last bases are checked, so that the
+// points are not all read, and so paged in, when the table is loaded. The input must therefore come from a trusted
+// source.
+func FixedBaseTableFromBytes(data []byte, bases []bls12381.G1Affine) (*FixedBaseTable, bool, error) {
+	var header fixedBaseTableHeader
+	headerSize := binary.Size(header)
+	if len(data) < headerSize {
+		return nil, false, ErrInvalidFixedBaseTable
+	}
+	if err := binary.Read(bytes.NewReader(data[:headerSize]), binary.LittleEndian, &header); err != nil {
+		return nil, false, err
+	}
+	if header.Magic != fixedBaseTableMagic {
+		return nil, false, ErrInvalidFixedBaseTable
+	}
+	windowBits := uint(header.WindowBits)
+	if windowBits < MinWindowBits || windowBits > MaxWindowBits {
+		return nil, false, ErrInvalidWindowBits
+	}
+	if int(header.NumBases) != len(bases) || len(bases) == 0 {
+		return nil, false, ErrFixedBaseTableMismatch
+	}
+
+	numWindows := numWindowsFor(windowBits)
+	numPoints := len(bases) * int(numWindows)
+	pointData := data[headerSize:]
+	if len(pointData) != numPoints*g1AffineSize {
+		return nil, false, ErrInvalidFixedBaseTable
+	}
+
+	table := &FixedBaseTable{
+		windowBits: windowBits,
+		numWindows: numWindows,
+		numBases:   len(bases),
+	}
+	aliased := IsLittleEndian() && uintptr(unsafe.Pointer(&pointData[0]))%8 == 0
+	if aliased {
+		table.points = unsafe.Slice((*bls12381.G1Affine)(unsafe.Pointer(&pointData[0])), numPoints)
+	} else {
+		table.points = make([]bls12381.G1Affine, numPoints)
+		if err := binary.Read(bytes.NewReader(pointData), binary.LittleEndian, table.points); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// The first window of each base is the base itself. The second base is included, since permutations of the bases
+	// such as the bit reversal keep the first and last bases in place.
+	for _, i := range []int{0, 1 % len(bases), len(bases) - 1} {
+		point := &table.points[i*int(numWindows)]
+		if !point.IsOnCurve() || !point.Equal(&bases[i]) {
+			return nil, false, ErrFixedBaseTableMismatch
+		}
+	}
+
+	return table, aliased, nil
+}
+
+// g1AffineSize is the size of a [bls12381.G1Affine] in memory, which is also its size in a serialized table.
+const g1AffineSize = int(unsafe.Sizeof(bls12381.G1Affine{}))
+
+// IsLittleEndian reports whether the machine stores integers in little-endian byte order, as the serialized table
+// does.
+func IsLittleEndian() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}
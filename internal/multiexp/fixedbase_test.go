@@ -0,0 +1,158 @@
+package multiexp
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixedBaseMultiExpSmoke(t *testing.T) {
+	instanceSize := 64
+	points := genG1Points(uint(instanceSize))
+
+	scalars := make([]fr.Element, instanceSize)
+	for i := range scalars {
+		_, err := scalars[i].SetRandom()
+		require.NoError(t, err)
+	}
+	// Include the extremes, to check the carry of the signed digits
+	scalars[0].SetZero()
+	scalars[1].SetOne()
+	scalars[2].SetOne()
+	scalars[2].Neg(&scalars[2])
+
+	expected, err := slowMultiExp(scalars, points)
+	require.NoError(t, err)
+
+	for windowBits := uint(MinWindowBits); windowBits <= MaxWindowBits; windowBits++ {
+		table, err := NewFixedBaseTable(points, windowBits, 0)
+		require.NoError(t, err)
+
+		for _, numGoRoutines := range []int{0, 1, 3} {
+			got, err := table.MultiExp(scalars, numGoRoutines)
+			require.NoError(t, err)
+			require.True(t, got.Equal(expected), "inconsistent result for a window size of %d", windowBits)
+		}
+
+		// Using fewer scalars than points only uses a prefix of the points
+		got, err := table.MultiExp(scalars[:10], 0)
+		require.NoError(t, err)
+		expectedPrefix, err := slowMultiExp(scalars[:10], points[:10])
+		require.NoError(t, err)
+		require.True(t, got.Equal(expectedPrefix))
+	}
+}
+
+func TestFixedBaseInvalidInputs(t *testing.T) {
+	points := genG1Points(4)
+
+	_, err := NewFixedBaseTable(points, MinWindowBits-1, 0)
+	require.ErrorIs(t, err, ErrInvalidWindowBits)
+	_, err = NewFixedBaseTable(points, MaxWindowBits+1, 0)
+	require.ErrorIs(t, err, ErrInvalidWindowBits)
+
+	table, err := NewFixedBaseTable(points, 8, 0)
+	require.NoError(t, err)
+	_, err = table.MultiExp(make([]fr.Element, 5), 0)
+	require.ErrorIs(t, err, ErrTooManyScalars)
+	_, err = table.MultiExp(make([]fr.Element, 4), 1024)
+	require.ErrorIs(t, err, ErrTooManyGoRoutines)
+}
+
+func TestFixedBaseTableRoundTrip(t *testing.T) {
+	points := genG1Points(8)
+	table, err := NewFixedBaseTable(points, 5, 0)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := table.WriteTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n)
+	serialized := buf.Bytes()
+
+	gotTable, err := ReadFixedBaseTable(bytes.NewReader(serialized), points)
+	require.NoError(t, err)
+	require.Equal(t, table, gotTable)
+
+	_, err = ReadFixedBaseTable(bytes.NewReader(serialized), points[:7])
+	require.ErrorIs(t, err, ErrFixedBaseTableMismatch)
+
+	otherPoints := genG1Points(8)
+	otherPoints[0], otherPoints[1] = otherPoints[1], otherPoints[0]
+	_, err = ReadFixedBaseTable(bytes.NewReader(serialized), otherPoints)
+	require.ErrorIs(t, err, ErrFixedBaseTableMismatch)
+
+	corrupted := append([]byte{}, serialized...)
+	corrupted[0] ^= 1
+	_, err = ReadFixedBaseTable(bytes.NewReader(corrupted), points)
+	require.ErrorIs(t, err, ErrInvalidFixedBaseTable)
+
+	corrupted = append([]byte{}, serialized...)
+	corrupted[len(corrupted)-1] ^= 1
+	_, err = ReadFixedBaseTable(bytes.NewReader(corrupted), points)
+	require.ErrorIs(t, err, ErrInvalidFixedBaseTable)
+}
+
+func TestFixedBaseTableFromBytes(t *testing.T) {
+	points := genG1Points(8)
+	table, err := NewFixedBaseTable(points, MinWindowBits, 0)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = table.WriteTo(&buf)
+	require.NoError(t, err)
+	serialized := buf.Bytes()
+
+	// A buffer which is offset by one byte cannot be aliased, so the points are copied
+	misaligned := make([]byte, len(serialized)+1)[1:]
+	copy(misaligned, serialized)
+	gotTable, _, err := FixedBaseTableFromBytes(serialized, points)
+	require.NoError(t, err)
+	require.Equal(t, table, gotTable)
+	gotTable, aliased, err := FixedBaseTableFromBytes(misaligned, points)
+	require.NoError(t, err)
+	require.False(t, aliased)
+	require.Equal(t, table, gotTable)
+
+	_, _, err = FixedBaseTableFromBytes(serialized, points[:7])
+	require.ErrorIs(t, err, ErrFixedBaseTableMismatch)
+	_, _, err = FixedBaseTableFromBytes(serialized[:len(serialized)-1], points)
+	require.ErrorIs(t, err, ErrInvalidFixedBaseTable)
+	_, _, err = FixedBaseTableFromBytes(serialized[:4], points)
+	require.ErrorIs(t, err, ErrInvalidFixedBaseTable)
+
+	corrupted := append([]byte{}, serialized...)
+	corrupted[0] ^= 1
+	_, _, err = FixedBaseTableFromBytes(corrupted, points)
+	require.ErrorIs(t, err, ErrInvalidFixedBaseTable)
+}
+
+func BenchmarkMultiExp(b *testing.B) {
+	const instanceSize = 4096
+	points := genG1Points(instanceSize)
+	scalars := make([]fr.Element, instanceSize)
+	for i := range scalars {
+		_, _ = scalars[i].SetRandom()
+	}
+
+	b.Run("pippenger", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_, _ = MultiExp(scalars, points, 0)
+		}
+	})
+
+	for _, windowBits := range []uint{8, 10, 12} {
+		table, err := NewFixedBaseTable(points, windowBits, 0)
+		require.NoError(b, err)
+		b.Run(fmt.Sprintf("fixed base/window=%d", windowBits), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				_, _ = table.MultiExp(scalars, 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package multiexp
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// Pool is a fixed set of workers which are reserved for latency-sensitive multi exponentiations.
+//
+// Each worker is a go-routine which is locked to its own OS thread, so a multi exponentiation on the pool does not
+// wait for the go scheduler to find a thread for it. A multi exponentiation is split into one chunk per worker.
+//
+// Note: The workers are pinned to OS threads, not to CPUs; which CPU a thread runs on is left to the OS.
+type Pool struct {
+	tasks chan func()
+	// done is closed to stop the workers. The tasks channel itself is never closed, so that a call which is
+	// still running when the pool is closed falls back to new go-routines instead of panicking.
+	done      chan struct{}
+	size      int
+	closeOnce sync.Once
+}
+
+// NewPool starts a pool with the given number of workers. Setting size to a negative number or 0 will make it
+// default to the number of CPUs.
+//
+// Returns an error if size exceeds 1024.
+func NewPool(size int) (*Pool, error) {
+	if err := isValidNumGoRoutines(size); err != nil {
+		return nil, err
+	}
+	if size <= 0 {
+		size = runtime.NumCPU()
+	}
+
+	p := &Pool{
+		tasks: make(chan func()),
+		done:  make(chan struct{}),
+		size:  size,
+	}
+	for i := 0; i < size; i++ {
+		go p.worker()
+	}
+	return p, nil
+}
+
+// worker runs tasks from the pool on the current OS thread until the pool is closed.
+func (p *Pool) worker() {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	for {
+		select {
+		case task := <-p.tasks:
+			task()
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// Size returns the number of workers in the pool.
+func (p *Pool) Size() int {
+	return p.size
+}
+
+// Close stops the workers of the pool. The pool can still be used afterwards, but each chunk of work then runs on a
+// new go-routine.
+func (p *Pool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+}
+
+// MultiExp computes scalars[0]*points[0] + ... + scalars[n-1]*points[n-1] using the workers of the pool.
+//
+// If the slices differ in length, this function returns an error.
+func (p *Pool) MultiExp(scalars []fr.Element, points []bls12381.G1Affine) (*bls12381.G1Affine, error) {
+	if len(scalars) != len(points) {
+		return nil, ErrLengthMismatch
+	}
+
+	var (
+		mu       sync.Mutex
+		result   bls12381.G1Jac
+		firstErr error
+	)
+	p.run(len(scalars), func(start, end int) {
+		var partial bls12381.G1Jac
+		_, err := partial.MultiExp(points[start:end], scalars[start:end], ecc.MultiExpConfig{NbTasks: 1})
+
+		mu.Lock()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		result.AddAssign(&partial)
+		mu.Unlock()
+	})
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	var resultAff bls12381.G1Affine
+	resultAff.FromJacobian(&result)
+	return &resultAff, nil
+}
+
+// MultiExpTable is the same as [FixedBaseTable.MultiExp], except that the work is done by the workers of the pool.
+func (p *Pool) MultiExpTable(t *FixedBaseTable, scalars []fr.Element) (*bls12381.G1Affine, error) {
+	return t.multiExp(scalars, p.run)
+}
+
+// run splits the range [0, n) into one contiguous chunk per worker, and waits for work to be called on each chunk.
+//
+// A chunk is handed to an idle worker if there is one. Otherwise it is run on a new go-routine, so that a second
+// caller never queues behind the first.
+func (p *Pool) run(n int, work func(start, end int)) {
+	numChunks := p.size
+	if numChunks > n {
+		numChunks = n
+	}
+	if numChunks <= 1 {
+		work(0, n)
+		return
+	}
+
+	chunkSize := (n + numChunks - 1) / numChunks
+
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += chunkSize {
+		start := start
+		end := start + chunkSize
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		task := func() {
+			defer wg.Done()
+			work(start, end)
+		}
+		select {
+		case p.tasks <- task:
+		default:
+			go task()
+		}
+	}
+	wg.Wait()
+}
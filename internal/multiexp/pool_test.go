@@ -0,0 +1,100 @@
+package multiexp
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolMultiExp(t *testing.T) {
+	instanceSize := 64
+	points := genG1Points(uint(instanceSize))
+
+	scalars := make([]fr.Element, instanceSize)
+	for i := range scalars {
+		_, err := scalars[i].SetRandom()
+		require.NoError(t, err)
+	}
+	expected, err := slowMultiExp(scalars, points)
+	require.NoError(t, err)
+
+	table, err := NewFixedBaseTable(points, MinWindowBits, 0)
+	require.NoError(t, err)
+
+	for _, size := range []int{0, 1, 3, 100} {
+		pool, err := NewPool(size)
+		require.NoError(t, err)
+
+		got, err := pool.MultiExp(scalars, points)
+		require.NoError(t, err)
+		require.True(t, got.Equal(expected), "inconsistent result for a pool of size %d", size)
+
+		got, err = pool.MultiExpTable(table, scalars)
+		require.NoError(t, err)
+		require.True(t, got.Equal(expected), "inconsistent result for a pool of size %d", size)
+
+		pool.Close()
+	}
+}
+
+func TestPoolConcurrentCallers(t *testing.T) {
+	instanceSize := 32
+	points := genG1Points(uint(instanceSize))
+	scalars := make([]fr.Element, instanceSize)
+	for i := range scalars {
+		scalars[i].SetUint64(uint64(i + 1))
+	}
+	expected, err := slowMultiExp(scalars, points)
+	require.NoError(t, err)
+
+	pool, err := NewPool(2)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	// More callers than workers must not deadlock
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := pool.MultiExp(scalars, points)
+			require.NoError(t, err)
+			require.True(t, got.Equal(expected))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPoolInvalidInputs(t *testing.T) {
+	_, err := NewPool(1024)
+	require.ErrorIs(t, err, ErrTooManyGoRoutines)
+
+	pool, err := NewPool(1)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	points := genG1Points(4)
+	_, err = pool.MultiExp(make([]fr.Element, 3), points)
+	require.ErrorIs(t, err, ErrLengthMismatch)
+}
+
+func TestPoolUseAfterClose(t *testing.T) {
+	points := genG1Points(8)
+	scalars := make([]fr.Element, len(points))
+	for i := range scalars {
+		scalars[i].SetUint64(uint64(i + 1))
+	}
+	expected, err := slowMultiExp(scalars, points)
+	require.NoError(t, err)
+
+	pool, err := NewPool(4)
+	require.NoError(t, err)
+	pool.Close()
+	pool.Close()
+
+	got, err := pool.MultiExp(scalars, points)
+	require.NoError(t, err)
+	require.True(t, got.Equal(expected))
+}
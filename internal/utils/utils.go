@@ -1,9 +1,27 @@
 package utils
 
 import (
+	"math/bits"
+	"runtime"
+	"sync"
+
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 )
 
+// Integer is the set of integer types.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// MultiplicativeElement is the set of pointers to elements of a group that is written multiplicatively, such as
+// *fr.Element, *fp.Element or *bls12381.GT. The methods are the ones that gnark-crypto generates for these types.
+type MultiplicativeElement[T any] interface {
+	*T
+	SetOne() *T
+	Mul(x, y *T) *T
+}
+
 // The spec includes a method to compute the modular inverse.
 // This method is named .Inverse on `fr.Element`
 // When the element to invert is zero, this method will return zero
@@ -18,16 +36,19 @@ import (
 // More precisely, given x and n, returns a slice containing [x^0, ..., x^n-1]
 // In particular, for n==0, an empty slice is returned
 //
+// It works for any type whose pointer implements [MultiplicativeElement], so the type
+// parameters can be inferred from x, for example ComputePowers(x, n) for an fr.Element.
+//
 // [compute_powers]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#compute_powers
-func ComputePowers(x fr.Element, n uint) []fr.Element {
+func ComputePowers[T any, PT MultiplicativeElement[T]](x T, n uint) []T {
 	if n == 0 {
-		return []fr.Element{}
+		return []T{}
 	}
 
-	powers := make([]fr.Element, n)
-	powers[0].SetOne()
+	powers := make([]T, n)
+	PT(&powers[0]).SetOne()
 	for i := uint(1); i < n; i++ {
-		powers[i].Mul(&powers[i-1], &x)
+		PT(&powers[i]).Mul(&powers[i-1], &x)
 	}
 
 	return powers
@@ -35,10 +56,10 @@ func ComputePowers(x fr.Element, n uint) []fr.Element {
 
 // IsPowerOfTwo returns true if `value` is a power of two.
 //
-// `0` will return false
+// `0` and negative values will return false
 //
 // [is_power_of_two]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#is_power_of_two
-func IsPowerOfTwo(value uint64) bool {
+func IsPowerOfTwo[T Integer](value T) bool {
 	return value > 0 && (value&(value-1) == 0)
 }
 
@@ -48,3 +69,70 @@ func ReduceCanonicalBigEndian(serScalar []byte) (fr.Element, error) {
 
 	return scalar, err
 }
+
+// BitReverse applies the bit-reversal permutation to `list`.
+// `len(list)` must be a power of 2
+//
+// This means that for post-state list output and pre-state list input,
+// we have output[i] == input[bitreverse(i)], where bitreverse reverses the bit-pattern
+// of i, interpreted as a log2(len(list))-bit integer.
+//
+// The elements can be of any type, such as field elements, group elements or cells.
+//
+// Modified from [gnark-crypto].
+//
+// [gnark-crypto]: https://github.com/ConsenSys/gnark-crypto/blob/8f7ca09273c24ed9465043566906cbecf5dcee91/ecc/bls12-381/fr/fft/fft.go#L245
+//
+// [reverse_bits]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#reverse_bits
+func BitReverse[K any](list []K) {
+	n := uint64(len(list))
+	if !IsPowerOfTwo(n) {
+		panic("size of list given to BitReverse must be a power of two")
+	}
+
+	// The standard library's bits.Reverse64 inverts its input as a 64-bit unsigned integer.
+	// However, we need to invert it as a log2(len(list))-bit integer, so we need to correct this by
+	// shifting appropriately.
+	shiftCorrection := uint64(64 - bits.TrailingZeros64(n))
+
+	for i := uint64(0); i < n; i++ {
+		// Find index irev, such that i and irev get swapped
+		irev := bits.Reverse64(i) >> shiftCorrection
+		if irev > i {
+			list[i], list[irev] = list[irev], list[i]
+		}
+	}
+}
+
+// Parallelize splits the range [0, n) into contiguous chunks and calls work on each chunk in its own go-routine.
+//
+// numGoRoutines is used to configure the amount of concurrency needed. Setting this
+// value to a negative number or 0 will make it default to the number of CPUs.
+func Parallelize(n, numGoRoutines int, work func(start, end int)) {
+	if numGoRoutines <= 0 {
+		numGoRoutines = runtime.NumCPU()
+	}
+	if numGoRoutines > n {
+		numGoRoutines = n
+	}
+	if numGoRoutines <= 1 {
+		work(0, n)
+		return
+	}
+
+	chunkSize := (n + numGoRoutines - 1) / numGoRoutines
+
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += chunkSize {
+		end := start + chunkSize
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			work(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
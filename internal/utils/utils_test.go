@@ -6,6 +6,8 @@ import (
 	"math/big"
 	"testing"
 
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 )
 
@@ -82,6 +84,54 @@ func TestComputePowersSmoke(t *testing.T) {
 	}
 }
 
+func TestIsPow2Generic(t *testing.T) {
+	if !IsPowerOfTwo(uint32(1<<31)) || IsPowerOfTwo(uint8(6)) {
+		t.Error("incorrect result for unsigned integers")
+	}
+	if IsPowerOfTwo(-4) || IsPowerOfTwo(int64(math.MinInt64)) {
+		t.Error("negative numbers are not powers of two")
+	}
+}
+
+// ComputePowers works for any type with the methods of a multiplicative group.
+func TestComputePowersGeneric(t *testing.T) {
+	var base fp.Element
+	base.SetUint64(5)
+	fpPowers := ComputePowers(base, 4)
+	expectedFp := fp.NewElement(125)
+	if !fpPowers[3].Equal(&expectedFp) {
+		t.Error("incorrect power of a base field element")
+	}
+
+	_, _, genG1, genG2 := bls12381.Generators()
+	gt, err := bls12381.Pair([]bls12381.G1Affine{genG1}, []bls12381.G2Affine{genG2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gtPowers := ComputePowers(gt, 3)
+
+	var doubleG1 bls12381.G1Affine
+	doubleG1.Double(&genG1)
+	expectedGt, err := bls12381.Pair([]bls12381.G1Affine{doubleG1}, []bls12381.G2Affine{genG2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gtPowers[2].Equal(&expectedGt) {
+		t.Error("incorrect power of a target group element")
+	}
+}
+
+func TestBitReverse(t *testing.T) {
+	list := []string{"000", "001", "010", "011", "100", "101", "110", "111"}
+	BitReverse(list)
+	expected := []string{"000", "100", "010", "110", "001", "101", "011", "111"}
+	for i := range list {
+		if list[i] != expected[i] {
+			t.Errorf("element %d: expected %s, got %s", i, expected[i], list[i])
+		}
+	}
+}
+
 func TestCanonicalEncoding(t *testing.T) {
 	x := randReducedBigInt()
 	xPlusModulus := addModP(x)
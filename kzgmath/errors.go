@@ -0,0 +1,21 @@
+package kzgmath
+
+import "errors"
+
+var (
+	// ErrLengthMismatch is returned when the number of points and the number of values given to a function differ.
+	ErrLengthMismatch = errors.New("number of points and values differ")
+	// ErrSetupTooSmall is returned when a [Setup] does not have enough points for a polynomial or a set of points.
+	ErrSetupTooSmall = errors.New("not enough points in the trusted setup")
+	// ErrInvalidSize is returned when a size is not a power of two, or a number of cells does not divide it evenly.
+	ErrInvalidSize = errors.New("size is not a power of two, or does not divide evenly")
+	// ErrDuplicatePoint is returned when points which must be distinct, such as those of an interpolation, are not.
+	ErrDuplicatePoint = errors.New("points are not distinct")
+	// ErrDivisionByZero is returned by [Divide] when the denominator is the zero polynomial.
+	ErrDivisionByZero = errors.New("division by the zero polynomial")
+	// ErrNotEnoughPoints is returned by [Recover] when there are fewer evaluations than coefficients to recover.
+	ErrNotEnoughPoints = errors.New("not enough evaluations to recover the polynomial")
+	// ErrInconsistentData is returned by [Recover] when the evaluations are not all of one polynomial with the
+	// expected number of coefficients.
+	ErrInconsistentData = errors.New("evaluations are not of a single polynomial of the expected degree")
+)
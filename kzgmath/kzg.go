@@ -0,0 +1,204 @@
+package kzgmath
+
+import (
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// Setup is a trusted setup in monomial form. Tests can build one from the monomial points of a
+// gokzg4844.InsecureSetup.
+type Setup struct {
+	// G1 holds [tau^i]G1 for i in [0, len(G1)).
+	G1 []bls12381.G1Affine
+	// G2 holds [tau^i]G2 for i in [0, len(G2)).
+	G2 []bls12381.G2Affine
+}
+
+// Commit returns the commitment [p(tau)]G1 = sum_i coeffs[i] * [tau^i]G1 to the polynomial, computed as one scalar
+// multiplication per coefficient.
+//
+// The same sum, taken over the Lagrange points of a setup and the evaluations of a polynomial, gives the same
+// commitment; that is how blobs are committed to.
+//
+// Returns [ErrSetupTooSmall] if the polynomial has more coefficients than there are G1 points.
+func Commit(setup *Setup, coeffs []fr.Element) (bls12381.G1Affine, error) {
+	if len(coeffs) > len(setup.G1) {
+		return bls12381.G1Affine{}, ErrSetupTooSmall
+	}
+
+	var sum bls12381.G1Jac
+	for i := range coeffs {
+		var term bls12381.G1Affine
+		term.ScalarMultiplication(&setup.G1[i], toBigInt(coeffs[i]))
+		sum.AddMixed(&term)
+	}
+	var commitment bls12381.G1Affine
+	commitment.FromJacobian(&sum)
+	return commitment, nil
+}
+
+// commitG2 returns [p(tau)]G2, in the same way as [Commit].
+func commitG2(setup *Setup, coeffs []fr.Element) (bls12381.G2Affine, error) {
+	if len(coeffs) > len(setup.G2) {
+		return bls12381.G2Affine{}, ErrSetupTooSmall
+	}
+
+	var sum bls12381.G2Jac
+	for i := range coeffs {
+		var term bls12381.G2Affine
+		term.ScalarMultiplication(&setup.G2[i], toBigInt(coeffs[i]))
+		sum.AddMixed(&term)
+	}
+	var commitment bls12381.G2Affine
+	commitment.FromJacobian(&sum)
+	return commitment, nil
+}
+
+// Open returns the proof that p(z) = y, which is the commitment to the quotient q(X) = (p(X) - y) / (X - z), along
+// with y.
+//
+// Returns [ErrSetupTooSmall] if the polynomial has more coefficients than there are G1 points.
+func Open(setup *Setup, coeffs []fr.Element, z fr.Element) (bls12381.G1Affine, fr.Element, error) {
+	proof, err := OpenMulti(setup, coeffs, []fr.Element{z})
+	return proof, Evaluate(coeffs, z), err
+}
+
+// Verify checks the proof that p(z) = y, for the polynomial p with the given commitment, by checking that
+//
+//	e(commitment - [y]G1, [1]G2) == e(proof, [tau - z]G2)
+//
+// which holds if commitment - [y]G1 is the commitment to (X - z)q(X), where q is the polynomial that the proof
+// commits to.
+//
+// Returns [ErrSetupTooSmall] if the setup has fewer than two G2 points.
+func Verify(setup *Setup, commitment bls12381.G1Affine, z, y fr.Element, proof bls12381.G1Affine) (bool, error) {
+	return VerifyMulti(setup, commitment, []fr.Element{z}, []fr.Element{y}, proof)
+}
+
+// OpenMulti returns the proof that p takes the values p(x) at each of the given points x. The proof is the
+// commitment to the quotient q(X) = (p(X) - I(X)) / Z(X), where I interpolates p at the points and Z vanishes at
+// them. With the points of a cell, this is a cell proof.
+//
+// Returns [ErrSetupTooSmall] if the polynomial has more coefficients than there are G1 points, and
+// [ErrDuplicatePoint] if two of the points are equal.
+func OpenMulti(setup *Setup, coeffs []fr.Element, points []fr.Element) (bls12381.G1Affine, error) {
+	if len(coeffs) > len(setup.G1) {
+		return bls12381.G1Affine{}, ErrSetupTooSmall
+	}
+
+	interpolation, err := Interpolate(points, EvaluateAll(coeffs, points))
+	if err != nil {
+		return bls12381.G1Affine{}, err
+	}
+	quotient, _, err := Divide(subtract(coeffs, interpolation), Vanishing(points))
+	if err != nil {
+		return bls12381.G1Affine{}, err
+	}
+	return Commit(setup, quotient)
+}
+
+// VerifyMulti checks the proof that the polynomial p with the given commitment takes the given values at the given
+// points, by checking that
+//
+//	e(commitment - [I(tau)]G1, [1]G2) == e(proof, [Z(tau)]G2)
+//
+// where I interpolates the values at the points, and Z vanishes at the points.
+//
+// Returns [ErrSetupTooSmall] if the setup does not have more G2 points than there are points, or fewer G1 points than
+// there are points, [ErrLengthMismatch] if the number of points and values differ, and [ErrDuplicatePoint] if two of
+// the points are equal.
+func VerifyMulti(setup *Setup, commitment bls12381.G1Affine, points, values []fr.Element, proof bls12381.G1Affine) (bool, error) {
+	interpolation, err := Interpolate(points, values)
+	if err != nil {
+		return false, err
+	}
+	interpolationCommitment, err := Commit(setup, interpolation)
+	if err != nil {
+		return false, err
+	}
+	vanishingCommitment, err := commitG2(setup, Vanishing(points))
+	if err != nil {
+		return false, err
+	}
+
+	var lhs, negProof bls12381.G1Affine
+	lhs.Sub(&commitment, &interpolationCommitment)
+	negProof.Neg(&proof)
+	return bls12381.PairingCheck(
+		[]bls12381.G1Affine{lhs, negProof},
+		[]bls12381.G2Affine{setup.G2[0], vanishingCommitment},
+	)
+}
+
+// CellPoints returns the points of each of the numCells cells of the extension of a polynomial with n coefficients.
+// The extension is the evaluation over the 2n'th roots of unity, in bit-reversed order, and each cell holds
+// 2n / numCells successive evaluations.
+//
+// Returns [ErrInvalidSize] if n or numCells is not a power of two, or there are more cells than roots.
+func CellPoints(n, numCells int) ([][]fr.Element, error) {
+	if n <= 0 || numCells <= 0 || numCells > 2*n || numCells&(numCells-1) != 0 {
+		return nil, ErrInvalidSize
+	}
+	roots, err := Roots(uint64(2 * n))
+	if err != nil {
+		return nil, err
+	}
+	roots, err = BitReverse(roots)
+	if err != nil {
+		return nil, err
+	}
+
+	size := len(roots) / numCells
+	cells := make([][]fr.Element, numCells)
+	for i := range cells {
+		cells[i] = roots[i*size : (i+1)*size]
+	}
+	return cells, nil
+}
+
+// CellsAndProofs returns the cells of the extension of the polynomial, as described in [CellPoints], and the proof
+// for each cell, as given by [OpenMulti]. The optimized way to compute all of the proofs is the FK20 algorithm, which
+// gives the same result.
+//
+// Returns [ErrInvalidSize] if the number of coefficients or numCells is not a power of two, and [ErrSetupTooSmall] if
+// the polynomial has more coefficients than there are G1 points.
+func CellsAndProofs(setup *Setup, coeffs []fr.Element, numCells int) ([][]fr.Element, []bls12381.G1Affine, error) {
+	cellPoints, err := CellPoints(len(coeffs), numCells)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cells := make([][]fr.Element, numCells)
+	proofs := make([]bls12381.G1Affine, numCells)
+	for i, points := range cellPoints {
+		cells[i] = EvaluateAll(coeffs, points)
+		proofs[i], err = OpenMulti(setup, coeffs, points)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return cells, proofs, nil
+}
+
+// subtract returns a - b.
+func subtract(a, b []fr.Element) []fr.Element {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	result := make([]fr.Element, n)
+	copy(result, a)
+	for i := range b {
+		result[i].Sub(&result[i], &b[i])
+	}
+	return result
+}
+
+// toBigInt returns the integer representation of x.
+func toBigInt(x fr.Element) *big.Int {
+	var result big.Int
+	x.BigInt(&result)
+	return &result
+}
@@ -0,0 +1,168 @@
+package kzgmath
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func randomElements(t *testing.T, n int) []fr.Element {
+	elements := make([]fr.Element, n)
+	for i := range elements {
+		_, err := elements[i].SetRandom()
+		require.NoError(t, err)
+	}
+	return elements
+}
+
+// insecureSetup returns the setup with 8 G1 points and 5 G2 points for a known secret.
+func insecureSetup(t *testing.T) *Setup {
+	setup, err := gokzg4844.NewInsecureSetup(big.NewInt(1234), 8, 5)
+	require.NoError(t, err)
+	return &Setup{G1: setup.G1Monomial, G2: setup.G2Monomial}
+}
+
+func TestRoots(t *testing.T) {
+	roots, err := Roots(8)
+	require.NoError(t, err)
+
+	// w^8 = 1, and w^4 = -1 so that w is primitive
+	var power fr.Element
+	power.Exp(roots[1], big.NewInt(8))
+	require.True(t, power.IsOne())
+	power.Exp(roots[1], big.NewInt(4))
+	minusOne := negate(fr.One())
+	require.True(t, power.Equal(&minusOne))
+
+	_, err = Roots(6)
+	require.ErrorIs(t, err, ErrInvalidSize)
+}
+
+func TestBitReverse(t *testing.T) {
+	values := make([]fr.Element, 8)
+	for i := range values {
+		values[i].SetUint64(uint64(i))
+	}
+	reversed, err := BitReverse(values)
+	require.NoError(t, err)
+	for i, expected := range []uint64{0, 4, 2, 6, 1, 5, 3, 7} {
+		require.Equal(t, fr.NewElement(expected), reversed[i])
+	}
+
+	single, err := BitReverse(values[:1])
+	require.NoError(t, err)
+	require.Equal(t, values[:1], single)
+}
+
+func TestDivide(t *testing.T) {
+	a := randomElements(t, 9)
+	b := randomElements(t, 4)
+
+	quotient, remainder, err := Divide(a, b)
+	require.NoError(t, err)
+	require.Len(t, remainder, 3)
+
+	// a(z) = q(z)b(z) + r(z)
+	z := randomElements(t, 1)[0]
+	var expected fr.Element
+	qz, bz, rz := Evaluate(quotient, z), Evaluate(b, z), Evaluate(remainder, z)
+	expected.Mul(&qz, &bz).Add(&expected, &rz)
+	az := Evaluate(a, z)
+	require.True(t, az.Equal(&expected))
+
+	_, _, err = Divide(a, make([]fr.Element, 2))
+	require.ErrorIs(t, err, ErrDivisionByZero)
+}
+
+func TestInterpolate(t *testing.T) {
+	coeffs := randomElements(t, 8)
+	points := randomElements(t, 8)
+
+	interpolated, err := Interpolate(points, EvaluateAll(coeffs, points))
+	require.NoError(t, err)
+	require.Equal(t, coeffs, interpolated)
+
+	for i := range points {
+		vanishing := Vanishing(points)
+		value := Evaluate(vanishing, points[i])
+		require.True(t, value.IsZero())
+	}
+
+	points[1] = points[0]
+	_, err = Interpolate(points, EvaluateAll(coeffs, points))
+	require.ErrorIs(t, err, ErrDuplicatePoint)
+}
+
+func TestRecover(t *testing.T) {
+	coeffs := randomElements(t, 4)
+	roots, err := Roots(8)
+	require.NoError(t, err)
+	values := EvaluateAll(coeffs, roots)
+
+	// Any half of the extension is enough
+	recovered, err := Recover(roots[3:], values[3:], 4)
+	require.NoError(t, err)
+	require.Equal(t, coeffs, recovered)
+
+	_, err = Recover(roots[5:], values[5:], 4)
+	require.ErrorIs(t, err, ErrNotEnoughPoints)
+
+	values[7].SetOne()
+	_, err = Recover(roots[3:], values[3:], 4)
+	require.ErrorIs(t, err, ErrInconsistentData)
+}
+
+func TestOpenVerify(t *testing.T) {
+	setup := insecureSetup(t)
+	coeffs := randomElements(t, 8)
+	commitment, err := Commit(setup, coeffs)
+	require.NoError(t, err)
+
+	z := randomElements(t, 1)[0]
+	proof, y, err := Open(setup, coeffs, z)
+	require.NoError(t, err)
+	ok, err := Verify(setup, commitment, z, y, proof)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	y.SetOne()
+	ok, err = Verify(setup, commitment, z, y, proof)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = Commit(setup, randomElements(t, 9))
+	require.ErrorIs(t, err, ErrSetupTooSmall)
+}
+
+func TestCellsAndProofs(t *testing.T) {
+	setup := insecureSetup(t)
+	coeffs := randomElements(t, 8)
+	commitment, err := Commit(setup, coeffs)
+	require.NoError(t, err)
+
+	cells, proofs, err := CellsAndProofs(setup, coeffs, 4)
+	require.NoError(t, err)
+	cellPoints, err := CellPoints(8, 4)
+	require.NoError(t, err)
+	for i := range cells {
+		ok, err := VerifyMulti(setup, commitment, cellPoints[i], cells[i], proofs[i])
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+
+	// The proof does not verify a cell with a different value
+	cells[0][0].SetOne()
+	ok, err := VerifyMulti(setup, commitment, cellPoints[0], cells[0], proofs[0])
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// The first half of the cells holds the evaluations over the original domain, in bit-reversed order
+	roots, err := Roots(8)
+	require.NoError(t, err)
+	evaluations, err := BitReverse(EvaluateAll(coeffs, roots))
+	require.NoError(t, err)
+	require.Equal(t, evaluations[4:], cells[1])
+}
@@ -0,0 +1,206 @@
+// Package kzgmath holds deliberately simple reference implementations of the polynomial arithmetic and KZG
+// operations that the rest of this module implements efficiently.
+//
+// Each function follows the textbook definition as directly as possible, and most take quadratic time, so they are
+// only usable for small sizes or in tests. They exist as an executable specification: the optimized code is tested
+// against them, and they can be read without knowing about FFTs, bit-reversal tricks or the barycentric formula.
+//
+// Polynomials are given by their coefficients, where the i'th element is the coefficient of X^i. Trusted setups are
+// given in monomial form, that is as the points [tau^i]G1 and [tau^i]G2.
+package kzgmath
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// Roots returns the n'th roots of unity w^0, w^1, ..., w^(n-1) in natural order, where w is the primitive n'th root
+// of unity used by the optimized code and the specs.
+//
+// Returns [ErrInvalidSize] if n is not a power of two, or is too large for the scalar field.
+func Roots(n uint64) ([]fr.Element, error) {
+	if n == 0 || n&(n-1) != 0 {
+		return nil, ErrInvalidSize
+	}
+	generator, err := fr.Generator(n)
+	if err != nil {
+		return nil, ErrInvalidSize
+	}
+
+	roots := make([]fr.Element, n)
+	roots[0].SetOne()
+	for i := uint64(1); i < n; i++ {
+		roots[i].Mul(&roots[i-1], &generator)
+	}
+	return roots, nil
+}
+
+// BitReverse returns a copy of values in which the element at index i is moved to the index whose binary
+// representation is that of i reversed. This is the order in which the specs store evaluations over the roots of
+// unity.
+//
+// Returns [ErrInvalidSize] if the number of values is not a power of two.
+func BitReverse(values []fr.Element) ([]fr.Element, error) {
+	n := uint64(len(values))
+	if n == 0 || n&(n-1) != 0 {
+		return nil, ErrInvalidSize
+	}
+
+	reversed := make([]fr.Element, n)
+	shift := 64 - bits.Len64(n-1)
+	for i := range values {
+		// For a single element the shift is 64, which gives zero as needed
+		reversed[bits.Reverse64(uint64(i))>>shift] = values[i]
+	}
+	return reversed, nil
+}
+
+// Evaluate returns p(z), using Horner's rule.
+func Evaluate(coeffs []fr.Element, z fr.Element) fr.Element {
+	var result fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		result.Mul(&result, &z)
+		result.Add(&result, &coeffs[i])
+	}
+	return result
+}
+
+// EvaluateAll returns p(x) for each of the points x, by evaluating p at each point in turn.
+func EvaluateAll(coeffs []fr.Element, points []fr.Element) []fr.Element {
+	values := make([]fr.Element, len(points))
+	for i := range points {
+		values[i] = Evaluate(coeffs, points[i])
+	}
+	return values
+}
+
+// Vanishing returns the coefficients of the polynomial Z(X) = (X - x_0)(X - x_1)...(X - x_(n-1)), which is zero at
+// exactly the given points.
+func Vanishing(points []fr.Element) []fr.Element {
+	coeffs := []fr.Element{fr.One()}
+	for i := range points {
+		// Multiply by X - x_i
+		next := make([]fr.Element, len(coeffs)+1)
+		for j := range coeffs {
+			var term fr.Element
+			term.Mul(&coeffs[j], &points[i])
+			next[j].Sub(&next[j], &term)
+			next[j+1].Add(&next[j+1], &coeffs[j])
+		}
+		coeffs = next
+	}
+	return coeffs
+}
+
+// Divide returns the quotient and the remainder of the division of the numerator by the denominator, using long
+// division. The remainder has fewer coefficients than the denominator.
+//
+// Returns [ErrDivisionByZero] if the denominator is the zero polynomial.
+func Divide(numerator, denominator []fr.Element) ([]fr.Element, []fr.Element, error) {
+	denominator = trim(denominator)
+	if len(denominator) == 0 {
+		return nil, nil, ErrDivisionByZero
+	}
+
+	remainder := make([]fr.Element, len(numerator))
+	copy(remainder, numerator)
+	if len(numerator) < len(denominator) {
+		return nil, remainder, nil
+	}
+
+	var leadInverse fr.Element
+	leadInverse.Inverse(&denominator[len(denominator)-1])
+
+	quotient := make([]fr.Element, len(numerator)-len(denominator)+1)
+	for i := len(quotient) - 1; i >= 0; i-- {
+		// Cancel the highest remaining coefficient with a multiple of X^i times the denominator
+		quotient[i].Mul(&remainder[i+len(denominator)-1], &leadInverse)
+		for j := range denominator {
+			var term fr.Element
+			term.Mul(&quotient[i], &denominator[j])
+			remainder[i+j].Sub(&remainder[i+j], &term)
+		}
+	}
+	return quotient, remainder[:len(denominator)-1], nil
+}
+
+// Interpolate returns the coefficients of the polynomial of degree less than n which takes the given values at the n
+// given points, using the Lagrange interpolation formula
+//
+//	p(X) = sum_i values[i] * prod_(j != i) (X - points[j]) / (points[i] - points[j])
+//
+// Returns [ErrLengthMismatch] if the number of points and values differ, and [ErrDuplicatePoint] if two points are
+// equal.
+func Interpolate(points, values []fr.Element) ([]fr.Element, error) {
+	if len(points) != len(values) {
+		return nil, ErrLengthMismatch
+	}
+
+	// The numerator of each term is Z(X) / (X - points[i])
+	vanishing := Vanishing(points)
+	coeffs := make([]fr.Element, len(points))
+	for i := range points {
+		numerator, _, err := Divide(vanishing, []fr.Element{negate(points[i]), fr.One()})
+		if err != nil {
+			return nil, err
+		}
+		denominator := Evaluate(numerator, points[i])
+		if denominator.IsZero() {
+			return nil, ErrDuplicatePoint
+		}
+
+		var scale fr.Element
+		scale.Inverse(&denominator)
+		scale.Mul(&scale, &values[i])
+		for j := range numerator {
+			var term fr.Element
+			term.Mul(&numerator[j], &scale)
+			coeffs[j].Add(&coeffs[j], &term)
+		}
+	}
+	return coeffs, nil
+}
+
+// Recover returns the coefficients of the polynomial of degree less than n which takes the given values at the given
+// points, such as the cells of an extended blob which were received. This is the same as [Interpolate] on the first n
+// points, except that the other points are checked to agree with the result.
+//
+// Returns [ErrNotEnoughPoints] if there are fewer than n points, and [ErrInconsistentData] if the values are not those
+// of a single polynomial of degree less than n.
+func Recover(points, values []fr.Element, n int) ([]fr.Element, error) {
+	if len(points) != len(values) {
+		return nil, ErrLengthMismatch
+	}
+	if len(points) < n {
+		return nil, ErrNotEnoughPoints
+	}
+
+	coeffs, err := Interpolate(points[:n], values[:n])
+	if err != nil {
+		return nil, err
+	}
+	for i := n; i < len(points); i++ {
+		value := Evaluate(coeffs, points[i])
+		if !value.Equal(&values[i]) {
+			return nil, ErrInconsistentData
+		}
+	}
+	return coeffs, nil
+}
+
+// trim returns the polynomial without its leading zero coefficients.
+func trim(coeffs []fr.Element) []fr.Element {
+	n := len(coeffs)
+	for n > 0 && coeffs[n-1].IsZero() {
+		n--
+	}
+	return coeffs[:n]
+}
+
+// negate returns -x.
+func negate(x fr.Element) fr.Element {
+	var result fr.Element
+	result.Neg(&x)
+	return result
+}
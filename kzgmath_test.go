@@ -0,0 +1,71 @@
+package gokzg4844_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/crate-crypto/go-kzg-4844/kzgmath"
+	"github.com/stretchr/testify/require"
+)
+
+// The optimized code gives the same results as the reference implementations in kzgmath.
+func TestAgainstReferenceImplementation(t *testing.T) {
+	setup := &kzgmath.Setup{G1: ctx.MonomialG1Points()}
+	for _, point := range ctx.TrustedSetup().SetupG2 {
+		compressed, err := hex.DecodeString(strings.TrimPrefix(point, "0x"))
+		require.NoError(t, err)
+		var g2 bls12381.G2Affine
+		_, err = g2.SetBytes(compressed)
+		require.NoError(t, err)
+		setup.G2 = append(setup.G2, g2)
+	}
+
+	blob := GetRandBlob(11)
+	polynomial, err := gokzg4844.DeserializeBlob(blob)
+	require.NoError(t, err)
+	roots, err := kzgmath.Roots(gokzg4844.ScalarsPerBlob)
+	require.NoError(t, err)
+	// Blobs are in bit-reversed order, and bit-reversal is its own inverse
+	evaluations, err := kzgmath.BitReverse(polynomial)
+	require.NoError(t, err)
+	coeffs, err := kzgmath.Interpolate(roots, evaluations)
+	require.NoError(t, err)
+
+	// Commitment
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	expectedCommitment, err := kzgmath.Commit(setup, coeffs)
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.KZGCommitment(gokzg4844.SerializeG1Point(expectedCommitment)), commitment)
+
+	// Opening at a point outside of the domain
+	z := GetRandFieldElement(12)
+	proof, y, err := ctx.ComputeKZGProof(blob, z, NumGoRoutines)
+	require.NoError(t, err)
+	zElement, err := gokzg4844.DeserializeScalar(z)
+	require.NoError(t, err)
+	expectedProof, expectedY, err := kzgmath.Open(setup, coeffs, zElement)
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.KZGProof(gokzg4844.SerializeG1Point(expectedProof)), proof)
+	require.Equal(t, gokzg4844.SerializeScalar(expectedY), y)
+	ok, err := kzgmath.Verify(setup, expectedCommitment, zElement, expectedY, expectedProof)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Extension into cells
+	cells, err := ctx.ComputeCells(blob)
+	require.NoError(t, err)
+	cellPoints, err := kzgmath.CellPoints(gokzg4844.ScalarsPerBlob, gokzg4844.CellsPerExtBlob)
+	require.NoError(t, err)
+	for i := range cells {
+		var expectedCell gokzg4844.Cell
+		for j, value := range kzgmath.EvaluateAll(coeffs, cellPoints[i]) {
+			scalar := gokzg4844.SerializeScalar(value)
+			copy(expectedCell[j*gokzg4844.SerializedScalarSize:], scalar[:])
+		}
+		require.Equal(t, expectedCell, cells[i], "cell %d", i)
+	}
+}
@@ -0,0 +1,181 @@
+package gokzg4844
+
+import (
+	"context"
+	"sync"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// loadedSetup holds the parts of a context which are derived from all of the points of the trusted setup. Only
+// verifying a single proof with [Context.VerifyKZGProof] can be done without them.
+type loadedSetup struct {
+	domain    *kzg.Domain
+	commitKey *kzg.CommitKey
+
+	// extensionCoset caches the values needed to evaluate polynomials over
+	// the coset which extends the domain to twice its size.
+	extensionCoset *cosetCache
+
+	// setupG2 holds all of the monomial G2 points of the trusted setup, of which
+	// only the first two are needed for verification. They are kept so that the
+	// setup can be exported with [Context.TrustedSetup].
+	setupG2 []bls12381.G2Affine
+}
+
+// lazySetup loads the [loadedSetup] of a context the first time that it is needed.
+type lazySetup struct {
+	once sync.Once
+	// load computes the setup. It is cleared once it has run, so that the
+	// unparsed setup that it references can be garbage collected.
+	load  func() (*loadedSetup, error)
+	setup *loadedSetup
+	// err is the error returned by load. The setup is still usable when it
+	// is set, but lacks the parts which could not be computed.
+	err error
+}
+
+// loaded returns the setup of the context, loading it if needed.
+func (c *Context) loaded() *loadedSetup {
+	c.lazy.once.Do(func() {
+		prof := startProfile(context.Background(), "load_setup", c.preset.ScalarsPerBlob)
+		defer prof.end()
+
+		c.lazy.setup, c.lazy.err = c.lazy.load()
+		c.lazy.load = nil
+	})
+	return c.lazy.setup
+}
+
+// domain returns the domain of the context, with its roots in the evaluation order of the context.
+func (c *Context) domain() *kzg.Domain {
+	return c.loaded().domain
+}
+
+// commitKey returns the Lagrange G1 points of the trusted setup, in the evaluation order of the context.
+func (c *Context) commitKey() *kzg.CommitKey {
+	return c.loaded().commitKey
+}
+
+// extensionCoset returns the cache of the values needed to extend blobs.
+func (c *Context) extensionCoset() *cosetCache {
+	return c.loaded().extensionCoset
+}
+
+// setupG2 returns all of the monomial G2 points of the trusted setup.
+func (c *Context) setupG2() []bls12381.G2Affine {
+	return c.loaded().setupG2
+}
+
+// Warm does the work that a context created with [WithLazyInit] defers until it is first needed: parsing the G1
+// points of the trusted setup, computing the domain, applying the evaluation order and computing the table
+// configured with [WithPrecompute]. This lets callers pay for it at a time of their choosing, for example before
+// a node starts serving requests.
+//
+// It is safe to call Warm more than once, and concurrently with other methods. It does nothing for a context which
+// was not created with [WithLazyInit], or which has already been warmed.
+//
+// Everything that can fail is checked when the context is created, so Warm only returns an error if the table of
+// [WithPrecompute] could not be computed, in which case the context computes commitments without it. The same error
+// is returned by every call.
+func (c *Context) Warm() error {
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+	c.loaded()
+	return c.lazy.err
+}
+
+// newLoadedSetup computes the setup of a context from the Lagrange G1 points of the trusted setup and its G2 points.
+// The G1 points are in natural order, unless reversed is true, in which case they are already in bit-reversed order
+// and the evaluation order of the configuration must be [BitReversedOrder]. The G1 points are only modified if they
+// need to be permuted, so already reversed points may be in read-only memory.
+//
+// sourceIndices must be those of the evaluation order of the configuration if it is neither [NaturalOrder] nor
+// [BitReversedOrder], and the window size of the table, if any, must be valid.
+//
+// The setup is returned even if the table cannot be computed, along with the error; it then commits without the table.
+func newLoadedSetup(cfg config, preset Preset, setupLagrangeG1Points []bls12381.G1Affine, setupG2Points []bls12381.G2Affine, reversed bool, sourceIndices []int) (*loadedSetup, error) {
+	commitKey := kzg.CommitKey{
+		G1: setupLagrangeG1Points,
+	}
+
+	// The domain is shared, so its roots are permuted in a copy
+	sharedDomain := cfg.domain
+	if sharedDomain == nil {
+		sharedDomain = cachedDomain(uint64(preset.ScalarsPerBlob), cfg.numGoRoutines)
+	}
+	domain := sharedDomain.clone(!cfg.lowMemoryDomain)
+
+	// Permute the roots and the trusted setup according to the evaluation order.
+	//
+	// By default, these are bit-reversed according to the specs.
+	// The bit reversal is not needed for simple KZG however it was
+	// implemented to make the step for full dank-sharding easier.
+	switch cfg.order {
+	case NaturalOrder:
+	case BitReversedOrder:
+		if !reversed {
+			commitKey.ReversePoints()
+		}
+		domain.ReverseRoots()
+	default:
+		permuteSetup(sourceIndices, domain, &commitKey)
+	}
+
+	// The table depends on the order of the points, so this must happen after they have been reversed.
+	var err error
+	if cfg.precomputeWindowBits != 0 {
+		err = commitKey.Precompute(cfg.precomputeWindowBits, cfg.numGoRoutines)
+	}
+
+	return &loadedSetup{
+		domain:         domain,
+		commitKey:      &commitKey,
+		extensionCoset: newExtensionCosetCache(domain),
+		setupG2:        setupG2Points,
+	}, err
+}
+
+// newLazyContext4096 is the same as [NewContext4096], for a configuration with [WithLazyInit]. Only the first two
+// G2 points of the mainnet setup are parsed straight away. The points of any other setup are parsed, and checked if
+// the configuration requires it, before this returns, so that a malformed point is not found when the setup is
+// loaded; the points of the mainnet setup, which is identified by its digest, are known to be well-formed.
+func newLazyContext4096(cfg config, trustedSetup *JSONTrustedSetup) (*Context, error) {
+	// This should not happen for the ETH protocol
+	// However since it's a public method, we add the check.
+	if len(trustedSetup.SetupG2) < 2 {
+		return nil, kzg.ErrMinSRSSize
+	}
+	setupDigest, err := SetupDigest(trustedSetup)
+	if err != nil {
+		return nil, err
+	}
+	if setupDigest != MainnetSetupDigest || cfg.needsSetupChecks(setupDigest) {
+		setupDigest, genG1, setupLagrangeG1Points, setupG2Points, err := parseCheckedTrustedSetup(cfg, trustedSetup)
+		if err != nil {
+			return nil, err
+		}
+		return newContext4096(cfg, setupDigest, genG1, setupLagrangeG1Points, setupG2Points)
+	}
+
+	genG2, err := parseG2PointNoSubgroupCheck(trustedSetup.SetupG2[0])
+	if err != nil {
+		return nil, err
+	}
+	alphaG2, err := parseG2PointNoSubgroupCheck(trustedSetup.SetupG2[1])
+	if err != nil {
+		return nil, err
+	}
+
+	// The caller may modify the setup once this returns
+	setup := *trustedSetup
+	setup.SetupG2 = append([]G2CompressedHexStr(nil), trustedSetup.SetupG2...)
+	_, _, genG1, _ := bls12381.Generators()
+	return newContextFromLoader(cfg, mainnetPreset, setupDigest, genG1, genG2, alphaG2, func() ([]bls12381.G1Affine, []bls12381.G2Affine, bool, error) {
+		// This cannot fail, since the points are those of the mainnet setup
+		_, setupLagrangeG1Points, setupG2Points, err := parseTrustedSetup(&setup, cfg.numGoRoutines)
+		return setupLagrangeG1Points, setupG2Points, false, err
+	})
+}
@@ -0,0 +1,76 @@
+package gokzg4844
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/multiexp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazyInit(t *testing.T) {
+	ctx, err := NewContext4096Secure()
+	require.NoError(t, err)
+	lazyCtx, err := NewContext4096Secure(WithLazyInit(true))
+	require.NoError(t, err)
+	require.Nil(t, lazyCtx.lazy.setup)
+
+	polynomial := make([]fr.Element, ScalarsPerBlob)
+	for i := range polynomial {
+		polynomial[i].SetUint64(uint64(3*i + 1))
+	}
+	blob := SerializePoly(polynomial)
+	commitment, err := ctx.BlobToKZGCommitment(blob, 0)
+	require.NoError(t, err)
+	var z Scalar
+	z[31] = 5
+	proof, y, err := ctx.ComputeKZGProof(blob, z, 0)
+	require.NoError(t, err)
+
+	// Verifying a single proof does not need the setup to be loaded
+	require.NoError(t, lazyCtx.VerifyKZGProof(commitment, z, y, proof))
+	require.Nil(t, lazyCtx.lazy.setup)
+
+	lazyCommitment, err := lazyCtx.BlobToKZGCommitment(blob, 0)
+	require.NoError(t, err)
+	require.Equal(t, commitment, lazyCommitment)
+	require.NotNil(t, lazyCtx.lazy.setup)
+	require.Equal(t, ctx.TrustedSetup(), lazyCtx.TrustedSetup())
+
+	// Warming a loaded context does nothing
+	loaded := lazyCtx.lazy.setup
+	require.NoError(t, lazyCtx.Warm())
+	require.Same(t, loaded, lazyCtx.lazy.setup)
+}
+
+func TestLazyInitOptionErrors(t *testing.T) {
+	warmCtx, err := NewContext4096Secure(WithLazyInit(true))
+	require.NoError(t, err)
+	require.NoError(t, warmCtx.Warm())
+	require.NotNil(t, warmCtx.lazy.setup)
+
+	// Invalid options are reported by the constructor, not when the setup is loaded
+	_, err = NewContext4096Secure(WithLazyInit(true), WithPrecompute(1))
+	require.ErrorIs(t, err, multiexp.ErrInvalidWindowBits)
+	_, err = NewContext4096Secure(WithLazyInit(true), WithEvaluationOrder(nil))
+	require.ErrorIs(t, err, ErrInvalidPermutation)
+	domain, err := NewDomain(8)
+	require.NoError(t, err)
+	_, err = NewContext4096Secure(WithLazyInit(true), WithDomain(domain))
+	require.ErrorIs(t, err, ErrInvalidDomainSize)
+}
+
+// A malformed point is reported by the constructor, even if the setup is not checked and the context is lazy
+func TestLazyInitMalformedSetup(t *testing.T) {
+	ctx, err := NewContext4096Secure()
+	require.NoError(t, err)
+	setup := ctx.TrustedSetup()
+	// The point does not have the flag of a compressed point
+	setup.SetupG1Lagrange[5] = "0x" + strings.Repeat("00", CompressedG1Size)
+
+	for _, lazy := range []bool{false, true} {
+		_, err := NewContext4096(setup, WithLazyInit(lazy), WithSetupChecks(SetupChecksNever))
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !linux && !darwin
+
+package gokzg4844
+
+import (
+	"os"
+)
+
+// mmapFile reads the file at path into memory, since memory mapping is not supported on this platform.
+//
+// Returns [ErrMlockUnsupported] if lock is true.
+func mmapFile(path string, lock bool) ([]byte, func() error, error) {
+	if lock {
+		return nil, nil, ErrMlockUnsupported
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil, ErrInvalidPrecomputeFile
+	}
+	return data, func() error { return nil }, nil
+}
@@ -0,0 +1,207 @@
+package gokzg4844
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"unsafe"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+	"github.com/crate-crypto/go-kzg-4844/internal/multiexp"
+	"github.com/crate-crypto/go-kzg-4844/internal/utils"
+)
+
+// mmapSetupMagic identifies a trusted setup written by [WriteMmapTrustedSetup] and the version of its format.
+var mmapSetupMagic = [8]byte{'G', 'O', 'K', 'Z', 'G', 'M', 'S', '1'}
+
+// mmapSetupHeader is written before the points of a setup written by [WriteMmapTrustedSetup]. Its size is a multiple
+// of 8 bytes, so that the points which follow it are aligned in a mapping of the file.
+type mmapSetupHeader struct {
+	Magic [8]byte
+	NumG1 uint32
+	NumG2 uint32
+}
+
+const (
+	// g1AffineSize is the size of a [bls12381.G1Affine] in memory, which is also its size in a file written by
+	// [WriteMmapTrustedSetup].
+	g1AffineSize = int(unsafe.Sizeof(bls12381.G1Affine{}))
+	// g2AffineSize is the same as g1AffineSize, for a [bls12381.G2Affine].
+	g2AffineSize = int(unsafe.Sizeof(bls12381.G2Affine{}))
+)
+
+// WriteMmapTrustedSetup converts a trusted setup to the preprocessed binary format read by [NewContext4096Mmap], and
+// writes it to w.
+//
+// The Lagrange G1 points are written in bit-reversed order, followed by the G2 points, in the internal (Montgomery,
+// little-endian) representation used by gnark-crypto, so that they can be used straight from a memory mapping of the
+// file. The format is therefore only meant to be read back by this library.
+//
+// The points of the setup are checked to be in the correct subgroup, unless it is the setup from the Ethereum KZG
+// ceremony, as for [NewContext4096].
+func WriteMmapTrustedSetup(w io.Writer, setup *JSONTrustedSetup) error {
+	_, _, setupLagrangeG1Points, setupG2Points, err := parseCheckedTrustedSetup(newConfig(nil), setup)
+	if err != nil {
+		return err
+	}
+	utils.BitReverse(setupLagrangeG1Points)
+
+	bw := bufio.NewWriter(w)
+	header := mmapSetupHeader{
+		Magic: mmapSetupMagic,
+		NumG1: uint32(len(setupLagrangeG1Points)),
+		NumG2: uint32(len(setupG2Points)),
+	}
+	if err := binary.Write(bw, binary.LittleEndian, &header); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, setupLagrangeG1Points); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, setupG2Points); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// NewContext4096Mmap is the same as [NewContext4096], except that the trusted setup is read from the file at path,
+// in the format written by [WriteMmapTrustedSetup], by memory mapping it.
+//
+// The points are used straight from the mapping, without being parsed or copied, so creating a context this way is
+// much faster than parsing the setup, and processes on the same host which map the same file share the memory of its
+// points. This is meant for memory-constrained hosts which run several node processes; the precomputed table can be
+// shared in the same way with [Context.LoadPrecomputeMmap]. The G1 points are copied instead if the context uses an
+// evaluation order other than [BitReversedOrder], or, as for [Context.LoadPrecomputeMmap], on platforms where the file
+// cannot be mapped or the points cannot be used in place.
+//
+// All of the points are checked to be on the curve. They are checked to be in the correct subgroup as for
+// [NewContext4096], unless the file holds the setup from the Ethereum KZG ceremony, which is identified by the digest
+// of its points. The file must not be modified while the context is in use. The mapping is kept for the lifetime of
+// the process if the points are used from it, and released straight away otherwise.
+//
+// Returns [ErrInvalidMmapTrustedSetup] if the file does not hold a setup in the expected format or a point is not on
+// the curve, or [ErrPointNotInSubgroup] if a point is not in the correct subgroup.
+func NewContext4096Mmap(path string, opts ...Option) (*Context, error) {
+	cfg := newConfig(opts)
+
+	if err := SelfTest(); err != nil {
+		return nil, err
+	}
+
+	data, release, err := mmapFile(path, false)
+	if errors.Is(err, ErrInvalidPrecomputeFile) {
+		return nil, ErrInvalidMmapTrustedSetup
+	}
+	if err != nil {
+		return nil, err
+	}
+	setupLagrangeG1Points, setupG2Points, aliased, err := mmapSetupPoints(data, cfg.numGoRoutines)
+	if err != nil {
+		_ = release()
+		return nil, err
+	}
+
+	// The digest is computed over the points in natural order
+	natural := append([]bls12381.G1Affine(nil), setupLagrangeG1Points...)
+	utils.BitReverse(natural)
+	setupDigest := setupDigestOfPoints(natural, setupG2Points)
+	if cfg.needsSetupChecks(setupDigest) {
+		err := forEachSetupChunk(len(natural), cfg.numGoRoutines, func(start, end int) error {
+			for i := start; i < end; i++ {
+				if !natural[i].IsInSubGroup() {
+					return ErrPointNotInSubgroup
+				}
+			}
+			return nil
+		})
+		for i := range setupG2Points {
+			if err == nil && !setupG2Points[i].IsInSubGroup() {
+				err = ErrPointNotInSubgroup
+			}
+		}
+		if err != nil {
+			_ = release()
+			return nil, err
+		}
+	}
+
+	// The mapping is only kept if the context uses the points in it
+	reversed := cfg.order == BitReversedOrder
+	if !reversed {
+		setupLagrangeG1Points = natural
+	}
+	if !reversed || !aliased {
+		_ = release()
+	}
+	_, _, genG1, _ := bls12381.Generators()
+	return newContextFromLoader(cfg, mainnetPreset, setupDigest, genG1, setupG2Points[0], setupG2Points[1], func() ([]bls12381.G1Affine, []bls12381.G2Affine, bool, error) {
+		return setupLagrangeG1Points, setupG2Points, reversed, nil
+	})
+}
+
+// mmapSetupPoints returns the points of a setup written by [WriteMmapTrustedSetup], after checking that they are all on
+// the curve, using up to numGoRoutines go-routines. The G1 points use the memory of data if possible, which the
+// returned boolean reports; the G2 points are always copied.
+func mmapSetupPoints(data []byte, numGoRoutines int) ([]bls12381.G1Affine, []bls12381.G2Affine, bool, error) {
+	var header mmapSetupHeader
+	headerSize := binary.Size(header)
+	if len(data) < headerSize {
+		return nil, nil, false, ErrInvalidMmapTrustedSetup
+	}
+	if err := binary.Read(bytes.NewReader(data[:headerSize]), binary.LittleEndian, &header); err != nil {
+		return nil, nil, false, err
+	}
+	if header.Magic != mmapSetupMagic || header.NumG1 != ScalarsPerBlob {
+		return nil, nil, false, ErrInvalidMmapTrustedSetup
+	}
+	if header.NumG2 > maxSetupG2Points {
+		return nil, nil, false, &CountLimitError{What: "G2 points", Count: int(header.NumG2), Max: maxSetupG2Points, Err: ErrInvalidMmapTrustedSetup}
+	}
+	// This should not happen for the ETH protocol
+	// However since it's a public method, we add the check.
+	if header.NumG2 < 2 {
+		return nil, nil, false, kzg.ErrMinSRSSize
+	}
+	g1Data := data[headerSize:]
+	if len(g1Data) != int(header.NumG1)*g1AffineSize+int(header.NumG2)*g2AffineSize {
+		return nil, nil, false, ErrInvalidMmapTrustedSetup
+	}
+	g2Data := g1Data[int(header.NumG1)*g1AffineSize:]
+
+	var g1Points []bls12381.G1Affine
+	aliased := multiexp.IsLittleEndian() && uintptr(unsafe.Pointer(&g1Data[0]))%8 == 0
+	if aliased {
+		g1Points = unsafe.Slice((*bls12381.G1Affine)(unsafe.Pointer(&g1Data[0])), header.NumG1)
+	} else {
+		g1Points = make([]bls12381.G1Affine, header.NumG1)
+		if err := binary.Read(bytes.NewReader(g1Data[:len(g1Data)-len(g2Data)]), binary.LittleEndian, g1Points); err != nil {
+			return nil, nil, false, err
+		}
+	}
+	g2Points := make([]bls12381.G2Affine, header.NumG2)
+	if err := binary.Read(bytes.NewReader(g2Data), binary.LittleEndian, g2Points); err != nil {
+		return nil, nil, false, err
+	}
+
+	// The digest of the setup is computed from the compressed encodings, which only identify points on the curve
+	err := forEachSetupChunk(len(g1Points), numGoRoutines, func(start, end int) error {
+		for i := start; i < end; i++ {
+			if !g1Points[i].IsOnCurve() {
+				return ErrInvalidMmapTrustedSetup
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, false, err
+	}
+	for i := range g2Points {
+		if !g2Points[i].IsOnCurve() {
+			return nil, nil, false, ErrInvalidMmapTrustedSetup
+		}
+	}
+	return g1Points, g2Points, aliased, nil
+}
@@ -0,0 +1,75 @@
+package gokzg4844_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewContext4096Mmap(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, gokzg4844.WriteMmapTrustedSetup(&buf, ctx.TrustedSetup()))
+	path := filepath.Join(t.TempDir(), "setup.bin")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+
+	blob := GetRandBlob(61)
+	expectedCommitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	expectedProof, err := ctx.ComputeBlobKZGProof(blob, expectedCommitment, NumGoRoutines)
+	require.NoError(t, err)
+
+	for _, opts := range [][]gokzg4844.Option{nil, {gokzg4844.WithBitReversalPermutation(false)}} {
+		ctxMmap, err := gokzg4844.NewContext4096Mmap(path, opts...)
+		require.NoError(t, err)
+		require.Equal(t, gokzg4844.MainnetSetupDigest, ctxMmap.SetupDigest())
+		require.Equal(t, ctx.TrustedSetup(), ctxMmap.TrustedSetup())
+
+		commitment, err := ctxMmap.BlobToKZGCommitment(blob, NumGoRoutines)
+		require.NoError(t, err)
+		proof, err := ctxMmap.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+		require.NoError(t, err)
+		require.NoError(t, ctxMmap.VerifyBlobKZGProof(blob, commitment, proof))
+		if opts == nil {
+			require.Equal(t, expectedCommitment, commitment)
+			require.Equal(t, expectedProof, proof)
+		} else {
+			ctxNatural, err := gokzg4844.NewContext4096Secure(opts...)
+			require.NoError(t, err)
+			naturalCommitment, err := ctxNatural.BlobToKZGCommitment(blob, NumGoRoutines)
+			require.NoError(t, err)
+			require.Equal(t, naturalCommitment, commitment)
+		}
+	}
+
+	// The precomputed table can be mapped as well
+	ctxPrecompute, err := gokzg4844.NewContext4096Secure(gokzg4844.WithPrecompute(8))
+	require.NoError(t, err)
+	tablePath := filepath.Join(t.TempDir(), "precompute.bin")
+	f, err := os.Create(tablePath)
+	require.NoError(t, err)
+	require.NoError(t, ctxPrecompute.SavePrecompute(f))
+	require.NoError(t, f.Close())
+	ctxMmap, err := gokzg4844.NewContext4096Mmap(path)
+	require.NoError(t, err)
+	require.NoError(t, ctxMmap.LoadPrecomputeMmap(tablePath))
+	commitment, err := ctxMmap.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, expectedCommitment, commitment)
+
+	// A point whose Y coordinate is not on the curve, which does not change its compressed encoding or the digest
+	offCurve := append([]byte(nil), buf.Bytes()...)
+	offCurve[16+100*96+48] ^= 1
+
+	for _, data := range [][]byte{nil, buf.Bytes()[:100], buf.Bytes()[:buf.Len()-1], offCurve} {
+		invalid := filepath.Join(t.TempDir(), "invalid.bin")
+		require.NoError(t, os.WriteFile(invalid, data, 0o600))
+		_, err = gokzg4844.NewContext4096Mmap(invalid)
+		require.ErrorIs(t, err, gokzg4844.ErrInvalidMmapTrustedSetup)
+	}
+	_, err = gokzg4844.NewContext4096Mmap(filepath.Join(t.TempDir(), "missing.bin"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
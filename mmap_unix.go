@@ -0,0 +1,45 @@
+//go:build linux || darwin
+
+package gokzg4844
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapFile maps the file at path into memory, read-only. If lock is true, the pages are also locked into memory, so
+// that they are never paged out.
+//
+// The returned function releases the mapping.
+func mmapFile(path string, lock bool) ([]byte, func() error, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The mapping stays valid after the file is closed
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	if info.Size() == 0 {
+		return nil, nil, ErrInvalidPrecomputeFile
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	release := func() error {
+		return syscall.Munmap(data)
+	}
+
+	if lock {
+		if err := syscall.Mlock(data); err != nil {
+			_ = release()
+			return nil, nil, err
+		}
+	}
+	return data, release, nil
+}
@@ -0,0 +1,106 @@
+package gokzg4844
+
+import (
+	"context"
+	"sync"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/multiexp"
+)
+
+// monomialKey holds the monomial form of the G1 points of the trusted setup, which are derived from the Lagrange
+// points the first time that they are needed.
+type monomialKey struct {
+	once sync.Once
+	// g1 holds [tau^i]G for i in [0, n), where n is the number of scalars in a blob of the preset of the context.
+	g1 []bls12381.G1Affine
+}
+
+// monomialG1 returns the monomial G1 points of the trusted setup, deriving them if needed.
+func (c *Context) monomialG1() []bls12381.G1Affine {
+	c.monomial.once.Do(func() {
+		prof := startProfile(context.Background(), "derive_monomial_srs", c.preset.ScalarsPerBlob)
+		defer prof.end()
+
+		n := len(c.commitKey().G1)
+		sourceIndices := make([]int, n)
+		for i := range sourceIndices {
+			sourceIndices[i] = c.order.SourceIndex(i, n)
+		}
+		c.monomial.g1 = lagrangeToMonomialG1(c.domain(), c.commitKey().G1, sourceIndices)
+	})
+	return c.monomial.g1
+}
+
+// MonomialG1Points returns the G1 points of the trusted setup in monomial form, ie [tau^i]G for i in [0, n), where n
+// is the number of scalars in a blob of the preset of the context; this is [ScalarsPerBlob] unless the context was
+// created for another [Preset]. The returned slice is a copy, which the caller may modify.
+//
+// The trusted setup only holds the points in Lagrange form, so the monomial points are derived from them the first
+// time that they are needed by this method or [Context.CommitMonomial], unless the context was created with
+// [WithEagerMonomialSRS]. This takes a few seconds.
+func (c *Context) MonomialG1Points() []bls12381.G1Affine {
+	if c.checkOpen() != nil {
+		return nil
+	}
+	points := make([]bls12381.G1Affine, c.preset.ScalarsPerBlob)
+	copy(points, c.monomialG1())
+	return points
+}
+
+// TruncatedLagrangeG1Points returns the Lagrange form of the first size monomial G1 points of the trusted setup, over
+// the domain of that size, in the given order; use [BitReversedOrder] for the order of a blob. That is, it returns
+// the setup for polynomials of degree less than size under the same secret as the setup of the context, with which
+// commitments to shorter polynomials can be computed directly from their evaluations.
+//
+// The monomial points are derived first if needed, as described in [Context.MonomialG1Points], and are then converted
+// back with an FFT over the smaller domain.
+//
+// Returns [ErrInvalidDomainSize] if size is not a power of two, or is larger than the number of scalars in a blob of
+// the preset of the context, and [ErrInvalidPermutation] if order is not a bijection.
+func (c *Context) TruncatedLagrangeG1Points(size uint64, order Permutation) ([]bls12381.G1Affine, error) {
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
+	if size > uint64(c.preset.ScalarsPerBlob) {
+		return nil, ErrInvalidDomainSize
+	}
+	domain, err := NewDomain(size)
+	if err != nil {
+		return nil, err
+	}
+	return domain.MonomialToLagrangeG1(c.MonomialG1Points()[:size], order)
+}
+
+// CommitMonomial computes the commitment to the polynomial with the given coefficients, where the i'th coefficient
+// is the coefficient of X^i.
+//
+// The commitment is the same as the one computed by [Context.BlobToKZGCommitment] for the blob holding the
+// evaluations of the polynomial, see [Context.VerifyBasisConsistency]. Committing to the coefficients directly avoids
+// the FFT that would be needed to compute the evaluations.
+//
+// numGoRoutines is used to configure the amount of concurrency needed. Setting this
+// value to a negative number or 0 will make it default to the value configured with
+// [WithNumGoRoutines], which itself defaults to the number of CPUs.
+//
+// It returns [ErrInvalidPolynomialLength] if there are more coefficients than scalars in a blob of the preset of the
+// context. The commitment to an
+// empty list of coefficients is the commitment to the zero polynomial, ie [PointAtInfinity].
+func (c *Context) CommitMonomial(coeffs []fr.Element, numGoRoutines int) (KZGCommitment, error) {
+	if err := c.checkOpen(); err != nil {
+		return KZGCommitment{}, err
+	}
+	if len(coeffs) > c.preset.ScalarsPerBlob {
+		return KZGCommitment{}, ErrInvalidPolynomialLength
+	}
+	if len(coeffs) == 0 {
+		return PointAtInfinity, nil
+	}
+
+	commitment, err := multiexp.MultiExp(coeffs, c.monomialG1()[:len(coeffs)], c.goRoutines(numGoRoutines))
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	return KZGCommitment(SerializeG1Point(*commitment)), nil
+}
@@ -0,0 +1,85 @@
+package gokzg4844_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/crate-crypto/go-kzg-4844/kzgmath"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitMonomial(t *testing.T) {
+	// The first monomial point is the generator
+	_, _, genG1, _ := bls12381.Generators()
+	points := ctx.MonomialG1Points()
+	require.Len(t, points, gokzg4844.ScalarsPerBlob)
+	require.True(t, points[0].Equal(&genG1))
+
+	coeffs := make([]fr.Element, 100)
+	for i := range coeffs {
+		coeffs[i].SetUint64(uint64(3*i + 1))
+	}
+	monomialCommitment, err := ctx.CommitMonomial(coeffs, NumGoRoutines)
+	require.NoError(t, err)
+
+	polynomial, err := ctx.PolynomialFromCoefficients(coeffs)
+	require.NoError(t, err)
+	blob, err := polynomial.ToBlob()
+	require.NoError(t, err)
+	lagrangeCommitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, lagrangeCommitment, monomialCommitment)
+
+	commitment, err := ctx.CommitMonomial(nil, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.KZGCommitment(gokzg4844.PointAtInfinity), commitment)
+
+	_, err = ctx.CommitMonomial(make([]fr.Element, gokzg4844.ScalarsPerBlob+1), NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPolynomialLength)
+}
+
+func TestWithEagerMonomialSRS(t *testing.T) {
+	// The monomial points do not depend on the evaluation order
+	eagerCtx, err := gokzg4844.NewContext4096Secure(gokzg4844.WithEagerMonomialSRS(true), gokzg4844.WithEvaluationOrder(gokzg4844.NaturalOrder))
+	require.NoError(t, err)
+	require.Equal(t, ctx.MonomialG1Points(), eagerCtx.MonomialG1Points())
+}
+
+func TestTruncatedLagrangeG1Points(t *testing.T) {
+	const size = 16
+	lagrange, err := ctx.TruncatedLagrangeG1Points(size, gokzg4844.BitReversedOrder)
+	require.NoError(t, err)
+	require.Len(t, lagrange, size)
+
+	// Committing to the evaluations with the truncated setup is the same as committing to the coefficients
+	coeffs := make([]fr.Element, size)
+	for i := range coeffs {
+		coeffs[i].SetUint64(uint64(3*i + 1))
+	}
+	domain, err := gokzg4844.NewDomain(size)
+	require.NoError(t, err)
+	evaluations, err := domain.FFT(coeffs, gokzg4844.BitReversedOrder)
+	require.NoError(t, err)
+	commitment, err := kzgmath.Commit(&kzgmath.Setup{G1: lagrange}, evaluations)
+	require.NoError(t, err)
+	expected, err := ctx.CommitMonomial(coeffs, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, expected, gokzg4844.KZGCommitment(gokzg4844.SerializeG1Point(commitment)))
+
+	// The full size gives back the setup of the context
+	full, err := ctx.TruncatedLagrangeG1Points(gokzg4844.ScalarsPerBlob, gokzg4844.NaturalOrder)
+	require.NoError(t, err)
+	compressed, err := hex.DecodeString(strings.TrimPrefix(ctx.TrustedSetup().SetupG1Lagrange[5], "0x"))
+	require.NoError(t, err)
+	serialized := gokzg4844.SerializeG1Point(full[5])
+	require.Equal(t, compressed, serialized[:])
+
+	_, err = ctx.TruncatedLagrangeG1Points(12, gokzg4844.BitReversedOrder)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidDomainSize)
+	_, err = ctx.TruncatedLagrangeG1Points(2*gokzg4844.ScalarsPerBlob, gokzg4844.BitReversedOrder)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidDomainSize)
+}
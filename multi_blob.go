@@ -0,0 +1,151 @@
+package gokzg4844
+
+import "github.com/crate-crypto/go-kzg-4844/internal/kzg"
+
+// BytesPerBlobData is the number of bytes of data that [MultiBlobCommitter] packs into each blob. Each scalar holds
+// 31 bytes of data, after a zero byte which ensures that it is canonical.
+const BytesPerBlobData = ScalarsPerBlob * (SerializedScalarSize - 1)
+
+// MultiBlobCommitter splits a stream of bytes into blobs, and computes the commitment and proof of each blob as soon
+// as it is full. This is what a rollup sequencer does to post a batch which is larger than one blob.
+//
+// The data is packed 31 bytes per scalar, in the last 31 bytes of each serialized scalar, so that every scalar is
+// less than [BlsModulus] whatever the data. The last blob is padded with zeros, so the length of the data must be
+// known to recover it exactly; it is recorded in the [MultiBlobBundle].
+//
+// A MultiBlobCommitter is not safe for concurrent use.
+type MultiBlobCommitter struct {
+	ctx           *Context
+	numGoRoutines int
+
+	// current is the blob being filled, which holds filled bytes of data.
+	current Blob
+	filled  int
+
+	bundle MultiBlobBundle
+	err    error
+}
+
+// MultiBlobBundle holds the blobs of a stream of data, in order, with their commitments and proofs. These can be
+// verified with [Context.VerifyBlobKZGProofBatch].
+type MultiBlobBundle struct {
+	Blobs       []Blob
+	Commitments []KZGCommitment
+	Proofs      []KZGProof
+	// DataLength is the number of bytes of data in the blobs, without the padding.
+	DataLength int
+}
+
+// NewMultiBlobCommitter returns an empty [MultiBlobCommitter], which commits to the blobs using this context. The
+// commitments and proofs are computed using up to numGoRoutines go-routines, as for [Context.BlobToKZGCommitment].
+func (c *Context) NewMultiBlobCommitter(numGoRoutines int) (*MultiBlobCommitter, error) {
+	if err := c.checkTypes(); err != nil {
+		return nil, err
+	}
+	return &MultiBlobCommitter{ctx: c, numGoRoutines: numGoRoutines}, nil
+}
+
+// Write adds data to the stream, committing to each blob that it fills. It implements [io.Writer].
+//
+// Once an error has been returned, it is returned by every subsequent call, and by [MultiBlobCommitter.Finish].
+func (m *MultiBlobCommitter) Write(data []byte) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+
+	written := 0
+	for written < len(data) {
+		scalar, offset := m.filled/(SerializedScalarSize-1), m.filled%(SerializedScalarSize-1)
+		start := scalar*SerializedScalarSize + 1 + offset
+		n := copy(m.current[start:(scalar+1)*SerializedScalarSize], data[written:])
+		m.filled += n
+		written += n
+
+		if m.filled == BytesPerBlobData {
+			if err := m.commit(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Finish pads and commits to the last blob if it holds any data, and returns the blobs of the stream with their
+// commitments and proofs. The committer is then reset, so that it can be used for another stream.
+//
+// An empty stream has no blobs.
+func (m *MultiBlobCommitter) Finish() (*MultiBlobBundle, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.filled != 0 {
+		if err := m.commit(); err != nil {
+			return nil, err
+		}
+	}
+
+	bundle := m.bundle
+	m.bundle = MultiBlobBundle{}
+	return &bundle, nil
+}
+
+// commit commits to the current blob, adds it to the bundle and starts a new one.
+func (m *MultiBlobCommitter) commit() error {
+	commitment, proof, err := m.ctx.commitAndOpenBlob(&m.current, m.numGoRoutines)
+	if err != nil {
+		m.err = err
+		return err
+	}
+
+	m.bundle.Blobs = append(m.bundle.Blobs, m.current)
+	m.bundle.Commitments = append(m.bundle.Commitments, commitment)
+	m.bundle.Proofs = append(m.bundle.Proofs, proof)
+	m.bundle.DataLength += m.filled
+	m.current = Blob{}
+	m.filled = 0
+	return nil
+}
+
+// commitAndOpenBlob returns the commitment to the blob and its proof, as [Context.BlobToKZGCommitment] and
+// [Context.ComputeBlobKZGProof] would, but deserializes the blob only once, as done by [Context.BuildSidecars].
+func (c *Context) commitAndOpenBlob(blob *Blob, numGoRoutines int) (KZGCommitment, KZGProof, error) {
+	if err := c.checkOpen(); err != nil {
+		return KZGCommitment{}, KZGProof{}, err
+	}
+	numGoRoutines = c.goRoutines(numGoRoutines)
+
+	polynomial, err := deserializeBlobBytesPar(blob[:], numGoRoutines)
+	if err != nil {
+		return KZGCommitment{}, KZGProof{}, err
+	}
+	commitment, err := kzg.Commit(polynomial, c.singleProofKey(), numGoRoutines)
+	if err != nil {
+		return KZGCommitment{}, KZGProof{}, err
+	}
+	serCommitment := KZGCommitment(SerializeG1Point(*commitment))
+
+	// The commitment was just computed, so it does not need to be checked to be in the subgroup
+	evaluationChallenge := computeChallenge(blob[:], serCommitment)
+	openingProof, err := kzg.Open(c.domain(), polynomial, evaluationChallenge, c.singleProofKey(), numGoRoutines)
+	if err != nil {
+		return KZGCommitment{}, KZGProof{}, err
+	}
+	return serCommitment, KZGProof(SerializeG1Point(openingProof.QuotientCommitment)), nil
+}
+
+// Data returns the data which was written to the [MultiBlobCommitter] that produced the bundle, without the padding.
+//
+// Returns [ErrInvalidDataLength] if DataLength is negative or larger than the blobs can hold, which can happen if the
+// bundle was decoded from untrusted data.
+func (b *MultiBlobBundle) Data() ([]byte, error) {
+	if b.DataLength < 0 || b.DataLength > len(b.Blobs)*BytesPerBlobData {
+		return nil, ErrInvalidDataLength
+	}
+	data := make([]byte, 0, len(b.Blobs)*BytesPerBlobData)
+	for i := range b.Blobs {
+		for j := 0; j < ScalarsPerBlob; j++ {
+			data = append(data, b.Blobs[i][j*SerializedScalarSize+1:(j+1)*SerializedScalarSize]...)
+		}
+	}
+	return data[:b.DataLength], nil
+}
@@ -0,0 +1,70 @@
+package gokzg4844_test
+
+import (
+	"math/rand"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiBlobCommitter(t *testing.T) {
+	committer, err := ctx.NewMultiBlobCommitter(NumGoRoutines)
+	require.NoError(t, err)
+
+	// Random data, which would not be canonical if packed 32 bytes per scalar
+	data := make([]byte, 2*gokzg4844.BytesPerBlobData+1000)
+	rand.New(rand.NewSource(51)).Read(data)
+	for i := range data {
+		data[i] |= 0x80
+	}
+	for written := 0; written < len(data); {
+		end := written + 40000
+		if end > len(data) {
+			end = len(data)
+		}
+		n, err := committer.Write(data[written:end])
+		require.NoError(t, err)
+		require.Equal(t, end-written, n)
+		written = end
+	}
+
+	bundle, err := committer.Finish()
+	require.NoError(t, err)
+	require.Len(t, bundle.Blobs, 3)
+	require.Equal(t, len(data), bundle.DataLength)
+	bundleData, err := bundle.Data()
+	require.NoError(t, err)
+	require.Equal(t, data, bundleData)
+	for i := range bundle.Blobs {
+		require.NoError(t, gokzg4844.ValidateBlobFast(&bundle.Blobs[i]))
+		commitment, err := ctx.BlobToKZGCommitment(&bundle.Blobs[i], NumGoRoutines)
+		require.NoError(t, err)
+		require.Equal(t, commitment, bundle.Commitments[i])
+	}
+	require.NoError(t, ctx.VerifyBlobKZGProofBatch(bundle.Blobs, bundle.Commitments, bundle.Proofs))
+
+	// The committer is reset by Finish
+	bundle, err = committer.Finish()
+	require.NoError(t, err)
+	require.Empty(t, bundle.Blobs)
+	bundleData, err = bundle.Data()
+	require.NoError(t, err)
+	require.Empty(t, bundleData)
+
+	_, err = committer.Write(data[:gokzg4844.BytesPerBlobData])
+	require.NoError(t, err)
+	bundle, err = committer.Finish()
+	require.NoError(t, err)
+	require.Len(t, bundle.Blobs, 1)
+	bundleData, err = bundle.Data()
+	require.NoError(t, err)
+	require.Equal(t, data[:gokzg4844.BytesPerBlobData], bundleData)
+
+	// The length of the data must fit in the blobs
+	for _, dataLength := range []int{-1, gokzg4844.BytesPerBlobData + 1} {
+		bundle.DataLength = dataLength
+		_, err = bundle.Data()
+		require.ErrorIs(t, err, gokzg4844.ErrInvalidDataLength)
+	}
+}
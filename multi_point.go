@@ -0,0 +1,180 @@
+package gokzg4844
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+	"github.com/crate-crypto/go-kzg-4844/poly"
+)
+
+// ComputeMultiPointKZGProof computes a single proof that the polynomial of the blob takes the returned values at
+// each of the input points. The proof is the commitment to the quotient q(X) = (p(X) - I(X)) / Z(X), where I
+// interpolates p at the points and Z vanishes at them; with a single point, this is the proof returned by
+// [Context.ComputeKZGProof]. The points may be roots of unity or any other scalars.
+//
+// The i'th value in the returned slice is the evaluation at the i'th point. The proof can be verified with
+// [Context.VerifyMultiPointKZGProof], which needs a G2 point of the trusted setup for each input point, so at most
+// 64 points can be opened with the trusted setup of the Ethereum KZG ceremony.
+//
+// numGoRoutines is used to configure the amount of concurrency needed. Setting this
+// value to a negative number or 0 will make it default to the value configured with
+// [WithNumGoRoutines], which itself defaults to the number of CPUs.
+//
+// It returns [ErrInvalidMultiPointCount] if there are no input points or more than the trusted setup supports, and
+// [ErrDuplicateInputPoint] if two of the input points are equal.
+func (c *Context) ComputeMultiPointKZGProof(blob *Blob, inputPointsBytes []Scalar, numGoRoutines int) (KZGProof, []Scalar, error) {
+	if err := c.checkTypes(); err != nil {
+		return KZGProof{}, nil, err
+	}
+
+	// 1. Deserialization
+	//
+	polynomial, err := DeserializeBlobPar(blob, c.goRoutines(numGoRoutines))
+	if err != nil {
+		return KZGProof{}, nil, err
+	}
+	inputPoints, err := c.deserializeMultiPoints(inputPointsBytes)
+	if err != nil {
+		return KZGProof{}, nil, err
+	}
+
+	// 2. Evaluate the polynomial
+	//
+	coeffs := c.domain().IfftFr(c.toNaturalOrder(polynomial))
+	claimedValues := make([]Scalar, len(inputPoints))
+	for i := range inputPoints {
+		claimedValues[i] = SerializeScalar(poly.Evaluate(coeffs, inputPoints[i]))
+	}
+
+	// 3. Commit to the quotient by the vanishing polynomial
+	//
+	commitment, err := c.openMultiPoint(coeffs, inputPoints, numGoRoutines)
+	if err != nil {
+		return KZGProof{}, nil, err
+	}
+	return KZGProof(SerializeG1Point(*commitment)), claimedValues, nil
+}
+
+// openMultiPoint returns the proof for the input points of the polynomial with the given coefficients, as computed
+// by [Context.ComputeMultiPointKZGProof]. The input points must be distinct.
+func (c *Context) openMultiPoint(coeffs, inputPoints []fr.Element, numGoRoutines int) (*bls12381.G1Affine, error) {
+	// The quotient by Z is found by dividing by each of its linear factors
+	// in turn. Since I has a lower degree than Z, it does not change the
+	// quotient, so it does not need to be subtracted.
+	quotient := coeffs
+	for i := range inputPoints {
+		quotient, _ = poly.DivideByLinear(quotient, inputPoints[i])
+	}
+	return c.commitCoefficients(quotient, numGoRoutines)
+}
+
+// VerifyMultiPointKZGProof verifies a proof returned by [Context.ComputeMultiPointKZGProof] that the polynomial with
+// the given commitment takes the i'th claimed value at the i'th input point, by checking that
+//
+//	e(commitment - [I(tau)]G1, [1]G2) == e(proof, [Z(tau)]G2)
+//
+// where I interpolates the claimed values at the input points, and Z vanishes at the input points.
+//
+// It returns [ErrMultiPointLengthCheck] if the number of input points and claimed values differ,
+// [ErrInvalidMultiPointCount] if there are no input points or more than the trusted setup supports,
+// [ErrDuplicateInputPoint] if two of the input points are equal, and [ErrProofInvalid] if the proof is not valid.
+func (c *Context) VerifyMultiPointKZGProof(commitment KZGCommitment, inputPointsBytes, claimedValuesBytes []Scalar, kzgProof KZGProof) error {
+	if err := c.checkTypes(); err != nil {
+		return err
+	}
+	if len(inputPointsBytes) != len(claimedValuesBytes) {
+		return ErrMultiPointLengthCheck
+	}
+
+	// 1. Deserialization
+	//
+	polyCommitment, err := DeserializeKZGCommitment(commitment)
+	if err != nil {
+		return err
+	}
+	quotientCommitment, err := DeserializeKZGProof(kzgProof)
+	if err != nil {
+		return err
+	}
+	inputPoints, err := c.deserializeMultiPoints(inputPointsBytes)
+	if err != nil {
+		return err
+	}
+	claimedValues := make([]fr.Element, len(claimedValuesBytes))
+	for i := range claimedValuesBytes {
+		claimedValues[i], err = DeserializeScalar(claimedValuesBytes[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	// 2. Commit to the interpolation polynomial in G1 and the vanishing
+	// polynomial in G2
+	//
+	interpolation, err := poly.Interpolate(inputPoints, claimedValues)
+	if err != nil {
+		return err
+	}
+	interpolationCommitment, err := c.commitCoefficients(interpolation, 0)
+	if err != nil {
+		return err
+	}
+	vanishing := kzg.VanishingPolynomialOfPoints(inputPoints)
+	var vanishingCommitment bls12381.G2Affine
+	if _, err := vanishingCommitment.MultiExp(c.setupG2()[:len(vanishing)], vanishing, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+
+	// 3. Check the pairing equation
+	//
+	var lhs, negProof bls12381.G1Affine
+	lhs.Sub(&polyCommitment, interpolationCommitment)
+	negProof.Neg(&quotientCommitment)
+	ok, err := bls12381.PairingCheck(
+		[]bls12381.G1Affine{lhs, negProof},
+		[]bls12381.G2Affine{c.openKey.GenG2, vanishingCommitment},
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrProofInvalid
+	}
+	return nil
+}
+
+// deserializeMultiPoints deserializes the input points of a multi-point proof, and checks that there are as many as
+// the trusted setup supports and that they are distinct.
+func (c *Context) deserializeMultiPoints(inputPointsBytes []Scalar) ([]fr.Element, error) {
+	// Z has one coefficient more than there are points, and each needs a G2 point
+	if len(inputPointsBytes) == 0 || len(inputPointsBytes) >= len(c.setupG2()) {
+		return nil, ErrInvalidMultiPointCount
+	}
+
+	inputPoints := make([]fr.Element, len(inputPointsBytes))
+	seen := make(map[Scalar]bool, len(inputPointsBytes))
+	for i := range inputPointsBytes {
+		var err error
+		inputPoints[i], err = DeserializeScalar(inputPointsBytes[i])
+		if err != nil {
+			return nil, err
+		}
+		// Canonical scalars are equal exactly when their encodings are
+		if seen[inputPointsBytes[i]] {
+			return nil, ErrDuplicateInputPoint
+		}
+		seen[inputPointsBytes[i]] = true
+	}
+	return inputPoints, nil
+}
+
+// commitCoefficients commits to the polynomial with the given coefficients, of which there must be at most the number
+// of scalars in a blob, by committing to its evaluations with the Lagrange points of the trusted setup. Unlike
+// [Context.CommitMonomial], this does not need the monomial points to be derived.
+func (c *Context) commitCoefficients(coeffs []fr.Element, numGoRoutines int) (*bls12381.G1Affine, error) {
+	padded := make([]fr.Element, c.domain().Cardinality)
+	copy(padded, coeffs)
+	evaluations := c.fromNaturalOrder(c.domain().FftFr(padded))
+	return kzg.Commit(evaluations, c.commitKey(), c.goRoutines(numGoRoutines))
+}
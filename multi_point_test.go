@@ -0,0 +1,79 @@
+package gokzg4844_test
+
+import (
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiPointKZGProof(t *testing.T) {
+	blob := GetRandBlob(41)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	domain, err := gokzg4844.NewDomain(gokzg4844.ScalarsPerBlob)
+	require.NoError(t, err)
+
+	// A single point gives the same proof as ComputeKZGProof
+	z := GetRandFieldElement(42)
+	proof, values, err := ctx.ComputeMultiPointKZGProof(blob, []gokzg4844.Scalar{z}, NumGoRoutines)
+	require.NoError(t, err)
+	expectedProof, expectedValue, err := ctx.ComputeKZGProof(blob, z, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, expectedProof, proof)
+	require.Equal(t, []gokzg4844.Scalar{expectedValue}, values)
+
+	// Points inside and outside of the domain, up to the limit of the setup
+	for _, numPoints := range []int{2, 5, 64} {
+		points := make([]gokzg4844.Scalar, numPoints)
+		for i := range points {
+			if i%2 == 0 {
+				points[i] = gokzg4844.SerializeScalar(domain.Root(uint64(3 * i)))
+			} else {
+				points[i] = GetRandFieldElement(int64(100 + i))
+			}
+		}
+		proof, values, err := ctx.ComputeMultiPointKZGProof(blob, points, NumGoRoutines)
+		require.NoError(t, err)
+		for i := range points[:2] {
+			_, y, err := ctx.ComputeKZGProof(blob, points[i], NumGoRoutines)
+			require.NoError(t, err)
+			require.Equal(t, y, values[i])
+		}
+		require.NoError(t, ctx.VerifyMultiPointKZGProof(commitment, points, values, proof))
+
+		// A wrong value or a subset of the points fails
+		wrongValues := append([]gokzg4844.Scalar(nil), values...)
+		wrongValues[numPoints-1] = values[0]
+		require.ErrorIs(t, ctx.VerifyMultiPointKZGProof(commitment, points, wrongValues, proof), gokzg4844.ErrProofInvalid)
+		require.ErrorIs(t, ctx.VerifyMultiPointKZGProof(commitment, points[1:], values[1:], proof), gokzg4844.ErrProofInvalid)
+	}
+}
+
+func TestMultiPointKZGProofInvalid(t *testing.T) {
+	blob := GetRandBlob(43)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	points := []gokzg4844.Scalar{GetRandFieldElement(44), GetRandFieldElement(45)}
+	proof, values, err := ctx.ComputeMultiPointKZGProof(blob, points, NumGoRoutines)
+	require.NoError(t, err)
+
+	tooMany := make([]gokzg4844.Scalar, 65)
+	for i := range tooMany {
+		tooMany[i] = GetRandFieldElement(int64(200 + i))
+	}
+	for _, invalid := range [][]gokzg4844.Scalar{nil, tooMany} {
+		_, _, err = ctx.ComputeMultiPointKZGProof(blob, invalid, NumGoRoutines)
+		require.ErrorIs(t, err, gokzg4844.ErrInvalidMultiPointCount)
+		require.ErrorIs(t, ctx.VerifyMultiPointKZGProof(commitment, invalid, make([]gokzg4844.Scalar, len(invalid)), proof), gokzg4844.ErrInvalidMultiPointCount)
+	}
+
+	duplicates := []gokzg4844.Scalar{points[0], points[1], points[0]}
+	_, _, err = ctx.ComputeMultiPointKZGProof(blob, duplicates, NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrDuplicateInputPoint)
+	require.ErrorIs(t, ctx.VerifyMultiPointKZGProof(commitment, duplicates, append(values, values[0]), proof), gokzg4844.ErrDuplicateInputPoint)
+
+	require.ErrorIs(t, ctx.VerifyMultiPointKZGProof(commitment, points, values[:1], proof), gokzg4844.ErrMultiPointLengthCheck)
+	nonCanonical := gokzg4844.Scalar{0xff}
+	require.ErrorIs(t, ctx.VerifyMultiPointKZGProof(commitment, points, []gokzg4844.Scalar{values[0], nonCanonical}, proof), gokzg4844.ErrNonCanonicalScalar)
+}
@@ -0,0 +1,73 @@
+package gokzg4844
+
+// The functions in this file are one-line equivalents of the methods of [Context], for small tools and scripts which
+// do not want to manage a context. They use the context returned by [SharedContext4096Secure], which is created the
+// first time that any of them is called; this takes about a second. Programs which create many proofs, or need any
+// option, should create their own context with [NewContext4096Secure] instead.
+
+// BlobToKZGCommitment is the same as [Context.BlobToKZGCommitment], using the shared default context and as many
+// go-routines as there are CPUs.
+func BlobToKZGCommitment(blob *Blob) (KZGCommitment, error) {
+	ctx, err := SharedContext4096Secure()
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	return ctx.BlobToKZGCommitment(blob, 0)
+}
+
+// ComputeBlobKZGProof is the same as [Context.ComputeBlobKZGProof], using the shared default context and as many
+// go-routines as there are CPUs.
+func ComputeBlobKZGProof(blob *Blob, blobCommitment KZGCommitment) (KZGProof, error) {
+	ctx, err := SharedContext4096Secure()
+	if err != nil {
+		return KZGProof{}, err
+	}
+	return ctx.ComputeBlobKZGProof(blob, blobCommitment, 0)
+}
+
+// ComputeKZGProof is the same as [Context.ComputeKZGProof], using the shared default context and as many go-routines
+// as there are CPUs.
+func ComputeKZGProof(blob *Blob, inputPointBytes Scalar) (KZGProof, Scalar, error) {
+	ctx, err := SharedContext4096Secure()
+	if err != nil {
+		return KZGProof{}, Scalar{}, err
+	}
+	return ctx.ComputeKZGProof(blob, inputPointBytes, 0)
+}
+
+// VerifyKZGProof is the same as [Context.VerifyKZGProof], using the shared default context.
+func VerifyKZGProof(blobCommitment KZGCommitment, inputPointBytes, claimedValueBytes Scalar, kzgProof KZGProof) error {
+	ctx, err := SharedContext4096Secure()
+	if err != nil {
+		return err
+	}
+	return ctx.VerifyKZGProof(blobCommitment, inputPointBytes, claimedValueBytes, kzgProof)
+}
+
+// VerifyBlobKZGProof is the same as [Context.VerifyBlobKZGProof], using the shared default context.
+func VerifyBlobKZGProof(blob *Blob, blobCommitment KZGCommitment, kzgProof KZGProof) error {
+	ctx, err := SharedContext4096Secure()
+	if err != nil {
+		return err
+	}
+	return ctx.VerifyBlobKZGProof(blob, blobCommitment, kzgProof)
+}
+
+// VerifyBlobKZGProofBatch is the same as [Context.VerifyBlobKZGProofBatch], using the shared default context.
+func VerifyBlobKZGProofBatch(blobs []Blob, polynomialCommitments []KZGCommitment, kzgProofs []KZGProof) error {
+	ctx, err := SharedContext4096Secure()
+	if err != nil {
+		return err
+	}
+	return ctx.VerifyBlobKZGProofBatch(blobs, polynomialCommitments, kzgProofs)
+}
+
+// SimulatePointEvaluationPrecompile is the same as [Context.PointEvaluationPrecompile], using the shared default
+// context.
+func SimulatePointEvaluationPrecompile(input []byte) ([64]byte, error) {
+	ctx, err := SharedContext4096Secure()
+	if err != nil {
+		return [64]byte{}, err
+	}
+	return ctx.PointEvaluationPrecompile(input)
+}
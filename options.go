@@ -0,0 +1,262 @@
+package gokzg4844
+
+import "io"
+
+// Option configures a [Context] at construction time.
+//
+// Options are passed to the context constructors, for example [NewContext4096Secure] and [NewContext4096]. This allows
+// us to add new configuration parameters without changing the signature of the constructors.
+type Option func(*config)
+
+// config holds the settings which can be modified by passing an [Option] to a context constructor.
+type config struct {
+	// numGoRoutines is the amount of concurrency used by methods which are
+	// called with a non-positive numGoRoutines argument.
+	//
+	// A value of 0 means that we use as many go-routines as there are CPUs.
+	numGoRoutines int
+
+	// order is the order in which the evaluations of a blob are stored.
+	// The roots of unity and the Lagrange SRS are permuted accordingly.
+	order Permutation
+
+	// batchRandomness is the source of the randomness used in batch verification.
+	// A nil value means that crypto/rand is used.
+	batchRandomness io.Reader
+
+	// fiatShamirBatch denotes whether the challenge used in batch verification
+	// is derived from a hash of the proofs, instead of being sampled randomly.
+	fiatShamirBatch bool
+
+	// precomputeWindowBits is the window size of the fixed base table used
+	// to compute commitments. A value of 0 means that no table is created.
+	precomputeWindowBits uint
+
+	// domain is a precomputed domain to use instead of computing a new one.
+	// A nil value means that a new domain is computed.
+	domain *Domain
+
+	// eagerMonomial denotes whether the monomial form of the G1 points is
+	// derived when the context is created, instead of when it is first needed.
+	eagerMonomial bool
+
+	// setupChecks selects when the points of the trusted setup are checked
+	// to be in the correct subgroup.
+	setupChecks SetupChecks
+
+	// lazyInit denotes whether the work which only depends on the trusted
+	// setup is deferred until it is first needed.
+	lazyInit bool
+	// lowMemoryDomain denotes whether the inverses of the roots of unity are
+	// computed when needed, instead of being stored in the domain.
+	lowMemoryDomain bool
+	// latencyMode denotes whether the single blob proving methods run their
+	// multi exponentiations on a dedicated pool of workers.
+	latencyMode bool
+
+	// mlockPrecompute denotes whether a memory-mapped precomputed table is
+	// locked into memory.
+	mlockPrecompute bool
+
+	// accounting is called with the usage of each call which takes a
+	// context.Context. A nil value means that usage is not recorded.
+	accounting AccountingHook
+}
+
+// newConfig returns the default config with all of the options applied in order.
+func newConfig(opts []Option) config {
+	cfg := config{
+		numGoRoutines: 0,
+		order:         BitReversedOrder,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithNumGoRoutines sets the default amount of concurrency that the [Context] will use.
+//
+// Methods which take a numGoRoutines argument will use this value when they are given a value that is 0 or negative.
+// It also bounds the go-routines used to decompress and check the points of the trusted setup when the context is
+// created, and those used by the multi-exponentiations which fold the proofs of a batch verification.
+// If this option is not supplied, or numGoRoutines is not positive, the default is to use as many go-routines as there
+// are CPUs.
+func WithNumGoRoutines(numGoRoutines int) Option {
+	return func(cfg *config) {
+		cfg.numGoRoutines = numGoRoutines
+	}
+}
+
+// WithBitReversalPermutation sets whether the evaluations of a blob are interpreted in bit-reversed order.
+//
+// The default is true, which is what the specs mandate. Setting this to false means that the i'th evaluation of a
+// blob is the evaluation at the i'th root of unity in natural order.
+//
+// This is equivalent to [WithEvaluationOrder] with [BitReversedOrder] or [NaturalOrder].
+//
+// Note: Disabling the bit-reversal permutation produces commitments and proofs which are not compatible with
+// EIP-4844. It is intended for experimentation only.
+func WithBitReversalPermutation(enabled bool) Option {
+	if enabled {
+		return WithEvaluationOrder(BitReversedOrder)
+	}
+	return WithEvaluationOrder(NaturalOrder)
+}
+
+// WithEvaluationOrder sets the order in which the evaluations of a blob are stored.
+//
+// The default is [BitReversedOrder], which is what the specs mandate. The context constructors return
+// [ErrInvalidPermutation] if the permutation is not a bijection.
+//
+// Note: Any order other than [BitReversedOrder] produces commitments and proofs which are not compatible with
+// EIP-4844. It is intended for experimentation only.
+func WithEvaluationOrder(perm Permutation) Option {
+	return func(cfg *config) {
+		cfg.order = perm
+	}
+}
+
+// WithPrecompute enables a precomputed table of multiples of the trusted setup points, which speeds up the
+// computation of commitments and proofs at the cost of memory.
+//
+// windowBits is the size of the windows used by the table. Larger windows use less time per commitment but
+// more memory: the table uses 4096 * (255/windowBits + 1) * 96 bytes, for example about 8.3MB when windowBits is 12.
+// Values between 8 and 12 are a good choice. Setting windowBits to 0 disables the table, which is the default.
+//
+// The context constructors return an error if windowBits is not 0 and is not in the range [2, 16].
+func WithPrecompute(windowBits uint) Option {
+	return func(cfg *config) {
+		cfg.precomputeWindowBits = windowBits
+	}
+}
+
+// WithBatchRandomness sets the source of the randomness that is used to combine proofs in batch verification, for
+// example in [Context.VerifyBlobKZGProofBatch].
+//
+// By default, the randomness is read from crypto/rand. Supplying a deterministic reader makes batch verification
+// reproducible, which is useful for tests and audits. 64 bytes are read for each batch.
+//
+// Note: The soundness of batch verification relies on the randomness being unpredictable to whoever created the
+// proofs. A deterministic reader should only be used when this is not a concern. The reader must be safe for
+// concurrent use if the context is used from multiple go-routines.
+func WithBatchRandomness(randomness io.Reader) Option {
+	return func(cfg *config) {
+		cfg.batchRandomness = randomness
+	}
+}
+
+// WithFiatShamirBatchChallenge sets whether the challenge that is used to combine proofs in batch verification is
+// derived from a hash of the commitments, proofs, input points and claimed values, as done in
+// [verify_kzg_proof_batch], instead of being sampled randomly.
+//
+// This makes batch verification deterministic and removes its dependency on a source of entropy, which is useful in
+// sandboxed environments. When enabled, any reader set with [WithBatchRandomness] is ignored.
+//
+// [verify_kzg_proof_batch]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#verify_kzg_proof_batch
+func WithFiatShamirBatchChallenge(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.fiatShamirBatch = enabled
+	}
+}
+
+// WithDomain makes the context use a domain which has already been computed, instead of computing its own.
+//
+// This lets processes which create many contexts, such as test suites or registries of networks, compute the domain
+// only once. The context copies the domain before applying its evaluation order, so the domain can be shared between
+// contexts with different options.
+//
+// The context constructors return [ErrInvalidDomainSize] if the size of the domain is not the number of scalars in a
+// blob of the preset of the context, which is [ScalarsPerBlob] for the [MainnetPreset].
+func WithDomain(domain *Domain) Option {
+	return func(cfg *config) {
+		cfg.domain = domain
+	}
+}
+
+// WithEagerMonomialSRS sets whether the monomial form of the G1 points of the trusted setup is derived when the
+// context is created.
+//
+// The trusted setup only holds the G1 points in Lagrange form. The monomial points, which are used by
+// [Context.CommitMonomial] and [Context.MonomialG1Points], are derived from them, which takes a few seconds. By
+// default, this happens the first time that they are needed; enabling this option moves the cost to the context
+// constructor instead, so that it is not paid while serving a request.
+func WithEagerMonomialSRS(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.eagerMonomial = enabled
+	}
+}
+
+// WithSetupChecks selects when [NewContext4096] checks that the points of the trusted setup are in the correct
+// subgroup, as done by [CheckTrustedSetupIsWellFormed].
+//
+// The default is [SetupChecksUnknown], which skips the checks for the setup from the Ethereum KZG ceremony, since that
+// setup is known to be well-formed. The checks take about four times as long as the rest of the construction.
+//
+// Contexts created from other setups were not checked before this option was added; use [SetupChecksNever] to keep
+// that behaviour for a setup from a trusted source.
+func WithSetupChecks(checks SetupChecks) Option {
+	return func(cfg *config) {
+		cfg.setupChecks = checks
+	}
+}
+
+// WithLatencyMode makes the single blob proving methods, [Context.BlobToKZGCommitment], [Context.ComputeBlobKZGProof]
+// and [Context.ComputeKZGProof], optimize for the latency of each call rather than for throughput. This is intended
+// for a proposer computing the proofs for its own blobs, where the time until the proof is ready matters most.
+//
+// The context starts a dedicated pool of workers, each locked to its own OS thread, and the multi exponentiation of
+// each call is split over all of them. The pool has as many workers as configured with [WithNumGoRoutines], which
+// defaults to the number of CPUs, and the numGoRoutines argument of those methods then only applies to
+// deserialization. If the workers are busy with another call, the work is given to new go-routines instead of
+// waiting for them. All other methods, such as batch proving and verification, are unaffected.
+func WithLatencyMode() Option {
+	return func(cfg *config) {
+		cfg.latencyMode = true
+	}
+}
+
+// WithMlockPrecompute sets whether the precomputed table loaded by [ReadContextFromFileMmap] is locked into memory, so
+// that its pages are never swapped out and a commitment never waits for them to be read back from disk. Locking the
+// table reads all of it into memory when it is loaded.
+//
+// This usually needs the RLIMIT_MEMLOCK limit to be raised, and is only supported on Linux and macOS.
+func WithMlockPrecompute(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.mlockPrecompute = enabled
+	}
+}
+
+// WithLazyInit sets whether the context defers the expensive part of its construction until it is first needed:
+// parsing the G1 points of the trusted setup, which takes most of the time, computing the domain, applying the
+// evaluation order and computing the table configured with [WithPrecompute]. This suits tools which only verify a
+// single proof with [Context.VerifyKZGProof], which needs none of them. Call [Context.Warm] to do the work at a time of
+// your choosing.
+//
+// Deferring the parsing of the setup only applies to the setup from the Ethereum KZG ceremony, identified by
+// [MainnetSetupDigest], such as the one embedded in this library, since its points are known to be well-formed. The
+// points of any other setup are parsed, and checked as configured with [WithSetupChecks], by the context
+// constructor, so that an error in them is returned straight away; for such a setup, only the domain, the evaluation
+// order and the table are deferred, and creating the context takes most of the time that it would without this
+// option. Any error in the options is also returned by the constructor.
+func WithLazyInit(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.lazyInit = enabled
+	}
+}
+
+// WithLowMemoryDomain sets whether the context stores the inverses of the roots of unity of its domain, which take
+// as much memory as the roots themselves: 128KB for a domain of [ScalarsPerBlob] roots.
+//
+// The inverses are only needed when computing a proof at a point which is a root of unity, with
+// [Context.ComputeKZGProof]. When this is enabled, the inverse that such a proof needs is instead computed with the
+// batch inversion that the proof does anyway, which costs a few multiplications.
+//
+// This only makes the context's own copy of the domain smaller. The domain that it is copied from, which is either
+// the one given to [WithDomain] or the one that is cached for the process, still holds its inverses, so the memory
+// saved is that of the copy in each context created with this option.
+func WithLowMemoryDomain(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.lowMemoryDomain = enabled
+	}
+}
@@ -0,0 +1,119 @@
+package gokzg4844
+
+import (
+	"context"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// PairingInputs holds the pairs of points of a pairing check: the check passes if the product of e(G1[i], G2[i])
+// over all i is the identity.
+//
+// This lets systems which verify other pairing-based claims, such as BLS signatures, combine them with KZG proofs into
+// a single multi-pairing. See [Context.PrepareVerification] and [ExecutePairingCheck].
+type PairingInputs struct {
+	G1 []bls12381.G1Affine
+	G2 []bls12381.G2Affine
+}
+
+// PrepareVerification does all of the work of [Context.VerifyBlobKZGProofBatch] except for the final pairing check,
+// and returns the folded pairs of points which that check would use. The proofs are valid if and only if the
+// pairing check on the returned inputs passes.
+//
+// The proofs are folded with the same challenge as [Context.VerifyBlobKZGProofBatch] would use, so the options
+// [WithBatchRandomness] and [WithFiatShamirBatchChallenge] apply.
+//
+// Like [Context.VerifyBlobKZGProofBatch], an invalid element is reported with a [*BatchVerificationError]. Since no
+// pairing check is done, the error never wraps [ErrProofInvalid].
+func (c *Context) PrepareVerification(blobs []Blob, polynomialCommitments []KZGCommitment, kzgProofs []KZGProof) (PairingInputs, error) {
+	if err := c.checkTypes(); err != nil {
+		return PairingInputs{}, err
+	}
+
+	// 1. Check that all components in the batch have the same size
+	//
+	if len(polynomialCommitments) != len(blobs) || len(kzgProofs) != len(blobs) {
+		return PairingInputs{}, ErrBatchLengthCheck
+	}
+
+	prof := startProfile(context.Background(), "prepare_verification", len(blobs))
+	defer prof.end()
+
+	// 2. Collect opening proofs
+	//
+	prof.phase("prepare")
+	blobBytes := make([][]byte, len(blobs))
+	for i := range blobs {
+		blobBytes[i] = blobs[i][:]
+	}
+	commitments, openingProofs, err := c.blobOpeningProofs(context.Background(), blobBytes, polynomialCommitments, kzgProofs)
+	if err != nil {
+		return PairingInputs{}, err
+	}
+
+	// 3. Fold the opening proofs
+	//
+	prof.phase("fold")
+	challenge, err := c.batchChallenge(openingProofs, polynomialCommitments, kzgProofs)
+	if err != nil {
+		return PairingInputs{}, err
+	}
+	g1Points, g2Points, err := kzg.FoldMultiPoints(commitments, openingProofs, c.openKey, challenge, c.goRoutines(0))
+	if err != nil {
+		return PairingInputs{}, err
+	}
+
+	return PairingInputs{G1: g1Points[:], G2: g2Points[:]}, nil
+}
+
+// ExecutePairingCheck runs the pairing checks of all of the inputs as a single multi-pairing. It returns nil if all
+// of the checks pass, and [ErrProofInvalid] if at least one of them fails.
+//
+// Before the inputs are combined, the G1 points of each of them, except the first, are multiplied by a fresh random
+// scalar. Without this, two checks which both fail could cancel each other out.
+//
+// Returns [ErrInvalidPairingInputs] if one of the inputs does not have the same number of G1 and G2 points.
+func ExecutePairingCheck(inputs ...PairingInputs) error {
+	var (
+		g1Points []bls12381.G1Affine
+		g2Points []bls12381.G2Affine
+	)
+	for i, input := range inputs {
+		if len(input.G1) != len(input.G2) {
+			return ErrInvalidPairingInputs
+		}
+
+		if i == 0 {
+			g1Points = append(g1Points, input.G1...)
+		} else {
+			var r fr.Element
+			if _, err := r.SetRandom(); err != nil {
+				return err
+			}
+			var rBI big.Int
+			r.BigInt(&rBI)
+			for j := range input.G1 {
+				var scaled bls12381.G1Affine
+				scaled.ScalarMultiplication(&input.G1[j], &rBI)
+				g1Points = append(g1Points, scaled)
+			}
+		}
+		g2Points = append(g2Points, input.G2...)
+	}
+
+	if len(g1Points) == 0 {
+		return nil
+	}
+
+	check, err := bls12381.PairingCheck(g1Points, g2Points)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrProofInvalid
+	}
+	return nil
+}
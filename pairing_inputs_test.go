@@ -0,0 +1,70 @@
+package gokzg4844_test
+
+import (
+	"math/big"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareVerification(t *testing.T) {
+	const batchSize = 3
+	blobs := make([]gokzg4844.Blob, batchSize)
+	commitments := make([]gokzg4844.KZGCommitment, batchSize)
+	proofs := make([]gokzg4844.KZGProof, batchSize)
+	for i := range blobs {
+		blobs[i] = *GetRandBlob(int64(i))
+		commitment, err := ctx.BlobToKZGCommitment(&blobs[i], NumGoRoutines)
+		require.NoError(t, err)
+		proof, err := ctx.ComputeBlobKZGProof(&blobs[i], commitment, NumGoRoutines)
+		require.NoError(t, err)
+		commitments[i] = commitment
+		proofs[i] = proof
+	}
+
+	valid, err := ctx.PrepareVerification(blobs, commitments, proofs)
+	require.NoError(t, err)
+	require.NoError(t, gokzg4844.ExecutePairingCheck(valid))
+
+	// A single proof has the same shape as a batch
+	single, err := ctx.PrepareVerification(blobs[:1], commitments[:1], proofs[:1])
+	require.NoError(t, err)
+	require.Len(t, single.G1, len(valid.G1))
+	require.NoError(t, gokzg4844.ExecutePairingCheck(valid, single))
+
+	// A proof for a different blob
+	proofs[1] = proofs[0]
+	invalid, err := ctx.PrepareVerification(blobs, commitments, proofs)
+	require.NoError(t, err)
+	require.ErrorIs(t, gokzg4844.ExecutePairingCheck(invalid), gokzg4844.ErrProofInvalid)
+	require.ErrorIs(t, gokzg4844.ExecutePairingCheck(valid, invalid), gokzg4844.ErrProofInvalid)
+
+	_, err = ctx.PrepareVerification(blobs, commitments[:1], proofs)
+	require.ErrorIs(t, err, gokzg4844.ErrBatchLengthCheck)
+}
+
+func TestExecutePairingCheck(t *testing.T) {
+	_, _, genG1, genG2 := bls12381.Generators()
+	var threeG1, negThreeG1, negG1 bls12381.G1Affine
+	threeG1.ScalarMultiplication(&genG1, big.NewInt(3))
+	negThreeG1.Neg(&threeG1)
+	negG1.Neg(&genG1)
+
+	// e([3]G1, G2) * e([-3]G1, G2) is the identity
+	external := gokzg4844.PairingInputs{
+		G1: []bls12381.G1Affine{threeG1, negThreeG1},
+		G2: []bls12381.G2Affine{genG2, genG2},
+	}
+	require.NoError(t, gokzg4844.ExecutePairingCheck(external))
+	require.NoError(t, gokzg4844.ExecutePairingCheck())
+
+	// Two checks which fail on their own must not cancel each other out
+	first := gokzg4844.PairingInputs{G1: []bls12381.G1Affine{genG1}, G2: []bls12381.G2Affine{genG2}}
+	second := gokzg4844.PairingInputs{G1: []bls12381.G1Affine{negG1}, G2: []bls12381.G2Affine{genG2}}
+	require.ErrorIs(t, gokzg4844.ExecutePairingCheck(first, second), gokzg4844.ErrProofInvalid)
+
+	mismatched := gokzg4844.PairingInputs{G1: []bls12381.G1Affine{genG1}}
+	require.ErrorIs(t, gokzg4844.ExecutePairingCheck(external, mismatched), gokzg4844.ErrInvalidPairingInputs)
+}
@@ -0,0 +1,107 @@
+package gokzg4844
+
+import (
+	"crypto/sha256"
+	"encoding"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// PendingVerification is a blob proof verification which is waiting for the commitment to the blob.
+//
+// In optimistic flows, the blob, its proof and the versioned hash of its commitment are known before the
+// commitment itself. [Context.NewPendingVerification] does all of the work that does not depend on the
+// commitment: deserializing the blob and the proof, and hashing the blob into the Fiat-Shamir transcript.
+// [PendingVerification.Complete] then only needs to finish the challenge, evaluate the polynomial and check the
+// pairing.
+type PendingVerification struct {
+	ctx *Context
+
+	versionedHash      [32]byte
+	polynomial         kzg.Polynomial
+	quotientCommitment bls12381.G1Affine
+
+	// transcriptState is the marshaled state of the Fiat-Shamir hasher, after
+	// it has absorbed everything that comes before the commitment.
+	transcriptState []byte
+}
+
+// NewPendingVerification starts the verification of a blob proof, for a blob whose commitment has the given
+// versioned hash.
+//
+// The blob is not retained, so it can be modified once this method returns.
+//
+// Returns an error if the blob or the proof cannot be deserialized.
+func (c *Context) NewPendingVerification(blob *Blob, kzgProof KZGProof, versionedHash [32]byte) (*PendingVerification, error) {
+	if err := c.checkTypes(); err != nil {
+		return nil, err
+	}
+	polynomial, err := DeserializeBlob(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	quotientCommitment, err := DeserializeKZGProof(kzgProof)
+	if err != nil {
+		return nil, err
+	}
+
+	transcriptState, err := newChallengeHasher(blob[:]).(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PendingVerification{
+		ctx:                c,
+		versionedHash:      versionedHash,
+		polynomial:         polynomial,
+		quotientCommitment: quotientCommitment,
+		transcriptState:    transcriptState,
+	}, nil
+}
+
+// Complete finishes the verification using the commitment to the blob. This is equivalent to calling
+// [Context.VerifyBlobKZGProof] with the blob, the commitment and the proof.
+//
+// Returns [ErrVersionedHashMismatch] if the commitment does not match the versioned hash that the verification was
+// started with.
+//
+// Complete may be called more than once, for example if a mismatching commitment was received first.
+func (p *PendingVerification) Complete(blobCommitment KZGCommitment) error {
+	if err := p.ctx.checkOpen(); err != nil {
+		return err
+	}
+	// 1. Check the commitment against the versioned hash
+	if !VersionedHash(p.versionedHash).Matches(blobCommitment) {
+		return ErrVersionedHashMismatch
+	}
+
+	polynomialCommitment, err := DeserializeKZGCommitment(blobCommitment)
+	if err != nil {
+		return err
+	}
+
+	// 2. Compute the evaluation challenge
+	h := sha256.New()
+	err = h.(encoding.BinaryUnmarshaler).UnmarshalBinary(p.transcriptState)
+	if err != nil {
+		return err
+	}
+	evaluationChallenge := finalizeChallenge(h, blobCommitment)
+
+	// 3. Compute output point/ claimed value
+	outputPoint, err := p.ctx.domain().EvaluateLagrangePolynomial(p.polynomial, evaluationChallenge)
+	if err != nil {
+		return err
+	}
+
+	// 4. Verify opening proof
+	openingProof := kzg.OpeningProof{
+		QuotientCommitment: p.quotientCommitment,
+		InputPoint:         evaluationChallenge,
+		ClaimedValue:       *outputPoint,
+	}
+
+	return kzg.Verify(&polynomialCommitment, &openingProof, p.ctx.openKey)
+}
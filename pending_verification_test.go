@@ -0,0 +1,38 @@
+package gokzg4844_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingVerification(t *testing.T) {
+	blob := GetRandBlob(123)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	proof, err := ctx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+
+	versionedHash := sha256.Sum256(commitment[:])
+	versionedHash[0] = 0x01
+
+	pending, err := ctx.NewPendingVerification(blob, proof, versionedHash)
+	require.NoError(t, err)
+
+	// A commitment which does not match the versioned hash should be rejected
+	otherCommitment, err := ctx.BlobToKZGCommitment(GetRandBlob(456), NumGoRoutines)
+	require.NoError(t, err)
+	err = pending.Complete(otherCommitment)
+	require.ErrorIs(t, err, gokzg4844.ErrVersionedHashMismatch)
+
+	err = pending.Complete(commitment)
+	require.NoError(t, err)
+
+	// The proof is not valid for a different blob with the same commitment
+	pending, err = ctx.NewPendingVerification(GetRandBlob(456), proof, versionedHash)
+	require.NoError(t, err)
+	err = pending.Complete(commitment)
+	require.Error(t, err)
+}
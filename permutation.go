@@ -0,0 +1,107 @@
+package gokzg4844
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// Permutation describes the order in which the evaluations of a polynomial are stored in a blob.
+//
+// The i'th evaluation in a blob is the evaluation at the root of unity w^SourceIndex(i, n), where w is the primitive
+// n'th root of unity of the domain. The roots of unity and the Lagrange form of the trusted setup are permuted
+// accordingly when the [Context] is created, so the rest of the code does not depend on the order.
+//
+// The specs use [BitReversedOrder]. Other orderings are intended for experimentation only and produce commitments
+// and proofs which are not compatible with EIP-4844.
+type Permutation interface {
+	// SourceIndex returns the index, in natural order, of the element that is moved to position i of a list
+	// with n elements, where n is a power of two.
+	//
+	// For every n, this must be a bijection on [0, n).
+	SourceIndex(i, n int) int
+}
+
+var (
+	// NaturalOrder is the identity permutation: the i'th evaluation is the evaluation at w^i.
+	NaturalOrder Permutation = naturalOrder{}
+
+	// BitReversedOrder is the [bit_reversal_permutation] used by the specs.
+	//
+	// [bit_reversal_permutation]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#bit_reversal_permutation
+	BitReversedOrder Permutation = bitReversedOrder{}
+)
+
+type naturalOrder struct{}
+
+func (naturalOrder) SourceIndex(i, _ int) int {
+	return i
+}
+
+type bitReversedOrder struct{}
+
+func (bitReversedOrder) SourceIndex(i, n int) int {
+	// Reverse i as a log2(n)-bit integer
+	shiftCorrection := 64 - bits.TrailingZeros64(uint64(n))
+	return int(bits.Reverse64(uint64(i)) >> shiftCorrection)
+}
+
+// permuteSetup applies the permutation with the given source indices, as returned by [permutationSourceIndices], to
+// the roots of unity of the domain and to the Lagrange form of the trusted setup.
+func permuteSetup(sourceIndices []int, domain *kzg.Domain, commitKey *kzg.CommitKey) {
+	domain.PermuteRoots(sourceIndices)
+	commitKey.G1 = permute(commitKey.G1, sourceIndices)
+	// The table depends on the order of the points, so it would no longer be valid
+	commitKey.FixedBase = nil
+}
+
+// permutationSourceIndices returns perm.SourceIndex(i, n) for each i in [0, n).
+//
+// Returns [ErrInvalidPermutation] if perm is nil or is not a bijection.
+func permutationSourceIndices(perm Permutation, n int) ([]int, error) {
+	if perm == nil {
+		return nil, ErrInvalidPermutation
+	}
+
+	sourceIndices := make([]int, n)
+	seen := make([]bool, n)
+	for i := 0; i < n; i++ {
+		j := perm.SourceIndex(i, n)
+		if j < 0 || j >= n || seen[j] {
+			return nil, ErrInvalidPermutation
+		}
+		seen[j] = true
+		sourceIndices[i] = j
+	}
+	return sourceIndices, nil
+}
+
+// permute returns a list where the i'th element is list[sourceIndices[i]].
+func permute[K any](list []K, sourceIndices []int) []K {
+	permuted := make([]K, len(list))
+	for i, j := range sourceIndices {
+		permuted[i] = list[j]
+	}
+	return permuted
+}
+
+// toNaturalOrder returns the evaluations in natural order, given evaluations in the order of the context.
+func (c *Context) toNaturalOrder(evaluations []fr.Element) []fr.Element {
+	n := len(evaluations)
+	natural := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		natural[c.order.SourceIndex(i, n)] = evaluations[i]
+	}
+	return natural
+}
+
+// fromNaturalOrder returns the evaluations in the order of the context, given evaluations in natural order.
+func (c *Context) fromNaturalOrder(natural []fr.Element) []fr.Element {
+	n := len(natural)
+	evaluations := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		evaluations[i] = natural[c.order.SourceIndex(i, n)]
+	}
+	return evaluations
+}
@@ -0,0 +1,22 @@
+package gokzg4844
+
+import (
+	"testing"
+
+	"github.com/crate-crypto/go-kzg-4844/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitReversedOrderMatchesBitReverse(t *testing.T) {
+	for _, n := range []int{1, 2, 8, 4096} {
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		utils.BitReverse(indices)
+
+		for i := 0; i < n; i++ {
+			require.Equal(t, indices[i], BitReversedOrder.SourceIndex(i, n))
+		}
+	}
+}
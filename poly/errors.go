@@ -0,0 +1,13 @@
+package poly
+
+import "errors"
+
+var (
+	// ErrInvalidSize is returned when a number of evaluations is not the size of a domain of roots of unity, which is
+	// a power of two of at most 2^32, or is less than the number of coefficients of the polynomial.
+	ErrInvalidSize = errors.New("size is not a power of two, or is less than the number of coefficients")
+	// ErrLengthMismatch is returned when there is not one value for each of the points of an interpolation.
+	ErrLengthMismatch = errors.New("number of points and values differ")
+	// ErrDuplicatePoint is returned when the points of an interpolation are not distinct.
+	ErrDuplicatePoint = errors.New("points are not distinct")
+)
@@ -0,0 +1,124 @@
+package poly
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// Interpolator finds and evaluates the polynomials which take given values at a fixed set of distinct points, which
+// need not be roots of unity.
+//
+// It holds the barycentric weights of the points, w_i = 1 / prod_(j != i) (x_i - x_j), which take quadratic time to
+// compute but only depend on the points. Each evaluation then takes linear time, so an Interpolator should be reused
+// for all of the polynomials over the same points. It is safe for concurrent use.
+type Interpolator struct {
+	points  []fr.Element
+	weights []fr.Element
+}
+
+// NewInterpolator returns an Interpolator for the given points.
+//
+// Returns [ErrDuplicatePoint] if the points are not distinct.
+func NewInterpolator(points []fr.Element) (*Interpolator, error) {
+	denominators := make([]fr.Element, len(points))
+	for i := range points {
+		denominators[i].SetOne()
+		for j := range points {
+			if i == j {
+				continue
+			}
+			var difference fr.Element
+			difference.Sub(&points[i], &points[j])
+			if difference.IsZero() {
+				return nil, ErrDuplicatePoint
+			}
+			denominators[i].Mul(&denominators[i], &difference)
+		}
+	}
+
+	return &Interpolator{
+		points:  append([]fr.Element(nil), points...),
+		weights: fr.BatchInvert(denominators),
+	}, nil
+}
+
+// Evaluate returns p(z), where p is the polynomial of degree less than the number of points which takes the i'th
+// value at the i'th point. It uses the barycentric formula
+//
+//	p(z) = Z(z) * sum_i w_i * y_i / (z - x_i)
+//
+// where Z is the polynomial which vanishes on the points, without finding the coefficients of p.
+//
+// Returns [ErrLengthMismatch] if there is not one value for each point.
+func (ip *Interpolator) Evaluate(values []fr.Element, z fr.Element) (fr.Element, error) {
+	if len(values) != len(ip.points) {
+		return fr.Element{}, ErrLengthMismatch
+	}
+
+	differences := make([]fr.Element, len(ip.points))
+	vanishing := fr.One()
+	for i := range ip.points {
+		differences[i].Sub(&z, &ip.points[i])
+		// The formula divides by zero at the points themselves
+		if differences[i].IsZero() {
+			return values[i], nil
+		}
+		vanishing.Mul(&vanishing, &differences[i])
+	}
+	inverses := fr.BatchInvert(differences)
+
+	var result fr.Element
+	for i := range values {
+		var term fr.Element
+		term.Mul(&ip.weights[i], &values[i])
+		term.Mul(&term, &inverses[i])
+		result.Add(&result, &term)
+	}
+	result.Mul(&result, &vanishing)
+	return result, nil
+}
+
+// Coefficients returns the coefficients of the polynomial of degree less than the number of points which takes the
+// i'th value at the i'th point. There is one coefficient for each point; the i'th is the coefficient of X^i.
+//
+// The polynomial is sum_i w_i * y_i * Z(X) / (X - x_i), which takes quadratic time to find.
+//
+// Returns [ErrLengthMismatch] if there is not one value for each point.
+func (ip *Interpolator) Coefficients(values []fr.Element) ([]fr.Element, error) {
+	if len(values) != len(ip.points) {
+		return nil, ErrLengthMismatch
+	}
+
+	vanishing := kzg.VanishingPolynomialOfPoints(ip.points)
+	coeffs := make([]fr.Element, len(ip.points))
+	for i := range ip.points {
+		var scale fr.Element
+		scale.Mul(&ip.weights[i], &values[i])
+		if scale.IsZero() {
+			continue
+		}
+		quotient, _ := DivideByLinear(vanishing, ip.points[i])
+		for j := range quotient {
+			var term fr.Element
+			term.Mul(&quotient[j], &scale)
+			coeffs[j].Add(&coeffs[j], &term)
+		}
+	}
+	return coeffs, nil
+}
+
+// Interpolate returns the coefficients of the polynomial of degree less than the number of points which takes the
+// i'th value at the i'th point. It is a shorthand for [NewInterpolator] followed by [Interpolator.Coefficients].
+//
+// Returns [ErrLengthMismatch] if there is not one value for each point, and [ErrDuplicatePoint] if the points are
+// not distinct.
+func Interpolate(points, values []fr.Element) ([]fr.Element, error) {
+	if len(points) != len(values) {
+		return nil, ErrLengthMismatch
+	}
+	ip, err := NewInterpolator(points)
+	if err != nil {
+		return nil, err
+	}
+	return ip.Coefficients(values)
+}
@@ -0,0 +1,53 @@
+package poly
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/kzgmath"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolator(t *testing.T) {
+	points, values := randomElements(t, 7), randomElements(t, 7)
+	ip, err := NewInterpolator(points)
+	require.NoError(t, err)
+
+	coeffs, err := ip.Coefficients(values)
+	require.NoError(t, err)
+	expected, err := kzgmath.Interpolate(points, values)
+	require.NoError(t, err)
+	require.Equal(t, expected, coeffs)
+	require.Equal(t, values, kzgmath.EvaluateAll(coeffs, points))
+
+	// Evaluating agrees with the coefficients, including at the points
+	for _, z := range append(randomElements(t, 3), points...) {
+		got, err := ip.Evaluate(values, z)
+		require.NoError(t, err)
+		want := Evaluate(coeffs, z)
+		require.True(t, want.Equal(&got))
+	}
+
+	_, err = ip.Evaluate(values[:6], points[0])
+	require.ErrorIs(t, err, ErrLengthMismatch)
+	_, err = ip.Coefficients(values[:6])
+	require.ErrorIs(t, err, ErrLengthMismatch)
+}
+
+func TestInterpolate(t *testing.T) {
+	points := []fr.Element{fr.NewElement(1), fr.NewElement(2), fr.NewElement(3)}
+
+	// p(X) = X^2 + 1
+	coeffs, err := Interpolate(points, []fr.Element{fr.NewElement(2), fr.NewElement(5), fr.NewElement(10)})
+	require.NoError(t, err)
+	require.Equal(t, []fr.Element{fr.NewElement(1), {}, fr.NewElement(1)}, coeffs)
+
+	coeffs, err = Interpolate(nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, coeffs)
+
+	_, err = Interpolate(points, points[:2])
+	require.ErrorIs(t, err, ErrLengthMismatch)
+	_, err = Interpolate(append(points, points[1]), make([]fr.Element, 4))
+	require.ErrorIs(t, err, ErrDuplicatePoint)
+}
@@ -0,0 +1,151 @@
+// Package poly implements arithmetic on polynomials over the scalar field of BLS12-381, using the conventions of the
+// rest of this module.
+//
+// Polynomials are given by their coefficients, where the i'th element is the coefficient of X^i. A slice may have
+// trailing zero coefficients; use [Degree] to find the degree of a polynomial, rather than the length of the slice.
+// Evaluations over a domain of roots of unity are given in bit-reversed order, which is the order of the evaluations
+// in a blob.
+//
+// Unlike the reference implementations in the kzgmath package, these functions are meant to be used on polynomials
+// of the size of a blob or larger: multiplication uses FFTs, and division by a linear factor takes linear time.
+package poly
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+	"github.com/crate-crypto/go-kzg-4844/internal/utils"
+)
+
+// Add returns a + b. The result has as many coefficients as the longer of the two polynomials.
+func Add(a, b []fr.Element) []fr.Element {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	sum := make([]fr.Element, len(a))
+	copy(sum, a)
+	for i := range b {
+		sum[i].Add(&sum[i], &b[i])
+	}
+	return sum
+}
+
+// Sub returns a - b. The result has as many coefficients as the longer of the two polynomials.
+func Sub(a, b []fr.Element) []fr.Element {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	difference := make([]fr.Element, n)
+	copy(difference, a)
+	for i := range b {
+		difference[i].Sub(&difference[i], &b[i])
+	}
+	return difference
+}
+
+// ScalarMul returns s * a, which has as many coefficients as a.
+func ScalarMul(a []fr.Element, s fr.Element) []fr.Element {
+	product := make([]fr.Element, len(a))
+	for i := range a {
+		product[i].Mul(&a[i], &s)
+	}
+	return product
+}
+
+// Mul returns a * b, which has len(a) + len(b) - 1 coefficients, or none if either polynomial has no coefficients.
+//
+// Small polynomials are multiplied directly. Larger ones are multiplied by evaluating them over a domain of roots of
+// unity which is large enough to hold the product, which takes O(n log n) time.
+func Mul(a, b []fr.Element) []fr.Element {
+	return kzg.MulPolynomials(a, b)
+}
+
+// Evaluate returns p(z), using Horner's rule.
+func Evaluate(coeffs []fr.Element, z fr.Element) fr.Element {
+	var result fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		result.Mul(&result, &z)
+		result.Add(&result, &coeffs[i])
+	}
+	return result
+}
+
+// DivideByLinear returns the quotient q and the remainder r of the division of p by X - z, so that
+// p(X) = q(X)(X - z) + r. The remainder is p(z), so it is zero exactly when z is a root of p; the quotient is then the
+// polynomial whose commitment is a KZG proof that p(z) = 0.
+//
+// The quotient has one coefficient fewer than p, or none if p has no coefficients.
+func DivideByLinear(coeffs []fr.Element, z fr.Element) ([]fr.Element, fr.Element) {
+	if len(coeffs) == 0 {
+		return []fr.Element{}, fr.Element{}
+	}
+
+	// Synthetic division, from the leading coefficient down
+	quotient := make([]fr.Element, len(coeffs)-1)
+	remainder := coeffs[len(coeffs)-1]
+	for i := len(coeffs) - 2; i >= 0; i-- {
+		quotient[i] = remainder
+		remainder.Mul(&remainder, &z)
+		remainder.Add(&remainder, &coeffs[i])
+	}
+	return quotient, remainder
+}
+
+// Degree returns the degree of the polynomial, which is the index of its last non-zero coefficient. The zero
+// polynomial, including a polynomial with no coefficients, has degree -1.
+func Degree(coeffs []fr.Element) int {
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		if !coeffs[i].IsZero() {
+			return i
+		}
+	}
+	return -1
+}
+
+// EvaluationsDegree returns the degree of the polynomial of degree less than n which has the given n evaluations over
+// the n'th roots of unity, in bit-reversed order. For example, the degree of the polynomial of a blob is less than
+// half the number of its evaluations if and only if it is the first half of a valid extended blob.
+//
+// Returns [ErrInvalidSize] if the number of evaluations is not a power of two, or is larger than 2^32.
+func EvaluationsDegree(evaluations []fr.Element) (int, error) {
+	coeffs, err := Coefficients(evaluations)
+	if err != nil {
+		return 0, err
+	}
+	return Degree(coeffs), nil
+}
+
+// Coefficients returns the n coefficients of the polynomial of degree less than n which has the given n evaluations
+// over the n'th roots of unity, in bit-reversed order.
+//
+// Returns [ErrInvalidSize] if the number of evaluations is not a power of two, or is larger than 2^32, the size of
+// the largest domain of roots of unity.
+func Coefficients(evaluations []fr.Element) ([]fr.Element, error) {
+	if !validSize(len(evaluations)) {
+		return nil, ErrInvalidSize
+	}
+	natural := make([]fr.Element, len(evaluations))
+	copy(natural, evaluations)
+	utils.BitReverse(natural)
+	return kzg.CachedDomain(uint64(len(evaluations)), 0).IfftFr(natural), nil
+}
+
+// Evaluations returns the evaluations of the polynomial over the n'th roots of unity, in bit-reversed order. Fewer
+// than n coefficients are padded with zeros.
+//
+// Returns [ErrInvalidSize] if n is not a power of two, is larger than 2^32 or is less than the number of coefficients.
+func Evaluations(coeffs []fr.Element, n int) ([]fr.Element, error) {
+	if !validSize(n) || len(coeffs) > n {
+		return nil, ErrInvalidSize
+	}
+	padded := make([]fr.Element, n)
+	copy(padded, coeffs)
+	evaluations := kzg.CachedDomain(uint64(n), 0).FftFr(padded)
+	utils.BitReverse(evaluations)
+	return evaluations, nil
+}
+
+// validSize returns whether n is the size of a domain of roots of unity.
+func validSize(n int) bool {
+	return utils.IsPowerOfTwo(n) && uint64(n) <= kzg.MaxDomainSize
+}
@@ -0,0 +1,128 @@
+package poly
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/kzgmath"
+	"github.com/stretchr/testify/require"
+)
+
+func randomElements(t *testing.T, n int) []fr.Element {
+	elements := make([]fr.Element, n)
+	for i := range elements {
+		_, err := elements[i].SetRandom()
+		require.NoError(t, err)
+	}
+	return elements
+}
+
+func TestAddSub(t *testing.T) {
+	a, b := randomElements(t, 5), randomElements(t, 3)
+	z := fr.NewElement(11)
+	aZ, bZ := Evaluate(a, z), Evaluate(b, z)
+
+	for _, sum := range [][]fr.Element{Add(a, b), Add(b, a)} {
+		require.Len(t, sum, 5)
+		var expected fr.Element
+		expected.Add(&aZ, &bZ)
+		got := Evaluate(sum, z)
+		require.True(t, expected.Equal(&got))
+	}
+
+	difference := Sub(b, a)
+	require.Len(t, difference, 5)
+	var expected fr.Element
+	expected.Sub(&bZ, &aZ)
+	got := Evaluate(difference, z)
+	require.True(t, expected.Equal(&got))
+
+	require.Equal(t, -1, Degree(Sub(a, a)))
+}
+
+func TestScalarMul(t *testing.T) {
+	a := randomElements(t, 4)
+	s, z := fr.NewElement(3), fr.NewElement(7)
+	aZ := Evaluate(a, z)
+
+	var expected fr.Element
+	expected.Mul(&aZ, &s)
+	got := Evaluate(ScalarMul(a, s), z)
+	require.True(t, expected.Equal(&got))
+}
+
+func TestMul(t *testing.T) {
+	// Sizes on both sides of the threshold at which FFTs are used
+	for _, sizes := range [][2]int{{1, 1}, {3, 5}, {100, 2}, {100, 130}, {4096, 4096}} {
+		a, b := randomElements(t, sizes[0]), randomElements(t, sizes[1])
+		product := Mul(a, b)
+		require.Len(t, product, sizes[0]+sizes[1]-1)
+
+		z := randomElements(t, 1)[0]
+		aZ, bZ := Evaluate(a, z), Evaluate(b, z)
+		var expected fr.Element
+		expected.Mul(&aZ, &bZ)
+		got := Evaluate(product, z)
+		require.True(t, expected.Equal(&got))
+	}
+	require.Empty(t, Mul(nil, randomElements(t, 2)))
+}
+
+func TestDivideByLinear(t *testing.T) {
+	coeffs := randomElements(t, 9)
+	z := fr.NewElement(5)
+	quotient, remainder := DivideByLinear(coeffs, z)
+
+	// q(X)(X - z) + r = p(X)
+	var negZ fr.Element
+	negZ.Neg(&z)
+	require.Equal(t, coeffs, Add(Mul(quotient, []fr.Element{negZ, fr.One()}), []fr.Element{remainder}))
+	expected := Evaluate(coeffs, z)
+	require.True(t, expected.Equal(&remainder))
+
+	quotient, remainder = DivideByLinear(nil, z)
+	require.Empty(t, quotient)
+	require.True(t, remainder.IsZero())
+}
+
+func TestDegree(t *testing.T) {
+	coeffs := make([]fr.Element, 6)
+	require.Equal(t, -1, Degree(coeffs))
+	require.Equal(t, -1, Degree(nil))
+	coeffs[3].SetOne()
+	require.Equal(t, 3, Degree(coeffs))
+}
+
+func TestEvaluations(t *testing.T) {
+	coeffs := randomElements(t, 5)
+	evaluations, err := Evaluations(coeffs, 16)
+	require.NoError(t, err)
+
+	// The evaluations are in bit-reversed order
+	roots, err := kzgmath.Roots(16)
+	require.NoError(t, err)
+	roots, err = kzgmath.BitReverse(roots)
+	require.NoError(t, err)
+	require.Equal(t, kzgmath.EvaluateAll(coeffs, roots), evaluations)
+
+	roundTrip, err := Coefficients(evaluations)
+	require.NoError(t, err)
+	require.Equal(t, coeffs, roundTrip[:5])
+	degree, err := EvaluationsDegree(evaluations)
+	require.NoError(t, err)
+	require.Equal(t, 4, degree)
+
+	_, err = Evaluations(coeffs, 4)
+	require.ErrorIs(t, err, ErrInvalidSize)
+	_, err = Evaluations(coeffs, 12)
+	require.ErrorIs(t, err, ErrInvalidSize)
+	_, err = Coefficients(evaluations[:15])
+	require.ErrorIs(t, err, ErrInvalidSize)
+	_, err = EvaluationsDegree(nil)
+	require.ErrorIs(t, err, ErrInvalidSize)
+
+	// There is no domain of more than 2^32 roots of unity
+	shift := 33
+	_, err = Evaluations(coeffs, 1<<shift)
+	require.ErrorIs(t, err, ErrInvalidSize)
+}
@@ -0,0 +1,85 @@
+package gokzg4844
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// Polynomial is a polynomial of degree less than [ScalarsPerBlob] in evaluation form. The i'th element is the
+// evaluation of the polynomial at the i'th root of unity of the domain, in the evaluation order of the context that
+// it is used with; this is the bit-reversed order by default. A Polynomial therefore holds the same values as a blob.
+//
+// Since the underlying type is a slice of field elements, the polynomials returned by [DeserializeBlob] can be
+// assigned to a Polynomial directly.
+type Polynomial []fr.Element
+
+// PolynomialFromBlob returns the polynomial that the blob holds the evaluations of.
+//
+// It returns [ErrBlobNotCanonical] if one of the scalars in the blob is not canonical.
+func PolynomialFromBlob(blob *Blob) (Polynomial, error) {
+	return DeserializeBlob(blob)
+}
+
+// ToPolynomial returns the polynomial that the blob holds the evaluations of. This is the same as
+// [PolynomialFromBlob].
+//
+// The polynomial is newly allocated and owned by the caller: it does not share any memory with the blob, so either
+// can be modified without affecting the other.
+//
+// It returns [ErrBlobNotCanonical] if one of the scalars in the blob is not canonical.
+func (b *Blob) ToPolynomial() (Polynomial, error) {
+	return PolynomialFromBlob(b)
+}
+
+// ToPolynomialInto is the same as [Blob.ToPolynomial], except that the polynomial is written into dst, so that its
+// memory can be reused.
+//
+// A blob cannot be viewed as a polynomial without copying it, since field elements are not stored in the same format
+// as serialized scalars. This is the way to avoid allocating.
+//
+// It returns [ErrInvalidPolynomialLength] if dst does not have [ScalarsPerBlob] elements, and [ErrBlobNotCanonical]
+// if one of the scalars in the blob is not canonical, in which case the contents of dst are unspecified.
+func (b *Blob) ToPolynomialInto(dst Polynomial) error {
+	if len(dst) != ScalarsPerBlob {
+		return ErrInvalidPolynomialLength
+	}
+	return deserializeBlobInto(b, dst)
+}
+
+// ToBlob returns the blob which holds the evaluations of the polynomial.
+//
+// It returns [ErrInvalidPolynomialLength] if the polynomial does not have [ScalarsPerBlob] evaluations.
+func (p Polynomial) ToBlob() (*Blob, error) {
+	return SerializeBlob(p)
+}
+
+// PolynomialFromCoefficients returns the polynomial with the given coefficients, where the i'th coefficient is the
+// coefficient of X^i. The evaluations are in the evaluation order of the context.
+//
+// It returns [ErrInvalidPolynomialLength] if there are more than [ScalarsPerBlob] coefficients. Fewer coefficients
+// are padded with zeros.
+func (c *Context) PolynomialFromCoefficients(coeffs []fr.Element) (Polynomial, error) {
+	if err := c.checkTypes(); err != nil {
+		return nil, err
+	}
+	if len(coeffs) > ScalarsPerBlob {
+		return nil, ErrInvalidPolynomialLength
+	}
+	padded := make([]fr.Element, ScalarsPerBlob)
+	copy(padded, coeffs)
+
+	return c.fromNaturalOrder(c.domain().FftFr(padded)), nil
+}
+
+// Coefficients returns the [ScalarsPerBlob] coefficients of the polynomial, where the i'th coefficient is the
+// coefficient of X^i. The evaluations of the polynomial must be in the evaluation order of the context.
+//
+// It returns [ErrInvalidPolynomialLength] if the polynomial does not have [ScalarsPerBlob] evaluations.
+func (c *Context) Coefficients(p Polynomial) ([]fr.Element, error) {
+	if err := c.checkTypes(); err != nil {
+		return nil, err
+	}
+	if len(p) != ScalarsPerBlob {
+		return nil, ErrInvalidPolynomialLength
+	}
+	return c.domain().IfftFr(c.toNaturalOrder(p)), nil
+}
@@ -0,0 +1,78 @@
+package gokzg4844_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolynomialConversions(t *testing.T) {
+	blob := GetRandBlob(1)
+	poly, err := gokzg4844.PolynomialFromBlob(blob)
+	require.NoError(t, err)
+	roundTrip, err := poly.ToBlob()
+	require.NoError(t, err)
+	require.Equal(t, blob, roundTrip)
+
+	coeffs, err := ctx.Coefficients(poly)
+	require.NoError(t, err)
+	fromCoeffs, err := ctx.PolynomialFromCoefficients(coeffs)
+	require.NoError(t, err)
+	require.Equal(t, poly, fromCoeffs)
+
+	_, err = ctx.Coefficients(poly[1:])
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPolynomialLength)
+	_, err = ctx.PolynomialFromCoefficients(append(coeffs, fr.One()))
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPolynomialLength)
+	_, err = poly[1:].ToBlob()
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPolynomialLength)
+}
+
+func TestPolynomialFromCoefficients(t *testing.T) {
+	// p(X) = 3 + 5X + 7X^2
+	coeffs := []fr.Element{fr.NewElement(3), fr.NewElement(5), fr.NewElement(7)}
+	poly, err := ctx.PolynomialFromCoefficients(coeffs)
+	require.NoError(t, err)
+	blob, err := poly.ToBlob()
+	require.NoError(t, err)
+
+	// The blob should evaluate to p(z) at any point
+	var z fr.Element
+	z.SetUint64(123456789)
+	_, claimedValue, err := ctx.ComputeKZGProof(blob, gokzg4844.SerializeScalar(z), NumGoRoutines)
+	require.NoError(t, err)
+
+	var expected fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		expected.Mul(&expected, &z)
+		expected.Add(&expected, &coeffs[i])
+	}
+	require.Equal(t, gokzg4844.SerializeScalar(expected), claimedValue)
+}
+
+func TestBlobToPolynomial(t *testing.T) {
+	blob := GetRandBlob(1)
+	poly, err := blob.ToPolynomial()
+	require.NoError(t, err)
+	expected, err := gokzg4844.DeserializeBlob(blob)
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.Polynomial(expected), poly)
+
+	// The polynomial does not alias the blob
+	poly[0].SetOne()
+	again, err := blob.ToPolynomial()
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.Polynomial(expected), again)
+
+	dst := make(gokzg4844.Polynomial, gokzg4844.ScalarsPerBlob)
+	require.NoError(t, blob.ToPolynomialInto(dst))
+	require.Equal(t, gokzg4844.Polynomial(expected), dst)
+	require.ErrorIs(t, blob.ToPolynomialInto(dst[1:]), gokzg4844.ErrInvalidPolynomialLength)
+
+	modifyBlob(blob, nonCanonicalScalar(1), 0)
+	_, err = blob.ToPolynomial()
+	require.ErrorIs(t, err, gokzg4844.ErrBlobNotCanonical)
+	require.ErrorIs(t, blob.ToPolynomialInto(dst), gokzg4844.ErrBlobNotCanonical)
+}
@@ -0,0 +1,67 @@
+package gokzg4844
+
+import (
+	"context"
+	"encoding/binary"
+)
+
+// PointEvaluationPrecompileAddress is the address of the point evaluation precompile, as defined by [EIP-4844].
+//
+// [EIP-4844]: https://eips.ethereum.org/EIPS/eip-4844#point-evaluation-precompile
+const PointEvaluationPrecompileAddress = 0x0a
+
+// PointEvaluationPrecompileGas is the gas cost of a call to the point evaluation precompile, as defined by
+// [EIP-4844]. The cost is fixed, whatever the input, and is charged even if the call fails.
+//
+// [EIP-4844]: https://eips.ethereum.org/EIPS/eip-4844#gas-costs
+const PointEvaluationPrecompileGas = 50000
+
+// PointEvaluationPrecompileInputSize is the number of bytes in the input of the point evaluation precompile: the
+// versioned hash, the input point, the claimed value, the commitment and the proof.
+const PointEvaluationPrecompileInputSize = 32 + 2*SerializedScalarSize + 2*CompressedG1Size
+
+// PointEvaluationPrecompile implements the [point evaluation precompile] of EIP-4844, for EVM simulators and
+// fuzzers. The input is the versioned hash of the commitment, followed by the input point, the claimed value, the
+// commitment and the proof; it is accepted if the commitment matches the versioned hash and the proof is valid.
+//
+// The output of a successful call is always the same: the number of scalars in a blob followed by [BlsModulus], each
+// as a 32 byte big-endian integer. Any error means that the call fails and consumes all of its gas.
+//
+// Returns [ErrInvalidPrecompileInputLength] if the input does not have [PointEvaluationPrecompileInputSize] bytes,
+// [ErrVersionedHashMismatch] if the commitment does not match the versioned hash, and the errors of
+// [Context.VerifyKZGProof] otherwise.
+//
+// [point evaluation precompile]: https://eips.ethereum.org/EIPS/eip-4844#point-evaluation-precompile
+func (c *Context) PointEvaluationPrecompile(input []byte) ([64]byte, error) {
+	if err := c.checkOpen(); err != nil {
+		return [64]byte{}, err
+	}
+	if len(input) != PointEvaluationPrecompileInputSize {
+		return [64]byte{}, ErrInvalidPrecompileInputLength
+	}
+
+	var (
+		versionedHash VersionedHash
+		inputPoint    Scalar
+		claimedValue  Scalar
+		commitment    KZGCommitment
+		proof         KZGProof
+	)
+	rest := input
+	for _, field := range [][]byte{versionedHash[:], inputPoint[:], claimedValue[:], commitment[:], proof[:]} {
+		rest = rest[copy(field, rest):]
+	}
+
+	if !versionedHash.Matches(commitment) {
+		return [64]byte{}, ErrVersionedHashMismatch
+	}
+	if err := c.checkKZGProof(context.Background(), commitment, inputPoint, claimedValue, proof); err != nil {
+		return [64]byte{}, err
+	}
+
+	// The constants are those of the mainnet preset, which the precompile is defined with
+	var output [64]byte
+	binary.BigEndian.PutUint64(output[24:32], ScalarsPerBlob)
+	copy(output[32:], BlsModulus[:])
+	return output, nil
+}
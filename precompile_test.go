@@ -0,0 +1,77 @@
+package gokzg4844_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+// The output of every successful call, as given in EIP-4844
+const precompileOutput = "0000000000000000000000000000000000000000000000000000000000001000" +
+	"73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"
+
+func TestPointEvaluationPrecompile(t *testing.T) {
+	blob := GetRandBlob(41)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	inputPoint := GetRandFieldElement(42)
+	proof, claimedValue, err := ctx.ComputeKZGProof(blob, inputPoint, NumGoRoutines)
+	require.NoError(t, err)
+
+	versionedHash := sha256.Sum256(commitment[:])
+	versionedHash[0] = 0x01
+	var input []byte
+	for _, field := range [][]byte{versionedHash[:], inputPoint[:], claimedValue[:], commitment[:], proof[:]} {
+		input = append(input, field...)
+	}
+	require.Len(t, input, gokzg4844.PointEvaluationPrecompileInputSize)
+
+	output, err := ctx.PointEvaluationPrecompile(input)
+	require.NoError(t, err)
+	require.Equal(t, precompileOutput, hex.EncodeToString(output[:]))
+	shared, err := gokzg4844.SimulatePointEvaluationPrecompile(input)
+	require.NoError(t, err)
+	require.Equal(t, output, shared)
+
+	_, err = ctx.PointEvaluationPrecompile(input[:len(input)-1])
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPrecompileInputLength)
+
+	tampered := append([]byte(nil), input...)
+	tampered[0] = 0x02
+	_, err = ctx.PointEvaluationPrecompile(tampered)
+	require.ErrorIs(t, err, gokzg4844.ErrVersionedHashMismatch)
+
+	// The claimed value is the third field
+	tampered = append([]byte(nil), input...)
+	otherValue := GetRandFieldElement(43)
+	copy(tampered[64:96], otherValue[:])
+	_, err = ctx.PointEvaluationPrecompile(tampered)
+	require.ErrorIs(t, err, gokzg4844.ErrProofInvalid)
+}
+
+func TestPointEvaluationPrecompileZeroPolynomial(t *testing.T) {
+	// The commitment to the zero polynomial and its proofs are all the point at
+	// infinity, so the input is valid for any input point with a value of zero.
+	var infinity gokzg4844.KZGCommitment
+	infinity[0] = 0xc0
+	versionedHash := gokzg4844.KZGToVersionedHash(infinity)
+	inputPoint := GetRandFieldElement(44)
+	var claimedValue gokzg4844.Scalar
+
+	var input []byte
+	for _, field := range [][]byte{versionedHash[:], inputPoint[:], claimedValue[:], infinity[:], infinity[:]} {
+		input = append(input, field...)
+	}
+	output, err := ctx.PointEvaluationPrecompile(input)
+	require.NoError(t, err)
+	require.Equal(t, precompileOutput, hex.EncodeToString(output[:]))
+
+	// Any other value is not
+	claimedValue[gokzg4844.SerializedScalarSize-1] = 1
+	copy(input[64:96], claimedValue[:])
+	_, err = ctx.PointEvaluationPrecompile(input)
+	require.ErrorIs(t, err, gokzg4844.ErrProofInvalid)
+}
@@ -0,0 +1,257 @@
+package gokzg4844
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/crate-crypto/go-kzg-4844/internal/multiexp"
+)
+
+// precomputeMagic identifies a file written by [Context.SavePrecompute] and the version of its header.
+var precomputeMagic = [8]byte{'G', 'O', 'K', 'Z', 'G', 'P', 'C', '1'}
+
+// precomputeConstantsDomain is the domain separator for [precomputeConstantsFingerprint].
+const precomputeConstantsDomain = "GOKZG_CONSTANTS1"
+
+// maxLibraryVersionLen bounds the length of the library version in the header, so that a corrupted header
+// cannot cause a large allocation.
+const maxLibraryVersionLen = 256
+
+// StalePrecomputeError is returned by [Context.LoadPrecompute] when the precomputed table was written by a
+// different version of this library, or with different constants or a different trusted setup, than the context
+// that is loading it.
+//
+// The table needs to be regenerated: create the context with [WithPrecompute] and save the table again
+// with [Context.SavePrecompute].
+//
+// errors.Is(err, ErrStalePrecompute) reports whether err is a StalePrecomputeError.
+type StalePrecomputeError struct {
+	// Field is the part of the fingerprint which did not match.
+	// It is one of "format", "library version", "constants" or "setup".
+	Field string
+	// Expected is the value for the context that is loading the table.
+	Expected string
+	// Found is the value stored in the table.
+	Found string
+}
+
+func (e *StalePrecomputeError) Error() string {
+	return fmt.Sprintf("precomputed table is stale: %s mismatch (expected %s, found %s); "+
+		"regenerate it by creating the context with WithPrecompute and calling SavePrecompute", e.Field, e.Expected, e.Found)
+}
+
+// Is makes errors.Is(err, ErrStalePrecompute) true for a [StalePrecomputeError].
+func (e *StalePrecomputeError) Is(target error) bool {
+	return target == ErrStalePrecompute
+}
+
+// precomputeHeader is written before the table by [Context.SavePrecompute].
+type precomputeHeader struct {
+	libraryVersion string
+	constants      [32]byte
+	setupDigest    [32]byte
+}
+
+// SavePrecompute writes the table created by [WithPrecompute] to w, so that it can later be loaded with
+// [Context.LoadPrecompute] instead of being recomputed.
+//
+// The table is prefixed with a fingerprint of the library version, the constants that the table depends on and the
+// trusted setup, so that a table written by a different version cannot be loaded by mistake.
+//
+// Returns [ErrNoPrecompute] if the context does not have a precomputed table.
+func (c *Context) SavePrecompute(w io.Writer) error {
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+	if c.commitKey().FixedBase == nil {
+		return ErrNoPrecompute
+	}
+
+	bw := bufio.NewWriter(w)
+	err := writePrecomputeHeader(bw, c.precomputeHeader())
+	if err != nil {
+		return err
+	}
+	_, err = c.commitKey().FixedBase.WriteTo(bw)
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// LoadPrecompute reads a table written by [Context.SavePrecompute] and uses it to compute commitments and proofs.
+//
+// Returns a [StalePrecomputeError] if the table was written by a different version of this library, or for different
+// constants or a different trusted setup. The table must also have been saved by a context with the same evaluation
+// order; an error is returned otherwise. In all error cases, the context is left unchanged.
+//
+// Note: This method must not be called concurrently with any other method on the context. It is intended to be
+// called once, right after the context has been created.
+func (c *Context) LoadPrecompute(r io.Reader) error {
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+	br := bufio.NewReader(r)
+
+	if err := c.checkPrecomputeHeader(br); err != nil {
+		return err
+	}
+
+	table, err := multiexp.ReadFixedBaseTable(br, c.commitKey().G1)
+	if err != nil {
+		return err
+	}
+	c.commitKey().FixedBase = table
+	return nil
+}
+
+// ReadContextFromFileMmap creates a context from the trusted setup of the Ethereum KZG ceremony, as
+// [NewContext4096Secure] does, and loads the precomputed table in the file at path with [Context.LoadPrecomputeMmap].
+//
+// The table can be locked into memory with [WithMlockPrecompute].
+func ReadContextFromFileMmap(path string, opts ...Option) (*Context, error) {
+	ctx, err := NewContext4096Secure(opts...)
+	if err != nil {
+		return nil, err
+	}
+	cfg := newConfig(opts)
+	if err := ctx.loadPrecomputeMmap(path, cfg.mlockPrecompute); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// LoadPrecomputeMmap is the same as [Context.LoadPrecompute], except that the table is read from the file at path by
+// memory mapping it. The table then uses the memory of the mapping directly, so the peak memory use is that of one
+// table rather than two, and the operating system only reads each part of the table from disk when it is first used.
+//
+// Only a few points of the table are checked when it is loaded, so that the whole file is not read; the file must come
+// from a trusted source, such as [Context.SavePrecompute] on the same machine. The file must not be modified while the
+// context is in use, and the mapping is kept for the lifetime of the process.
+//
+// On platforms other than Linux and macOS, and on machines where the table cannot be used in place, the table is read
+// into memory instead.
+func (c *Context) LoadPrecomputeMmap(path string) error {
+	return c.loadPrecomputeMmap(path, false)
+}
+
+// loadPrecomputeMmap implements [Context.LoadPrecomputeMmap], locking the mapping into memory if lock is true.
+func (c *Context) loadPrecomputeMmap(path string, lock bool) error {
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+	data, release, err := mmapFile(path, lock)
+	if err != nil {
+		return err
+	}
+
+	r := bytes.NewReader(data)
+	if err := c.checkPrecomputeHeader(r); err != nil {
+		_ = release()
+		return err
+	}
+	table, aliased, err := multiexp.FixedBaseTableFromBytes(data[len(data)-r.Len():], c.commitKey().G1)
+	if err != nil {
+		_ = release()
+		return err
+	}
+	if !aliased {
+		if err := release(); err != nil {
+			return err
+		}
+	}
+	c.commitKey().FixedBase = table
+	return nil
+}
+
+// checkPrecomputeHeader reads the header of a precomputed table from r, and checks that the table was written for
+// this context.
+func (c *Context) checkPrecomputeHeader(r io.Reader) error {
+	found, err := readPrecomputeHeader(r)
+	if err != nil {
+		return err
+	}
+	expected := c.precomputeHeader()
+	switch {
+	case found.libraryVersion != expected.libraryVersion:
+		return &StalePrecomputeError{Field: "library version", Expected: expected.libraryVersion, Found: found.libraryVersion}
+	case found.constants != expected.constants:
+		return &StalePrecomputeError{Field: "constants", Expected: fmt.Sprintf("%x", expected.constants), Found: fmt.Sprintf("%x", found.constants)}
+	case found.setupDigest != expected.setupDigest:
+		return &StalePrecomputeError{Field: "setup", Expected: fmt.Sprintf("%x", expected.setupDigest), Found: fmt.Sprintf("%x", found.setupDigest)}
+	}
+	return nil
+}
+
+// precomputeHeader returns the header for a table saved by this context.
+func (c *Context) precomputeHeader() precomputeHeader {
+	return precomputeHeader{
+		libraryVersion: libraryVersion(),
+		constants:      precomputeConstantsFingerprint(),
+		setupDigest:    c.setupDigest,
+	}
+}
+
+// precomputeConstantsFingerprint hashes the constants that a precomputed table depends on.
+func precomputeConstantsFingerprint() [32]byte {
+	h := sha256.New()
+	h.Write([]byte(precomputeConstantsDomain))
+	writeLength(h, ScalarsPerBlob)
+	writeLength(h, SerializedScalarSize)
+	writeLength(h, CompressedG1Size)
+	writeLength(h, multiexp.MinWindowBits)
+	writeLength(h, multiexp.MaxWindowBits)
+
+	var digest [32]byte
+	copy(digest[:], h.Sum(nil))
+	return digest
+}
+
+// writePrecomputeHeader writes the magic bytes followed by the header.
+func writePrecomputeHeader(w io.Writer, header precomputeHeader) error {
+	var buf bytes.Buffer
+	buf.Write(precomputeMagic[:])
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(len(header.libraryVersion)))
+	buf.WriteString(header.libraryVersion)
+	buf.Write(header.constants[:])
+	buf.Write(header.setupDigest[:])
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// readPrecomputeHeader reads a header written by [writePrecomputeHeader].
+func readPrecomputeHeader(r io.Reader) (precomputeHeader, error) {
+	var magic [8]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return precomputeHeader{}, err
+	}
+	if magic != precomputeMagic {
+		return precomputeHeader{}, &StalePrecomputeError{Field: "format", Expected: string(precomputeMagic[:]), Found: fmt.Sprintf("%q", magic[:])}
+	}
+
+	var versionLen uint16
+	if err := binary.Read(r, binary.LittleEndian, &versionLen); err != nil {
+		return precomputeHeader{}, err
+	}
+	if versionLen > maxLibraryVersionLen {
+		return precomputeHeader{}, multiexp.ErrInvalidFixedBaseTable
+	}
+	version := make([]byte, versionLen)
+	if _, err := io.ReadFull(r, version); err != nil {
+		return precomputeHeader{}, err
+	}
+
+	header := precomputeHeader{libraryVersion: string(version)}
+	if _, err := io.ReadFull(r, header.constants[:]); err != nil {
+		return precomputeHeader{}, err
+	}
+	if _, err := io.ReadFull(r, header.setupDigest[:]); err != nil {
+		return precomputeHeader{}, err
+	}
+	return header, nil
+}
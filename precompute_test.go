@@ -0,0 +1,43 @@
+package gokzg4844
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPrecomputeStaleHeader(t *testing.T) {
+	ctx, err := NewContext4096Secure()
+	require.NoError(t, err)
+
+	expected := ctx.precomputeHeader()
+
+	staleVersion := expected
+	staleVersion.libraryVersion = "v0.0.1"
+	staleConstants := expected
+	staleConstants.constants[0] ^= 1
+	staleSetup := expected
+	staleSetup.setupDigest[0] ^= 1
+
+	tests := map[string]precomputeHeader{
+		"library version": staleVersion,
+		"constants":       staleConstants,
+		"setup":           staleSetup,
+	}
+	for field, header := range tests {
+		var buf bytes.Buffer
+		require.NoError(t, writePrecomputeHeader(&buf, header))
+
+		err := ctx.LoadPrecompute(&buf)
+		require.ErrorIs(t, err, ErrStalePrecompute)
+
+		var staleErr *StalePrecomputeError
+		require.True(t, errors.As(err, &staleErr))
+		require.Equal(t, field, staleErr.Field)
+	}
+
+	err = ctx.LoadPrecompute(bytes.NewReader([]byte("not a precomputed table")))
+	require.ErrorIs(t, err, ErrStalePrecompute)
+}
@@ -0,0 +1,196 @@
+package gokzg4844
+
+// Preset holds the sizes which are set by a preset of the consensus specs, such as the number of scalars in a blob.
+//
+// The [Blob] and [Cell] types have the sizes of the [MainnetPreset]. A preset with other sizes will come with its own
+// set of types, so that the existing signatures do not change. Code which handles serialized data of unknown size
+// should check its length with the methods of the preset, and convert it with [Preset.BlobFromBytes], which returns an
+// error rather than failing to compile if the sizes do not match.
+type Preset struct {
+	// Name identifies the preset, for example "mainnet".
+	Name string
+	// ScalarsPerBlob is the number of scalars in a blob.
+	//
+	// It matches [FIELD_ELEMENTS_PER_BLOB] in the spec.
+	//
+	// [FIELD_ELEMENTS_PER_BLOB]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#blob
+	ScalarsPerBlob int
+	// ScalarsPerCell is the number of scalars in a cell.
+	//
+	// It matches [FIELD_ELEMENTS_PER_CELL] in the spec.
+	//
+	// [FIELD_ELEMENTS_PER_CELL]: https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/polynomial-commitments-sampling.md#preset
+	ScalarsPerCell int
+	// MaxBlobCommitmentsPerBlock is the largest number of blobs that a block can commit to, which bounds the number
+	// of blobs that a decoder of untrusted data needs to accept.
+	//
+	// It matches [MAX_BLOB_COMMITMENTS_PER_BLOCK] in the spec.
+	//
+	// [MAX_BLOB_COMMITMENTS_PER_BLOCK]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/beacon-chain.md#execution
+	MaxBlobCommitmentsPerBlock int
+}
+
+// MainnetPreset returns the preset used by Ethereum mainnet, and the one that contexts use unless they are created
+// with [NewContextWithPreset] or [NewContextFromMonomialSetup].
+func MainnetPreset() Preset {
+	return mainnetPreset
+}
+
+// mainnetPreset is the value returned by [MainnetPreset]. It is not exported, so that the sizes which contexts are
+// checked against cannot be changed by callers.
+var mainnetPreset = Preset{
+	Name:           "mainnet",
+	ScalarsPerBlob: ScalarsPerBlob,
+	ScalarsPerCell: ScalarsPerCell,
+
+	MaxBlobCommitmentsPerBlock: 4096,
+}
+
+// MinimalPreset returns the preset with tiny blobs which the consensus spec tests use to run quickly. Contexts for it
+// are created with [NewContextMinimalInsecure].
+func MinimalPreset() Preset {
+	return minimalPreset
+}
+
+// minimalPreset is the value returned by [MinimalPreset].
+var minimalPreset = Preset{
+	Name:           "minimal",
+	ScalarsPerBlob: 4,
+	ScalarsPerCell: 2,
+
+	MaxBlobCommitmentsPerBlock: 32,
+}
+
+// BytesPerBlob returns the number of bytes in a blob.
+func (p Preset) BytesPerBlob() int {
+	return p.ScalarsPerBlob * SerializedScalarSize
+}
+
+// ScalarsPerExtBlob returns the number of scalars in a blob after it has been extended with the Reed-Solomon code.
+func (p Preset) ScalarsPerExtBlob() int {
+	return 2 * p.ScalarsPerBlob
+}
+
+// BytesPerCell returns the number of bytes in a cell.
+func (p Preset) BytesPerCell() int {
+	return p.ScalarsPerCell * SerializedScalarSize
+}
+
+// CellsPerExtBlob returns the number of cells that an extended blob is split into.
+//
+// Returns [ErrInvalidPreset] if the preset does not pass [Preset.Validate], for example because it is the zero value.
+func (p Preset) CellsPerExtBlob() (int, error) {
+	if err := p.Validate(); err != nil {
+		return 0, err
+	}
+	return p.ScalarsPerExtBlob() / p.ScalarsPerCell, nil
+}
+
+// CheckBlobLength returns [ErrInvalidBlobLength] if n is not the number of bytes in a blob.
+func (p Preset) CheckBlobLength(n int) error {
+	if n != p.BytesPerBlob() {
+		return ErrInvalidBlobLength
+	}
+	return nil
+}
+
+// CheckCellLength returns [ErrInvalidCellLength] if n is not the number of bytes in a cell.
+func (p Preset) CheckCellLength(n int) error {
+	if n != p.BytesPerCell() {
+		return ErrInvalidCellLength
+	}
+	return nil
+}
+
+// CheckBlobCount returns a [*CountLimitError] if n is negative or is more blobs than a block can commit to. Code which
+// decodes a list of blobs, commitments or proofs from untrusted data should call this with the length of the list
+// before allocating it.
+func (p Preset) CheckBlobCount(n int) error {
+	return checkCount("blobs", n, p.MaxBlobCommitmentsPerBlock)
+}
+
+// CheckCellCount returns a [*CountLimitError] if n is negative or is more cells than an extended blob has. Code which
+// decodes a list of cells from untrusted data should call this with the length of the list before allocating it.
+//
+// Returns [ErrInvalidPreset] if the preset does not pass [Preset.Validate].
+func (p Preset) CheckCellCount(n int) error {
+	cellsPerExtBlob, err := p.CellsPerExtBlob()
+	if err != nil {
+		return err
+	}
+	return checkCount("cells", n, cellsPerExtBlob)
+}
+
+// checkCount returns a [*CountLimitError] if n is not in the range [0, max].
+func checkCount(what string, n, max int) error {
+	if n < 0 || n > max {
+		return &CountLimitError{What: what, Count: n, Max: max}
+	}
+	return nil
+}
+
+// BlobFromBytes returns the [Blob] which is backed by the given slice, as done by [BlobFromBytes], after checking that
+// the slice is a blob of this preset.
+//
+// Returns [ErrInvalidBlobLength] if the slice does not have the length of a blob of this preset, and
+// [ErrPresetMismatch] if blobs of this preset are not of the [Blob] type.
+func (p Preset) BlobFromBytes(byts []byte) (*Blob, error) {
+	if err := p.CheckBlobLength(len(byts)); err != nil {
+		return nil, err
+	}
+	if p.BytesPerBlob() != len(Blob{}) {
+		return nil, ErrPresetMismatch
+	}
+	return (*Blob)(byts), nil
+}
+
+// CellFromBytes returns the [Cell] which is backed by the given slice, as done by [CellFromBytes], after checking that
+// the slice is a cell of this preset.
+//
+// Returns [ErrInvalidCellLength] if the slice does not have the length of a cell of this preset, and
+// [ErrPresetMismatch] if cells of this preset are not of the [Cell] type.
+func (p Preset) CellFromBytes(byts []byte) (*Cell, error) {
+	if err := p.CheckCellLength(len(byts)); err != nil {
+		return nil, err
+	}
+	if p.BytesPerCell() != len(Cell{}) {
+		return nil, ErrPresetMismatch
+	}
+	return (*Cell)(byts), nil
+}
+
+// Validate returns [ErrInvalidPreset] if the sizes of the preset cannot be used to create a context; that is, unless
+// the number of scalars in a blob is a power of two, and the number of scalars in a cell is a power of two which is
+// at most the number of scalars in an extended blob.
+func (p Preset) Validate() error {
+	if !isPowerOfTwo(p.ScalarsPerBlob) || !isPowerOfTwo(p.ScalarsPerCell) || p.ScalarsPerCell > p.ScalarsPerExtBlob() {
+		return ErrInvalidPreset
+	}
+	if p.MaxBlobCommitmentsPerBlock < 0 {
+		return ErrInvalidPreset
+	}
+	return nil
+}
+
+// isPowerOfTwo reports whether n is a positive power of two.
+func isPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// Preset returns the preset that the context was created for.
+func (c *Context) Preset() Preset {
+	return c.preset
+}
+
+// checkTypes returns [ErrPresetMismatch] if the blobs and cells of the preset of the context are not of the [Blob]
+// and [Cell] types. Only the methods which take slices, such as [Context.BlobToKZGCommitmentBytes], can be used with
+// the other presets.
+func (c *Context) checkTypes() error {
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+	if c.preset.BytesPerBlob() != len(Blob{}) || c.preset.BytesPerCell() != len(Cell{}) {
+		return ErrPresetMismatch
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package gokzg4844
+
+import (
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// NewContextWithPreset is the same as [NewContext4096], except that the context is for the given preset, whose blobs
+// may have any power-of-two number of scalars up to [ScalarsPerBlob]. This can be used for test presets, or for
+// commitments to smaller amounts of data, with the setup from the Ethereum KZG ceremony.
+//
+// For a preset with smaller blobs, the monomial G1 points [tau^i]G are derived from the Lagrange points of the setup,
+// and the Lagrange points for the smaller domain are computed from the first of them; this takes a few seconds.
+// The methods which take a [Blob] or [Cell] return [ErrPresetMismatch] unless the preset has the sizes of the
+// [MainnetPreset]; use the methods which take slices, such as [Context.BlobToKZGCommitmentBytes], and
+// [Context.VerifyKZGProof], which does not depend on the size of a blob.
+//
+// Returns [ErrInvalidPreset] if the preset is not valid, and [ErrSetupTooSmall] if its blobs have more than
+// [ScalarsPerBlob] scalars; use [NewContextFromMonomialSetup] with a larger setup for those.
+func NewContextWithPreset(preset Preset, trustedSetup *JSONTrustedSetup, opts ...Option) (*Context, error) {
+	cfg := newConfig(opts)
+
+	if err := SelfTest(); err != nil {
+		return nil, err
+	}
+	if err := preset.Validate(); err != nil {
+		return nil, err
+	}
+	if preset.ScalarsPerBlob > ScalarsPerBlob {
+		return nil, ErrSetupTooSmall
+	}
+
+	setupDigest, genG1, setupLagrangeG1Points, setupG2Points, err := parseCheckedTrustedSetup(cfg, trustedSetup)
+	if err != nil {
+		return nil, err
+	}
+	if preset.ScalarsPerBlob == ScalarsPerBlob {
+		return newContext(cfg, preset, setupDigest, genG1, setupLagrangeG1Points, setupG2Points)
+	}
+
+	sourceIndices, err := permutationSourceIndices(NaturalOrder, ScalarsPerBlob)
+	if err != nil {
+		return nil, err
+	}
+	monomial := lagrangeToMonomialG1(cachedDomain(ScalarsPerBlob, cfg.numGoRoutines).inner, setupLagrangeG1Points, sourceIndices)
+	// The points have been checked already, if needed
+	cfg.setupChecks = SetupChecksNever
+	return newContextFromMonomial(cfg, preset, monomial[:preset.ScalarsPerBlob], setupG2Points)
+}
+
+// NewContextFromMonomialSetup creates a context for the given preset from a trusted setup in monomial form; that
+// is, from the points [tau^i]G for i in [0, len(g1Monomial)) and [tau^i]H for i in [0, len(g2Monomial)). The setup
+// must have at least as many G1 points as there are scalars in a blob of the preset, and the extra points are not
+// used. This allows contexts for blobs larger than [ScalarsPerBlob], given a large enough setup.
+//
+// The Lagrange points for the domain of the preset are computed from the monomial points. The points are checked to
+// be in the correct subgroup unless this is disabled with [WithSetupChecks]; they are known to be on the curve, since
+// they are already deserialized. See [NewContextWithPreset] for the methods that can be used with the context.
+//
+// Returns [ErrInvalidPreset] if the preset is not valid, [ErrSetupTooSmall] if there are not enough G1 points, and
+// [ErrPointNotInSubgroup] if a point is not in the correct subgroup.
+func NewContextFromMonomialSetup(preset Preset, g1Monomial []bls12381.G1Affine, g2Monomial []bls12381.G2Affine, opts ...Option) (*Context, error) {
+	cfg := newConfig(opts)
+
+	if err := SelfTest(); err != nil {
+		return nil, err
+	}
+	if err := preset.Validate(); err != nil {
+		return nil, err
+	}
+	if len(g1Monomial) < preset.ScalarsPerBlob {
+		return nil, ErrSetupTooSmall
+	}
+	// This should not happen for the ETH protocol
+	// However since it's a public method, we add the check.
+	if len(g2Monomial) < 2 {
+		return nil, kzg.ErrMinSRSSize
+	}
+
+	return newContextFromMonomial(cfg, preset, g1Monomial[:preset.ScalarsPerBlob], g2Monomial)
+}
+
+// newContextFromMonomial creates a context for the preset from the monomial G1 points of a trusted setup, of which
+// there must be one for each scalar in a blob of the preset.
+func newContextFromMonomial(cfg config, preset Preset, g1Monomial []bls12381.G1Affine, g2Points []bls12381.G2Affine) (*Context, error) {
+	domain := cachedDomain(uint64(preset.ScalarsPerBlob), cfg.numGoRoutines).inner
+	return newContextFromPoints(cfg, preset, domain.IfftG1(g1Monomial), g2Points)
+}
@@ -0,0 +1,115 @@
+package gokzg4844_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/crate-crypto/go-kzg-4844/kzgmath"
+	"github.com/stretchr/testify/require"
+)
+
+var smallPreset = gokzg4844.Preset{Name: "small", ScalarsPerBlob: 16, ScalarsPerCell: 4}
+
+// smallBlobCoefficients returns a blob of the small preset and the coefficients of its polynomial.
+func smallBlobCoefficients(t *testing.T, seed int64) ([]byte, []fr.Element) {
+	blob := GetRandBlob(seed)[:smallPreset.BytesPerBlob()]
+
+	evaluations := make([]fr.Element, smallPreset.ScalarsPerBlob)
+	for i := range evaluations {
+		var scalar gokzg4844.Scalar
+		copy(scalar[:], blob[i*gokzg4844.SerializedScalarSize:])
+		var err error
+		evaluations[i], err = gokzg4844.DeserializeScalar(scalar)
+		require.NoError(t, err)
+	}
+	roots, err := kzgmath.Roots(uint64(smallPreset.ScalarsPerBlob))
+	require.NoError(t, err)
+	evaluations, err = kzgmath.BitReverse(evaluations)
+	require.NoError(t, err)
+	coeffs, err := kzgmath.Interpolate(roots, evaluations)
+	require.NoError(t, err)
+	return blob, coeffs
+}
+
+func TestNewContextWithPreset(t *testing.T) {
+	smallCtx, err := gokzg4844.NewContextWithPreset(smallPreset, ctx.TrustedSetup())
+	require.NoError(t, err)
+	require.Equal(t, smallPreset, smallCtx.Preset())
+	require.Nil(t, smallCtx.TrustedSetup())
+
+	// The setup is the start of the mainnet setup
+	require.Equal(t, ctx.MonomialG1Points()[:smallPreset.ScalarsPerBlob], smallCtx.MonomialG1Points())
+	setup := &kzgmath.Setup{G1: smallCtx.MonomialG1Points()}
+
+	blob, coeffs := smallBlobCoefficients(t, 21)
+	commitment, err := smallCtx.BlobToKZGCommitmentBytes(blob, NumGoRoutines)
+	require.NoError(t, err)
+	expectedCommitment, err := kzgmath.Commit(setup, coeffs)
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.KZGCommitment(gokzg4844.SerializeG1Point(expectedCommitment)), commitment)
+
+	z := GetRandFieldElement(22)
+	proof, y, err := smallCtx.ComputeKZGProofBytes(blob, z, NumGoRoutines)
+	require.NoError(t, err)
+	require.NoError(t, smallCtx.VerifyKZGProof(commitment, z, y, proof))
+	zElement, err := gokzg4844.DeserializeScalar(z)
+	require.NoError(t, err)
+	expectedProof, _, err := kzgmath.Open(setup, coeffs, zElement)
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.KZGProof(gokzg4844.SerializeG1Point(expectedProof)), proof)
+
+	blobProof, err := smallCtx.ComputeBlobKZGProofBytes(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+	require.NoError(t, smallCtx.VerifyBlobKZGProofBytes(blob, commitment, blobProof))
+
+	// The methods which take mainnet types cannot be used
+	_, err = smallCtx.BlobToKZGCommitment(GetRandBlob(21), NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrPresetMismatch)
+	_, err = smallCtx.BlobToKZGCommitmentBytes(GetRandBlob(21)[:], NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidBlobLength)
+	require.ErrorIs(t, smallCtx.WriteTextTrustedSetup(nil), gokzg4844.ErrPresetMismatch)
+}
+
+func TestNewContextFromMonomialSetup(t *testing.T) {
+	insecure, err := gokzg4844.NewInsecureSetup(big.NewInt(1234), uint64(2*smallPreset.ScalarsPerBlob), 2)
+	require.NoError(t, err)
+	setup := &kzgmath.Setup{G1: insecure.G1Monomial, G2: insecure.G2Monomial}
+	smallCtx, err := gokzg4844.NewContextFromMonomialSetup(smallPreset, setup.G1, setup.G2)
+	require.NoError(t, err)
+
+	blob, coeffs := smallBlobCoefficients(t, 23)
+	commitment, err := smallCtx.BlobToKZGCommitmentBytes(blob, NumGoRoutines)
+	require.NoError(t, err)
+	expectedCommitment, err := kzgmath.Commit(setup, coeffs)
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.KZGCommitment(gokzg4844.SerializeG1Point(expectedCommitment)), commitment)
+
+	proof, err := smallCtx.ComputeBlobKZGProofBytes(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+	require.NoError(t, smallCtx.VerifyBlobKZGProofBytes(blob, commitment, proof))
+
+	_, err = gokzg4844.NewContextFromMonomialSetup(smallPreset, setup.G1[:smallPreset.ScalarsPerBlob-1], setup.G2)
+	require.ErrorIs(t, err, gokzg4844.ErrSetupTooSmall)
+}
+
+func TestPresetValidate(t *testing.T) {
+	require.NoError(t, gokzg4844.MainnetPreset().Validate())
+	require.NoError(t, smallPreset.Validate())
+
+	for _, preset := range []gokzg4844.Preset{
+		{ScalarsPerBlob: 12, ScalarsPerCell: 4},
+		{ScalarsPerBlob: 16, ScalarsPerCell: 3},
+		{ScalarsPerBlob: 16, ScalarsPerCell: 64},
+		{ScalarsPerBlob: 16, ScalarsPerCell: 4, MaxBlobCommitmentsPerBlock: -1},
+	} {
+		require.ErrorIs(t, preset.Validate(), gokzg4844.ErrInvalidPreset)
+		_, err := gokzg4844.NewContextWithPreset(preset, ctx.TrustedSetup())
+		require.ErrorIs(t, err, gokzg4844.ErrInvalidPreset)
+	}
+
+	large := gokzg4844.Preset{ScalarsPerBlob: 2 * gokzg4844.ScalarsPerBlob, ScalarsPerCell: 64}
+	_, err := gokzg4844.NewContextWithPreset(large, ctx.TrustedSetup())
+	require.ErrorIs(t, err, gokzg4844.ErrSetupTooSmall)
+}
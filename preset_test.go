@@ -0,0 +1,74 @@
+package gokzg4844_test
+
+import (
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMainnetPreset(t *testing.T) {
+	preset := ctx.Preset()
+	require.Equal(t, gokzg4844.MainnetPreset(), preset)
+	require.Equal(t, len(gokzg4844.Blob{}), preset.BytesPerBlob())
+	require.Equal(t, gokzg4844.ScalarsPerExtBlob, preset.ScalarsPerExtBlob())
+	require.Equal(t, gokzg4844.BytesPerCell, preset.BytesPerCell())
+	cellsPerExtBlob, err := preset.CellsPerExtBlob()
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.CellsPerExtBlob, cellsPerExtBlob)
+
+	blob, err := preset.BlobFromBytes(make([]byte, preset.BytesPerBlob()))
+	require.NoError(t, err)
+	require.NotNil(t, blob)
+	_, err = preset.BlobFromBytes(make([]byte, preset.BytesPerBlob()-1))
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidBlobLength)
+
+	cell, err := preset.CellFromBytes(make([]byte, preset.BytesPerCell()))
+	require.NoError(t, err)
+	require.NotNil(t, cell)
+	require.ErrorIs(t, preset.CheckCellLength(1), gokzg4844.ErrInvalidCellLength)
+}
+
+// Data of a preset with other sizes cannot be converted to the mainnet types.
+func TestPresetMismatch(t *testing.T) {
+	minimal := gokzg4844.Preset{Name: "minimal", ScalarsPerBlob: 4, ScalarsPerCell: 2}
+	cellsPerExtBlob, err := minimal.CellsPerExtBlob()
+	require.NoError(t, err)
+	require.Equal(t, 4, cellsPerExtBlob)
+
+	_, err = minimal.BlobFromBytes(make([]byte, minimal.BytesPerBlob()))
+	require.ErrorIs(t, err, gokzg4844.ErrPresetMismatch)
+	_, err = minimal.CellFromBytes(make([]byte, minimal.BytesPerCell()))
+	require.ErrorIs(t, err, gokzg4844.ErrPresetMismatch)
+
+	// A mainnet blob is not a minimal blob
+	_, err = minimal.BlobFromBytes(make([]byte, gokzg4844.MainnetPreset().BytesPerBlob()))
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidBlobLength)
+}
+
+func TestPresetCountLimits(t *testing.T) {
+	preset := gokzg4844.MainnetPreset()
+	require.NoError(t, preset.CheckBlobCount(0))
+	require.NoError(t, preset.CheckBlobCount(preset.MaxBlobCommitmentsPerBlock))
+	cellsPerExtBlob, err := preset.CellsPerExtBlob()
+	require.NoError(t, err)
+	require.NoError(t, preset.CheckCellCount(cellsPerExtBlob))
+
+	for _, err := range []error{
+		preset.CheckBlobCount(preset.MaxBlobCommitmentsPerBlock + 1),
+		preset.CheckBlobCount(-1),
+		preset.CheckCellCount(cellsPerExtBlob + 1),
+	} {
+		require.ErrorIs(t, err, gokzg4844.ErrCountLimitExceeded)
+		var limitErr *gokzg4844.CountLimitError
+		require.ErrorAs(t, err, &limitErr)
+	}
+}
+
+// The zero value of a preset has no cells, and reports an error rather than dividing by zero.
+func TestPresetZeroValue(t *testing.T) {
+	var preset gokzg4844.Preset
+	_, err := preset.CellsPerExtBlob()
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPreset)
+	require.ErrorIs(t, preset.CheckCellCount(0), gokzg4844.ErrInvalidPreset)
+}
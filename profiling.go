@@ -0,0 +1,43 @@
+//go:build gokzg_profiling
+
+package gokzg4844
+
+import (
+	"context"
+	"runtime/pprof"
+	"strconv"
+)
+
+// profiler attaches pprof labels to the current go-routine, so that CPU profiles attribute the time spent to the
+// operation and the phase of the operation that is running.
+//
+// The labels are inherited by any go-routine that is started while they are set.
+//
+// This is only compiled in when the gokzg_profiling build tag is set. Otherwise, every method is a no-op.
+type profiler struct {
+	// parent is the context of the caller, whose labels are restored by [profiler.end].
+	parent context.Context
+	ctx    context.Context
+}
+
+// startProfile labels the current go-routine with the name of the operation and its batch size, in addition to the
+// labels of ctx.
+//
+// Note: The labels of a go-routine cannot be read back, so [profiler.end] restores the labels of ctx, as
+// [pprof.Do] does. Callers which label their go-routines should use the methods which take a context, and pass the
+// context that holds their labels; the other methods restore an empty set of labels.
+func startProfile(ctx context.Context, op string, batchSize int) profiler {
+	labelled := pprof.WithLabels(ctx, pprof.Labels("gokzg_op", op, "gokzg_batch_size", strconv.Itoa(batchSize)))
+	pprof.SetGoroutineLabels(labelled)
+	return profiler{parent: ctx, ctx: labelled}
+}
+
+// phase labels the current go-routine with the phase of the operation that is about to run.
+func (p profiler) phase(name string) {
+	pprof.SetGoroutineLabels(pprof.WithLabels(p.ctx, pprof.Labels("gokzg_phase", name)))
+}
+
+// end restores the labels of the context that was given to [startProfile].
+func (p profiler) end() {
+	pprof.SetGoroutineLabels(p.parent)
+}
@@ -0,0 +1,16 @@
+//go:build !gokzg_profiling
+
+package gokzg4844
+
+import "context"
+
+// profiler is a no-op, since the gokzg_profiling build tag is not set.
+//
+// See profiling.go for the implementation which labels CPU profiles.
+type profiler struct{}
+
+func startProfile(context.Context, string, int) profiler { return profiler{} }
+
+func (profiler) phase(string) {}
+
+func (profiler) end() {}
@@ -0,0 +1,31 @@
+//go:build gokzg_profiling
+
+package gokzg4844
+
+import (
+	"context"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileLabels(t *testing.T) {
+	// The labels of the caller are kept, and restored when the operation ends
+	ctx := pprof.WithLabels(context.Background(), pprof.Labels("caller", "beacon"))
+	prof := startProfile(ctx, "verify_blob_kzg_proof_batch", 3)
+	defer prof.end()
+	require.Equal(t, ctx, prof.parent)
+
+	caller, ok := pprof.Label(prof.ctx, "caller")
+	require.True(t, ok)
+	require.Equal(t, "beacon", caller)
+
+	op, ok := pprof.Label(prof.ctx, "gokzg_op")
+	require.True(t, ok)
+	require.Equal(t, "verify_blob_kzg_proof_batch", op)
+
+	batchSize, ok := pprof.Label(prof.ctx, "gokzg_batch_size")
+	require.True(t, ok)
+	require.Equal(t, "3", batchSize)
+}
@@ -1,26 +1,57 @@
 package gokzg4844
 
 import (
+	"context"
+
 	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
 )
 
 // BlobToKZGCommitment implements [blob_to_kzg_commitment].
 //
 // numGoRoutines is used to configure the amount of concurrency needed. Setting this
-// value to a negative number or 0 will make it default to the number of CPUs.
+// value to a negative number or 0 will make it default to the value configured with
+// [WithNumGoRoutines], which itself defaults to the number of CPUs.
 //
 // [blob_to_kzg_commitment]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#blob_to_kzg_commitment
 func (c *Context) BlobToKZGCommitment(blob *Blob, numGoRoutines int) (KZGCommitment, error) {
+	return c.BlobToKZGCommitmentCtx(context.Background(), blob, numGoRoutines)
+}
+
+// BlobToKZGCommitmentCtx is the same as [Context.BlobToKZGCommitment], except that it stops and returns ctx.Err()
+// if ctx is cancelled.
+//
+// Cancellation is checked between the phases of the computation; a phase which has already started will run to
+// completion.
+func (c *Context) BlobToKZGCommitmentCtx(ctx context.Context, blob *Blob, numGoRoutines int) (KZGCommitment, error) {
+	if err := c.checkTypes(); err != nil {
+		return KZGCommitment{}, err
+	}
+	return c.blobToKZGCommitment(ctx, blob[:], numGoRoutines)
+}
+
+// blobToKZGCommitment implements [Context.BlobToKZGCommitmentCtx] for a blob of the preset of the context.
+func (c *Context) blobToKZGCommitment(ctx context.Context, blob []byte, numGoRoutines int) (KZGCommitment, error) {
+	acct := c.startAccounting(ctx, "blob_to_kzg_commitment", 1, len(blob))
+	defer acct.end()
+
+	prof := startProfile(ctx, "blob_to_kzg_commitment", 1)
+	defer prof.end()
+
 	// 1. Deserialization
 	//
 	// Deserialize blob into polynomial
-	polynomial, err := DeserializeBlob(blob)
+	prof.phase("deserialize")
+	polynomial, err := deserializeBlobBytesPar(blob, c.goRoutines(numGoRoutines))
 	if err != nil {
 		return KZGCommitment{}, err
 	}
 
 	// 2. Commit to polynomial
-	commitment, err := kzg.Commit(polynomial, c.commitKey, numGoRoutines)
+	if err := ctx.Err(); err != nil {
+		return KZGCommitment{}, err
+	}
+	prof.phase("commit")
+	commitment, err := kzg.Commit(polynomial, c.singleProofKey(), c.goRoutines(numGoRoutines))
 	if err != nil {
 		return KZGCommitment{}, err
 	}
@@ -40,13 +71,38 @@ func (c *Context) BlobToKZGCommitment(blob *Blob, numGoRoutines int) (KZGCommitm
 // commitment is a valid commitment. One should check this externally or call [Context.BlobToKZGCommitment].
 //
 // numGoRoutines is used to configure the amount of concurrency needed. Setting this
-// value to a negative number or 0 will make it default to the number of CPUs.
+// value to a negative number or 0 will make it default to the value configured with
+// [WithNumGoRoutines], which itself defaults to the number of CPUs.
 //
 // [compute_blob_kzg_proof]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#compute_blob_kzg_proof
 func (c *Context) ComputeBlobKZGProof(blob *Blob, blobCommitment KZGCommitment, numGoRoutines int) (KZGProof, error) {
+	return c.ComputeBlobKZGProofCtx(context.Background(), blob, blobCommitment, numGoRoutines)
+}
+
+// ComputeBlobKZGProofCtx is the same as [Context.ComputeBlobKZGProof], except that it stops and returns ctx.Err()
+// if ctx is cancelled.
+//
+// Cancellation is checked between the phases of the computation; a phase which has already started will run to
+// completion.
+func (c *Context) ComputeBlobKZGProofCtx(ctx context.Context, blob *Blob, blobCommitment KZGCommitment, numGoRoutines int) (KZGProof, error) {
+	if err := c.checkTypes(); err != nil {
+		return KZGProof{}, err
+	}
+	return c.computeBlobKZGProof(ctx, blob[:], blobCommitment, numGoRoutines)
+}
+
+// computeBlobKZGProof implements [Context.ComputeBlobKZGProofCtx] for a blob of the preset of the context.
+func (c *Context) computeBlobKZGProof(ctx context.Context, blob []byte, blobCommitment KZGCommitment, numGoRoutines int) (KZGProof, error) {
+	acct := c.startAccounting(ctx, "compute_blob_kzg_proof", 1, len(blob)+CompressedG1Size)
+	defer acct.end()
+
+	prof := startProfile(ctx, "compute_blob_kzg_proof", 1)
+	defer prof.end()
+
 	// 1. Deserialization
 	//
-	polynomial, err := DeserializeBlob(blob)
+	prof.phase("deserialize")
+	polynomial, err := deserializeBlobBytesPar(blob, c.goRoutines(numGoRoutines))
 	if err != nil {
 		return KZGProof{}, err
 	}
@@ -60,10 +116,15 @@ func (c *Context) ComputeBlobKZGProof(blob *Blob, blobCommitment KZGCommitment,
 	}
 
 	// 2. Compute Fiat-Shamir challenge
+	prof.phase("challenge")
 	evaluationChallenge := computeChallenge(blob, blobCommitment)
 
 	// 3. Create opening proof
-	openingProof, err := kzg.Open(c.domain, polynomial, evaluationChallenge, c.commitKey, numGoRoutines)
+	if err := ctx.Err(); err != nil {
+		return KZGProof{}, err
+	}
+	prof.phase("open")
+	openingProof, err := kzg.Open(c.domain(), polynomial, evaluationChallenge, c.singleProofKey(), c.goRoutines(numGoRoutines))
 	if err != nil {
 		return KZGProof{}, err
 	}
@@ -79,13 +140,38 @@ func (c *Context) ComputeBlobKZGProof(blob *Blob, blobCommitment KZGCommitment,
 // ComputeKZGProof implements [compute_kzg_proof].
 //
 // numGoRoutines is used to configure the amount of concurrency needed. Setting this
-// value to a negative number or 0 will make it default to the number of CPUs.
+// value to a negative number or 0 will make it default to the value configured with
+// [WithNumGoRoutines], which itself defaults to the number of CPUs.
 //
 // [compute_kzg_proof]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#compute_kzg_proof
 func (c *Context) ComputeKZGProof(blob *Blob, inputPointBytes Scalar, numGoRoutines int) (KZGProof, Scalar, error) {
+	return c.ComputeKZGProofCtx(context.Background(), blob, inputPointBytes, numGoRoutines)
+}
+
+// ComputeKZGProofCtx is the same as [Context.ComputeKZGProof], except that it stops and returns ctx.Err()
+// if ctx is cancelled.
+//
+// Cancellation is checked between the phases of the computation; a phase which has already started will run to
+// completion.
+func (c *Context) ComputeKZGProofCtx(ctx context.Context, blob *Blob, inputPointBytes Scalar, numGoRoutines int) (KZGProof, Scalar, error) {
+	if err := c.checkTypes(); err != nil {
+		return KZGProof{}, Scalar{}, err
+	}
+	return c.computeKZGProof(ctx, blob[:], inputPointBytes, numGoRoutines)
+}
+
+// computeKZGProof implements [Context.ComputeKZGProofCtx] for a blob of the preset of the context.
+func (c *Context) computeKZGProof(ctx context.Context, blob []byte, inputPointBytes Scalar, numGoRoutines int) (KZGProof, Scalar, error) {
+	acct := c.startAccounting(ctx, "compute_kzg_proof", 1, len(blob)+SerializedScalarSize)
+	defer acct.end()
+
+	prof := startProfile(ctx, "compute_kzg_proof", 1)
+	defer prof.end()
+
 	// 1. Deserialization
 	//
-	polynomial, err := DeserializeBlob(blob)
+	prof.phase("deserialize")
+	polynomial, err := deserializeBlobBytesPar(blob, c.goRoutines(numGoRoutines))
 	if err != nil {
 		return KZGProof{}, [32]byte{}, err
 	}
@@ -96,7 +182,11 @@ func (c *Context) ComputeKZGProof(blob *Blob, inputPointBytes Scalar, numGoRouti
 	}
 
 	// 2. Create opening proof
-	openingProof, err := kzg.Open(c.domain, polynomial, inputPoint, c.commitKey, numGoRoutines)
+	if err := ctx.Err(); err != nil {
+		return KZGProof{}, [32]byte{}, err
+	}
+	prof.phase("open")
+	openingProof, err := kzg.Open(c.domain(), polynomial, inputPoint, c.singleProofKey(), c.goRoutines(numGoRoutines))
 	if err != nil {
 		return KZGProof{}, [32]byte{}, err
 	}
@@ -0,0 +1,181 @@
+package gokzg4844
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+	"golang.org/x/sync/errgroup"
+)
+
+// BlobsToKZGCommitments computes the commitment to each of the blobs, as done by [Context.BlobToKZGCommitment].
+//
+// The i'th commitment in the returned slice is the commitment to the i'th blob. If any of the blobs is invalid, an
+// error is returned and no commitments are returned.
+//
+// The blobs are processed by a pool of workers, each of which reuses the memory for its deserialized polynomial.
+//
+// numGoRoutines is used to configure the amount of concurrency needed. Setting this
+// value to a negative number or 0 will make it default to the value configured with
+// [WithNumGoRoutines], which itself defaults to the number of CPUs.
+func (c *Context) BlobsToKZGCommitments(blobs []Blob, numGoRoutines int) ([]KZGCommitment, error) {
+	return c.BlobsToKZGCommitmentsCtx(context.Background(), blobs, numGoRoutines)
+}
+
+// BlobsToKZGCommitmentsCtx is the same as [Context.BlobsToKZGCommitments], except that it stops and returns
+// ctx.Err() if ctx is cancelled.
+//
+// Cancellation is checked before each blob is processed; blobs which are already being processed will run
+// to completion.
+func (c *Context) BlobsToKZGCommitmentsCtx(ctx context.Context, blobs []Blob, numGoRoutines int) ([]KZGCommitment, error) {
+	if err := c.checkTypes(); err != nil {
+		return nil, err
+	}
+	acct := c.startAccounting(ctx, "blobs_to_kzg_commitments", len(blobs), len(blobs)*len(Blob{}))
+	defer acct.end()
+
+	prof := startProfile(ctx, "blobs_to_kzg_commitments", len(blobs))
+	defer prof.end()
+
+	commitments := make([]KZGCommitment, len(blobs))
+
+	prof.phase("commit")
+	err := c.forEachBlob(ctx, blobs, numGoRoutines, func(i int, polynomial kzg.Polynomial, msmGoRoutines int) error {
+		commitment, err := kzg.Commit(polynomial, c.commitKey(), msmGoRoutines)
+		if err != nil {
+			return err
+		}
+		commitments[i] = KZGCommitment(SerializeG1Point(*commitment))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return commitments, nil
+}
+
+// ComputeBlobKZGProofs computes the proof for each of the blobs against its commitment, as done by
+// [Context.ComputeBlobKZGProof].
+//
+// The i'th proof in the returned slice is the proof for the i'th blob and the i'th commitment. If any of the
+// blobs or commitments is invalid, an error is returned and no proofs are returned.
+//
+// Note: As with [Context.ComputeBlobKZGProof], this method does not check that the commitments correspond to the
+// blobs.
+//
+// numGoRoutines is used to configure the amount of concurrency needed. Setting this
+// value to a negative number or 0 will make it default to the value configured with
+// [WithNumGoRoutines], which itself defaults to the number of CPUs.
+func (c *Context) ComputeBlobKZGProofs(blobs []Blob, commitments []KZGCommitment, numGoRoutines int) ([]KZGProof, error) {
+	return c.ComputeBlobKZGProofsCtx(context.Background(), blobs, commitments, numGoRoutines)
+}
+
+// ComputeBlobKZGProofsCtx is the same as [Context.ComputeBlobKZGProofs], except that it stops and returns
+// ctx.Err() if ctx is cancelled.
+//
+// Cancellation is checked before each blob is processed; blobs which are already being processed will run
+// to completion.
+func (c *Context) ComputeBlobKZGProofsCtx(ctx context.Context, blobs []Blob, commitments []KZGCommitment, numGoRoutines int) ([]KZGProof, error) {
+	if err := c.checkTypes(); err != nil {
+		return nil, err
+	}
+
+	// 1. Check that all components in the batch have the same size
+	if len(blobs) != len(commitments) {
+		return nil, ErrBatchLengthCheck
+	}
+
+	acct := c.startAccounting(ctx, "compute_blob_kzg_proofs", len(blobs), len(blobs)*(len(Blob{})+CompressedG1Size))
+	defer acct.end()
+
+	prof := startProfile(ctx, "compute_blob_kzg_proofs", len(blobs))
+	defer prof.end()
+
+	// 2. Deserialize the commitments
+	//
+	// We only do this to check if they are in the correct subgroup
+	for i := range commitments {
+		_, err := DeserializeKZGCommitment(commitments[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. Compute the proofs
+	prof.phase("open")
+	proofs := make([]KZGProof, len(blobs))
+	err := c.forEachBlob(ctx, blobs, numGoRoutines, func(i int, polynomial kzg.Polynomial, msmGoRoutines int) error {
+		evaluationChallenge := computeChallenge(blobs[i][:], commitments[i])
+
+		openingProof, err := kzg.Open(c.domain(), polynomial, evaluationChallenge, c.commitKey(), msmGoRoutines)
+		if err != nil {
+			return err
+		}
+		proofs[i] = KZGProof(SerializeG1Point(openingProof.QuotientCommitment))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proofs, nil
+}
+
+// forEachBlob deserializes each blob and calls work with its index and polynomial.
+//
+// The blobs are distributed over a pool of min(numGoRoutines, len(blobs)) workers, so the order in which work is
+// called is not deterministic. Callers must therefore write their outputs to the index that they are given, which
+// guarantees that the outputs are in the same order as the inputs.
+//
+// Each worker reuses a single polynomial, so work must not retain the polynomial after it returns. The go-routines
+// which are not needed for the pool are shared out between the workers, and work is told how many of them it can use.
+//
+// Returns the first error returned by work or by deserialization, or ctx.Err() if ctx is cancelled before all
+// of the blobs have been processed.
+func (c *Context) forEachBlob(ctx context.Context, blobs []Blob, numGoRoutines int, work func(i int, polynomial kzg.Polynomial, numGoRoutines int) error) error {
+	if err := c.checkTypes(); err != nil {
+		return err
+	}
+	if len(blobs) == 0 {
+		return nil
+	}
+
+	totalGoRoutines := c.goRoutines(numGoRoutines)
+	if totalGoRoutines <= 0 {
+		totalGoRoutines = runtime.NumCPU()
+	}
+	numWorkers := totalGoRoutines
+	if numWorkers > len(blobs) {
+		numWorkers = len(blobs)
+	}
+	workerGoRoutines := totalGoRoutines / numWorkers
+
+	var (
+		errG errgroup.Group
+		next int64 = -1
+	)
+	for w := 0; w < numWorkers; w++ {
+		errG.Go(func() error {
+			polynomial := make(kzg.Polynomial, ScalarsPerBlob)
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(blobs) {
+					return nil
+				}
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				if err := deserializeBlobInto(&blobs[i], polynomial); err != nil {
+					return err
+				}
+				if err := work(i, polynomial, workerGoRoutines); err != nil {
+					return err
+				}
+			}
+		})
+	}
+
+	return errG.Wait()
+}
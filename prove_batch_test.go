@@ -0,0 +1,93 @@
+package gokzg4844_test
+
+import (
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobsToKZGCommitments(t *testing.T) {
+	const numBlobs = 5
+	blobs := make([]gokzg4844.Blob, numBlobs)
+	for i := range blobs {
+		blobs[i] = *GetRandBlob(int64(i))
+	}
+
+	for _, numGoRoutines := range []int{0, 1, 2, 16} {
+		commitments, err := ctx.BlobsToKZGCommitments(blobs, numGoRoutines)
+		require.NoError(t, err)
+		require.Len(t, commitments, numBlobs)
+
+		for i := range blobs {
+			expected, err := ctx.BlobToKZGCommitment(&blobs[i], NumGoRoutines)
+			require.NoError(t, err)
+			require.Equal(t, expected, commitments[i])
+		}
+	}
+
+	commitments, err := ctx.BlobsToKZGCommitments(nil, NumGoRoutines)
+	require.NoError(t, err)
+	require.Empty(t, commitments)
+
+	modifyBlob(&blobs[3], nonCanonicalScalar(1), 0)
+	_, err = ctx.BlobsToKZGCommitments(blobs, NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrNonCanonicalScalar)
+}
+
+func TestComputeBlobKZGProofs(t *testing.T) {
+	const numBlobs = 5
+	blobs := make([]gokzg4844.Blob, numBlobs)
+	for i := range blobs {
+		blobs[i] = *GetRandBlob(int64(i))
+	}
+	commitments, err := ctx.BlobsToKZGCommitments(blobs, NumGoRoutines)
+	require.NoError(t, err)
+
+	proofs, err := ctx.ComputeBlobKZGProofs(blobs, commitments, NumGoRoutines)
+	require.NoError(t, err)
+	require.Len(t, proofs, numBlobs)
+	for i := range blobs {
+		expected, err := ctx.ComputeBlobKZGProof(&blobs[i], commitments[i], NumGoRoutines)
+		require.NoError(t, err)
+		require.Equal(t, expected, proofs[i])
+	}
+
+	err = ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	require.NoError(t, err)
+
+	_, err = ctx.ComputeBlobKZGProofs(blobs, commitments[1:], NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrBatchLengthCheck)
+
+	commitments[2] = gokzg4844.KZGCommitment{}
+	_, err = ctx.ComputeBlobKZGProofs(blobs, commitments, NumGoRoutines)
+	require.Error(t, err, "expected an error since the commitment is not a valid point")
+}
+
+// TestBatchOutputOrdering checks that the outputs of the batch methods are in the same order as their
+// inputs, even when the number of go-routines is much larger than the number of inputs.
+func TestBatchOutputOrdering(t *testing.T) {
+	const numBlobs = 9
+	blobs := make([]gokzg4844.Blob, numBlobs)
+	expectedCommitments := make([]gokzg4844.KZGCommitment, numBlobs)
+	expectedProofs := make([]gokzg4844.KZGProof, numBlobs)
+	for i := range blobs {
+		blobs[i] = *GetRandBlob(int64(i))
+
+		var err error
+		expectedCommitments[i], err = ctx.BlobToKZGCommitment(&blobs[i], NumGoRoutines)
+		require.NoError(t, err)
+		expectedProofs[i], err = ctx.ComputeBlobKZGProof(&blobs[i], expectedCommitments[i], NumGoRoutines)
+		require.NoError(t, err)
+	}
+
+	for _, numGoRoutines := range []int{1, 3, numBlobs, 64, 1023} {
+		commitments, err := ctx.BlobsToKZGCommitments(blobs, numGoRoutines)
+		require.NoError(t, err)
+		require.Equal(t, expectedCommitments, commitments, "commitments are out of order with %d go-routines", numGoRoutines)
+
+		proofs, err := ctx.ComputeBlobKZGProofs(blobs, commitments, numGoRoutines)
+		require.NoError(t, err)
+		require.Equal(t, expectedProofs, proofs, "proofs are out of order with %d go-routines", numGoRoutines)
+	}
+}
@@ -0,0 +1,221 @@
+package gokzg4844
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"hash"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// ReceiptOp identifies the operation that a [VerificationReceipt] was created for.
+type ReceiptOp string
+
+const (
+	// ReceiptOpVerifyKZGProof is the operation of [Context.VerifyKZGProofWithReceipt].
+	ReceiptOpVerifyKZGProof ReceiptOp = "verify_kzg_proof"
+	// ReceiptOpVerifyBlobKZGProof is the operation of [Context.VerifyBlobKZGProofWithReceipt].
+	ReceiptOpVerifyBlobKZGProof ReceiptOp = "verify_blob_kzg_proof"
+	// ReceiptOpVerifyBlobKZGProofBatch is the operation of [Context.VerifyBlobKZGProofBatchWithReceipt].
+	ReceiptOpVerifyBlobKZGProofBatch ReceiptOp = "verify_blob_kzg_proof_batch"
+)
+
+// setupDigestDomain is the domain separator used when hashing a trusted setup.
+const setupDigestDomain = "GOKZG_SETUP_V1__"
+
+// receiptInputsDomain is the domain separator used when hashing the inputs of a verification.
+const receiptInputsDomain = "GOKZG_RECEIPT_V1"
+
+// VerificationReceipt is a record of a successful verification.
+//
+// It identifies what was verified, by a hash of the inputs, and how it was verified, by a digest of the trusted
+// setup and the version of this library. It is meant to be stored in audit logs and later checked against the
+// inputs with [Context.CheckReceipt].
+//
+// Note: A receipt is not signed. It shows what a verification was run on, but anyone can create one.
+type VerificationReceipt struct {
+	// SetupDigest identifies the trusted setup that was used.
+	SetupDigest [32]byte `json:"setup_digest"`
+	// Op is the operation that was run.
+	Op ReceiptOp `json:"op"`
+	// InputsHash is the hash of the inputs to the operation.
+	InputsHash [32]byte `json:"inputs_hash"`
+	// Timestamp is the time at which the verification succeeded.
+	Timestamp time.Time `json:"timestamp"`
+	// LibraryVersion is the module version of this library, as recorded in the build information of the binary.
+	LibraryVersion string `json:"library_version"`
+}
+
+// ReceiptInputs are the inputs of a verification that are recorded in a [VerificationReceipt].
+//
+// Only the fields used by the operation should be set:
+//   - [ReceiptOpVerifyKZGProof] uses one commitment, input point, claimed value and proof.
+//   - [ReceiptOpVerifyBlobKZGProof] uses one blob, commitment and proof.
+//   - [ReceiptOpVerifyBlobKZGProofBatch] uses a blob, commitment and proof for each element in the batch.
+//
+// The blobs are referenced rather than copied, and must not be nil.
+type ReceiptInputs struct {
+	Blobs         []*Blob
+	Commitments   []KZGCommitment
+	Proofs        []KZGProof
+	InputPoints   []Scalar
+	ClaimedValues []Scalar
+}
+
+// VerifyKZGProofWithReceipt is the same as [Context.VerifyKZGProof], except that it returns a receipt if the
+// proof is valid.
+func (c *Context) VerifyKZGProofWithReceipt(blobCommitment KZGCommitment, inputPointBytes, claimedValueBytes Scalar, kzgProof KZGProof) (*VerificationReceipt, error) {
+	err := c.VerifyKZGProof(blobCommitment, inputPointBytes, claimedValueBytes, kzgProof)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newReceipt(ReceiptOpVerifyKZGProof, &ReceiptInputs{
+		Commitments:   []KZGCommitment{blobCommitment},
+		Proofs:        []KZGProof{kzgProof},
+		InputPoints:   []Scalar{inputPointBytes},
+		ClaimedValues: []Scalar{claimedValueBytes},
+	}), nil
+}
+
+// VerifyBlobKZGProofWithReceipt is the same as [Context.VerifyBlobKZGProof], except that it returns a receipt if the
+// proof is valid.
+func (c *Context) VerifyBlobKZGProofWithReceipt(blob *Blob, blobCommitment KZGCommitment, kzgProof KZGProof) (*VerificationReceipt, error) {
+	err := c.VerifyBlobKZGProof(blob, blobCommitment, kzgProof)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newReceipt(ReceiptOpVerifyBlobKZGProof, &ReceiptInputs{
+		Blobs:       []*Blob{blob},
+		Commitments: []KZGCommitment{blobCommitment},
+		Proofs:      []KZGProof{kzgProof},
+	}), nil
+}
+
+// VerifyBlobKZGProofBatchWithReceipt is the same as [Context.VerifyBlobKZGProofBatch], except that it returns a
+// receipt if all of the proofs are valid.
+func (c *Context) VerifyBlobKZGProofBatchWithReceipt(blobs []Blob, polynomialCommitments []KZGCommitment, kzgProofs []KZGProof) (*VerificationReceipt, error) {
+	err := c.VerifyBlobKZGProofBatch(blobs, polynomialCommitments, kzgProofs)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newReceipt(ReceiptOpVerifyBlobKZGProofBatch, &ReceiptInputs{
+		Blobs:       blobPointers(blobs),
+		Commitments: polynomialCommitments,
+		Proofs:      kzgProofs,
+	}), nil
+}
+
+// CheckReceipt checks that the receipt was created by a context with the same trusted setup as this one, for the
+// given inputs.
+//
+// This does not verify the proofs again. Returns [ErrReceiptMismatch] if the receipt does not match.
+func (c *Context) CheckReceipt(receipt *VerificationReceipt, inputs *ReceiptInputs) error {
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(receipt.SetupDigest[:], c.setupDigest[:]) != 1 {
+		return ErrReceiptMismatch
+	}
+	inputsHash := hashReceiptInputs(receipt.Op, inputs)
+	if subtle.ConstantTimeCompare(receipt.InputsHash[:], inputsHash[:]) != 1 {
+		return ErrReceiptMismatch
+	}
+	return nil
+}
+
+// newReceipt returns a receipt for a successful verification of the inputs with the given operation.
+func (c *Context) newReceipt(op ReceiptOp, inputs *ReceiptInputs) *VerificationReceipt {
+	return &VerificationReceipt{
+		SetupDigest:    c.setupDigest,
+		Op:             op,
+		InputsHash:     hashReceiptInputs(op, inputs),
+		Timestamp:      time.Now().UTC(),
+		LibraryVersion: libraryVersion(),
+	}
+}
+
+// hashReceiptInputs hashes the operation and its inputs. Each list is prefixed with its length, so that the
+// boundaries between the lists are unambiguous.
+func hashReceiptInputs(op ReceiptOp, inputs *ReceiptInputs) [32]byte {
+	h := sha256.New()
+	h.Write([]byte(receiptInputsDomain))
+	writeLengthPrefixed(h, []byte(op))
+
+	writeLength(h, len(inputs.Blobs))
+	for i := range inputs.Blobs {
+		h.Write(inputs.Blobs[i][:])
+	}
+	writeLength(h, len(inputs.Commitments))
+	for i := range inputs.Commitments {
+		h.Write(inputs.Commitments[i][:])
+	}
+	writeLength(h, len(inputs.Proofs))
+	for i := range inputs.Proofs {
+		h.Write(inputs.Proofs[i][:])
+	}
+	writeLength(h, len(inputs.InputPoints))
+	for i := range inputs.InputPoints {
+		h.Write(inputs.InputPoints[i][:])
+	}
+	writeLength(h, len(inputs.ClaimedValues))
+	for i := range inputs.ClaimedValues {
+		h.Write(inputs.ClaimedValues[i][:])
+	}
+
+	var digest [32]byte
+	copy(digest[:], h.Sum(nil))
+	return digest
+}
+
+// writeLength writes the length as a big-endian uint64 into the hasher.
+func writeLength(h hash.Hash, length int) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(length))
+	h.Write(buf[:])
+}
+
+// writeLengthPrefixed writes the length of the data, followed by the data, into the hasher.
+func writeLengthPrefixed(h hash.Hash, data []byte) {
+	writeLength(h, len(data))
+	h.Write(data)
+}
+
+// modulePath is the path of this module, used to find its version in the build information.
+const modulePath = "github.com/crate-crypto/go-kzg-4844"
+
+var (
+	libraryVersionOnce sync.Once
+	libraryVersionStr  string
+)
+
+// libraryVersion returns the version of this module that the binary was built with, or "unknown" if the build
+// information is not available.
+func libraryVersion() string {
+	libraryVersionOnce.Do(func() {
+		libraryVersionStr = "unknown"
+
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		if info.Main.Path == modulePath {
+			libraryVersionStr = info.Main.Version
+			return
+		}
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				libraryVersionStr = dep.Version
+				if dep.Replace != nil && dep.Replace.Version != "" {
+					libraryVersionStr = dep.Replace.Version
+				}
+				return
+			}
+		}
+	})
+	return libraryVersionStr
+}
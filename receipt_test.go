@@ -0,0 +1,51 @@
+package gokzg4844_test
+
+import (
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerificationReceipt(t *testing.T) {
+	blob := GetRandBlob(123)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	proof, err := ctx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+
+	receipt, err := ctx.VerifyBlobKZGProofWithReceipt(blob, commitment, proof)
+	require.NoError(t, err)
+	require.Equal(t, gokzg4844.ReceiptOpVerifyBlobKZGProof, receipt.Op)
+	require.NotEmpty(t, receipt.LibraryVersion)
+
+	inputs := &gokzg4844.ReceiptInputs{
+		Blobs:       []*gokzg4844.Blob{blob},
+		Commitments: []gokzg4844.KZGCommitment{commitment},
+		Proofs:      []gokzg4844.KZGProof{proof},
+	}
+	require.NoError(t, ctx.CheckReceipt(receipt, inputs))
+
+	// The batch receipt with the same inputs is for a different operation
+	batchReceipt, err := ctx.VerifyBlobKZGProofBatchWithReceipt([]gokzg4844.Blob{*blob}, inputs.Commitments, inputs.Proofs)
+	require.NoError(t, err)
+	require.NoError(t, ctx.CheckReceipt(batchReceipt, inputs))
+	require.NotEqual(t, receipt.InputsHash, batchReceipt.InputsHash)
+	require.Equal(t, receipt.SetupDigest, batchReceipt.SetupDigest)
+
+	otherInputs := &gokzg4844.ReceiptInputs{
+		Blobs:       []*gokzg4844.Blob{GetRandBlob(456)},
+		Commitments: []gokzg4844.KZGCommitment{commitment},
+		Proofs:      []gokzg4844.KZGProof{proof},
+	}
+	require.ErrorIs(t, ctx.CheckReceipt(receipt, otherInputs), gokzg4844.ErrReceiptMismatch)
+
+	otherSetupReceipt := *receipt
+	otherSetupReceipt.SetupDigest[0] ^= 1
+	require.ErrorIs(t, ctx.CheckReceipt(&otherSetupReceipt, inputs), gokzg4844.ErrReceiptMismatch)
+
+	// No receipt is returned for an invalid proof
+	receipt, err = ctx.VerifyBlobKZGProofWithReceipt(GetRandBlob(456), commitment, proof)
+	require.Error(t, err)
+	require.Nil(t, receipt)
+}
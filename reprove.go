@@ -0,0 +1,209 @@
+package gokzg4844
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// ProofKind is a kind of proof that [Context.ReproveArchive] can compute.
+type ProofKind int
+
+const (
+	// ProofKindBlob is the single proof for a blob, as computed by [Context.ComputeBlobKZGProof].
+	ProofKindBlob ProofKind = iota
+	// ProofKindCell is a proof for each cell of the extended blob, in the order of the cells returned by
+	// [Context.ComputeCells]. Each proof is the multi-point proof for the points of the cell, as computed by
+	// [Context.ComputeMultiPointKZGProof].
+	//
+	// Note: The proofs are computed one cell at a time rather than with FK20, so this takes about as long as
+	// [CellsPerExtBlob] calls to [Context.ComputeBlobKZGProof] for each blob.
+	ProofKindCell
+)
+
+// BlobIterator supplies the blobs that [Context.ReproveArchive] computes proofs for.
+//
+// Next returns the next blob, or io.EOF once there are no more blobs. Any other error stops the computation. Next is
+// never called concurrently. A blob is copied before Next is called again, so its memory can be reused.
+type BlobIterator interface {
+	Next() (*Blob, error)
+}
+
+// ReproveResult holds the commitment and proofs that [Context.ReproveArchive] computed for a blob.
+type ReproveResult struct {
+	// Index is the position of the blob, counted from the start of the iterator.
+	Index uint64
+	// Commitment is the commitment to the blob.
+	Commitment KZGCommitment
+	// Proofs holds the proofs of the requested kind. For [ProofKindBlob], this is a single proof, and for
+	// [ProofKindCell], it is a proof for each of the [CellsPerExtBlob] cells.
+	Proofs []KZGProof
+}
+
+// ReproveProgress reports how far [Context.ReproveArchive] has got.
+type ReproveProgress struct {
+	// Reproved is the number of blobs, counted from the start of the iterator, whose results have been emitted.
+	Reproved uint64
+	// Elapsed is the wall-clock time since [Context.ReproveArchive] was called.
+	Elapsed time.Duration
+}
+
+// Throughput returns the number of blobs reproved per second.
+func (p ReproveProgress) Throughput() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Reproved) / p.Elapsed.Seconds()
+}
+
+// ReproveArchive computes the commitment and the proofs of the target kind for every blob from the iterator, for
+// example to migrate an archive of blobs to a new proof format.
+//
+// Blobs are read in batches of one per worker, which are proved in parallel as done by
+// [Context.ComputeBlobKZGProofs]. emit is called with the result for each blob, in the order of the iterator, and
+// is never called concurrently. Since the results are emitted in order, emit can record the index of the last result
+// that it has stored as a checkpoint; to resume after an interruption, create an iterator which starts after it.
+//
+// workers is the number of blobs that are proved in parallel. Setting this value to a negative number or 0 will make
+// it default to the value configured with [WithNumGoRoutines], which itself defaults to the number of CPUs.
+//
+// Returns [ErrUnsupportedProofKind] if the target kind cannot be computed. Otherwise, ReproveArchive stops if a blob
+// is invalid, if the iterator returns an error other than io.EOF, if emit returns an error or if ctx is cancelled.
+// In all of these cases, the returned progress covers the results which were emitted before stopping.
+func (c *Context) ReproveArchive(ctx context.Context, it BlobIterator, target ProofKind, workers int, emit func(ReproveResult) error) (ReproveProgress, error) {
+	if err := c.checkOpen(); err != nil {
+		return ReproveProgress{}, err
+	}
+	var progress ReproveProgress
+	start := time.Now()
+
+	var cellPoints [][]fr.Element
+	switch target {
+	case ProofKindBlob:
+	case ProofKindCell:
+		cellPoints = c.cellPoints()
+	default:
+		return progress, ErrUnsupportedProofKind
+	}
+
+	workers = c.goRoutines(workers)
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	var (
+		blobs       = make([]Blob, workers)
+		commitments = make([]KZGCommitment, workers)
+		proofs      = make([][]KZGProof, workers)
+	)
+	for {
+		// 1. Read a batch of blobs
+		//
+		n, done, err := readBlobs(it, blobs)
+		if err != nil {
+			progress.Elapsed = time.Since(start)
+			return progress, err
+		}
+
+		// 2. Compute the commitments and proofs
+		//
+		prof := startProfile(ctx, "reprove_archive_batch", n)
+		err = c.forEachBlob(ctx, blobs[:n], workers, func(i int, polynomial kzg.Polynomial, msmGoRoutines int) error {
+			commitment, err := kzg.Commit(polynomial, c.commitKey(), msmGoRoutines)
+			if err != nil {
+				return err
+			}
+			commitments[i] = KZGCommitment(SerializeG1Point(*commitment))
+
+			if target == ProofKindCell {
+				proofs[i], err = c.computeCellProofs(polynomial, cellPoints, msmGoRoutines)
+				return err
+			}
+			evaluationChallenge := computeChallenge(blobs[i][:], commitments[i])
+			openingProof, err := kzg.Open(c.domain(), polynomial, evaluationChallenge, c.commitKey(), msmGoRoutines)
+			if err != nil {
+				return err
+			}
+			proofs[i] = []KZGProof{KZGProof(SerializeG1Point(openingProof.QuotientCommitment))}
+			return nil
+		})
+		prof.end()
+		if err != nil {
+			progress.Elapsed = time.Since(start)
+			return progress, fmt.Errorf("batch starting at blob %d: %w", progress.Reproved, err)
+		}
+
+		// 3. Emit the results in order
+		//
+		for i := 0; i < n; i++ {
+			result := ReproveResult{
+				Index:      progress.Reproved,
+				Commitment: commitments[i],
+				Proofs:     proofs[i],
+			}
+			if err := emit(result); err != nil {
+				progress.Elapsed = time.Since(start)
+				return progress, err
+			}
+			progress.Reproved++
+		}
+
+		if done {
+			progress.Elapsed = time.Since(start)
+			return progress, nil
+		}
+	}
+}
+
+// cellPoints returns the points of each cell of the extended blob. The extended blob holds the evaluations over the
+// domain followed by the evaluations over the extension coset, each in the order of the context.
+func (c *Context) cellPoints() [][]fr.Element {
+	roots := c.domain().Roots
+	coset := make([]fr.Element, len(roots))
+	for i := range roots {
+		coset[i].Mul(&roots[i], &c.extensionCoset().shift)
+	}
+	points := append(append([]fr.Element{}, roots...), coset...)
+
+	cells := make([][]fr.Element, CellsPerExtBlob)
+	for i := range cells {
+		cells[i] = points[i*ScalarsPerCell : (i+1)*ScalarsPerCell]
+	}
+	return cells
+}
+
+// computeCellProofs returns the multi-point proof for each cell of the extension of the polynomial, given the points
+// of each cell.
+func (c *Context) computeCellProofs(polynomial kzg.Polynomial, cellPoints [][]fr.Element, numGoRoutines int) ([]KZGProof, error) {
+	coeffs := c.domain().IfftFr(c.toNaturalOrder(polynomial))
+	proofs := make([]KZGProof, len(cellPoints))
+	for i, points := range cellPoints {
+		proof, err := c.openMultiPoint(coeffs, points, numGoRoutines)
+		if err != nil {
+			return nil, err
+		}
+		proofs[i] = KZGProof(SerializeG1Point(*proof))
+	}
+	return proofs, nil
+}
+
+// readBlobs copies up to len(blobs) blobs from the iterator into blobs. It returns the number of blobs which were
+// read, and whether the iterator has been exhausted.
+func readBlobs(it BlobIterator, blobs []Blob) (int, bool, error) {
+	for i := range blobs {
+		blob, err := it.Next()
+		if err == io.EOF {
+			return i, true, nil
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		blobs[i] = *blob
+	}
+	return len(blobs), false, nil
+}
@@ -0,0 +1,128 @@
+package gokzg4844_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"math/bits"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+// blobIterator is a BlobIterator over a slice of blobs.
+type blobIterator struct {
+	blobs []*gokzg4844.Blob
+	next  int
+}
+
+func (it *blobIterator) Next() (*gokzg4844.Blob, error) {
+	if it.next == len(it.blobs) {
+		return nil, io.EOF
+	}
+	blob := it.blobs[it.next]
+	it.next++
+	return blob, nil
+}
+
+func TestReproveArchive(t *testing.T) {
+	blobs := make([]*gokzg4844.Blob, 5)
+	for i := range blobs {
+		blobs[i] = GetRandBlob(int64(i))
+	}
+
+	var results []gokzg4844.ReproveResult
+	progress, err := ctx.ReproveArchive(context.Background(), &blobIterator{blobs: blobs}, gokzg4844.ProofKindBlob, 2, func(result gokzg4844.ReproveResult) error {
+		results = append(results, result)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(blobs)), progress.Reproved)
+	require.Positive(t, progress.Throughput())
+
+	// The results are emitted in order, and match the single blob methods
+	require.Len(t, results, len(blobs))
+	for i, result := range results {
+		require.Equal(t, uint64(i), result.Index)
+		commitment, err := ctx.BlobToKZGCommitment(blobs[i], NumGoRoutines)
+		require.NoError(t, err)
+		require.Equal(t, commitment, result.Commitment)
+		require.Len(t, result.Proofs, 1)
+		require.NoError(t, ctx.VerifyBlobKZGProof(blobs[i], result.Commitment, result.Proofs[0]))
+	}
+}
+
+// The points of the i'th cell are h * w^j, where w is a primitive ScalarsPerCell'th root of unity and h is a power of
+// a primitive ScalarsPerExtBlob'th root of unity given by the bit-reversal of i. The vanishing polynomial of the cell
+// is therefore X^ScalarsPerCell - h^ScalarsPerCell, which the quotient is computed by directly.
+func TestReproveArchiveCells(t *testing.T) {
+	blob := GetRandBlob(7)
+	var results []gokzg4844.ReproveResult
+	_, err := ctx.ReproveArchive(context.Background(), &blobIterator{blobs: []*gokzg4844.Blob{blob}}, gokzg4844.ProofKindCell, 1, func(result gokzg4844.ReproveResult) error {
+		results = append(results, result)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Len(t, results[0].Proofs, gokzg4844.CellsPerExtBlob)
+
+	domain, err := gokzg4844.NewDomain(gokzg4844.ScalarsPerBlob)
+	require.NoError(t, err)
+	polynomial, err := gokzg4844.DeserializeBlob(blob)
+	require.NoError(t, err)
+	coeffs, err := domain.IFFT(polynomial, gokzg4844.BitReversedOrder)
+	require.NoError(t, err)
+	extDomain, err := gokzg4844.NewDomain(gokzg4844.ScalarsPerExtBlob)
+	require.NoError(t, err)
+	generator := extDomain.Generator()
+
+	for i, proof := range results[0].Proofs {
+		var c fr.Element
+		exponent := uint64(bits.Reverse8(uint8(i))>>1) * gokzg4844.ScalarsPerCell
+		c.Exp(generator, new(big.Int).SetUint64(exponent))
+
+		quotient := make([]fr.Element, len(coeffs))
+		for k := len(coeffs) - 1; k >= gokzg4844.ScalarsPerCell; k-- {
+			var term fr.Element
+			term.Mul(&c, &quotient[k])
+			quotient[k-gokzg4844.ScalarsPerCell].Add(&coeffs[k], &term)
+		}
+		expected, err := ctx.CommitMonomial(quotient, NumGoRoutines)
+		require.NoError(t, err)
+		require.Equal(t, gokzg4844.KZGProof(expected), proof, "cell %d", i)
+	}
+}
+
+func TestReproveArchiveStops(t *testing.T) {
+	blobs := make([]*gokzg4844.Blob, 4)
+	for i := range blobs {
+		blobs[i] = GetRandBlob(int64(i))
+	}
+	noop := func(gokzg4844.ReproveResult) error { return nil }
+
+	// An unknown proof kind is not supported
+	_, err := ctx.ReproveArchive(context.Background(), &blobIterator{blobs: blobs}, gokzg4844.ProofKindCell+1, 2, noop)
+	require.ErrorIs(t, err, gokzg4844.ErrUnsupportedProofKind)
+
+	// An error from emit stops after the results that were already emitted
+	errStop := errors.New("stop")
+	progress, err := ctx.ReproveArchive(context.Background(), &blobIterator{blobs: blobs}, gokzg4844.ProofKindBlob, 2, func(result gokzg4844.ReproveResult) error {
+		if result.Index == 2 {
+			return errStop
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, uint64(2), progress.Reproved)
+
+	// An invalid blob
+	invalid := *blobs[3]
+	modifyBlob(&invalid, nonCanonicalScalar(1), 0)
+	blobs[3] = &invalid
+	progress, err = ctx.ReproveArchive(context.Background(), &blobIterator{blobs: blobs}, gokzg4844.ProofKindBlob, 2, noop)
+	require.ErrorIs(t, err, gokzg4844.ErrNonCanonicalScalar)
+	require.Equal(t, uint64(2), progress.Reproved)
+}
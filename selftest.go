@@ -0,0 +1,124 @@
+package gokzg4844
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sync"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/multiexp"
+)
+
+// SelfTestError is returned when one of the known-answer checks of [SelfTest] fails.
+//
+// errors.Is matches it with [ErrSelfTestFailed].
+type SelfTestError struct {
+	// Check is the name of the check which failed.
+	Check string
+}
+
+func (e *SelfTestError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrSelfTestFailed, e.Check)
+}
+
+// Unwrap returns [ErrSelfTestFailed].
+func (e *SelfTestError) Unwrap() error {
+	return ErrSelfTestFailed
+}
+
+// Known answers for the self-test. These were computed with gnark-crypto v0.13.0.
+const (
+	// selfTestMSM is the compressed result of the multi-scalar multiplication in selfTestMultiExp.
+	selfTestMSM = "a26b9e5ce5d0f305735e51d2a8f8ecb25d16513059e6b3f96c3590d069e74483dc1f7a9526f082c797b58779cd377e2d"
+	// selfTestPairing is the SHA256 hash of the serialization of e([3]G1, [5]G2).
+	selfTestPairing = "140ff2aa98b9fc1e25900b1af0c74e242f603919b72910a52f3f6e41b6ce363e"
+)
+
+var (
+	selfTestOnce sync.Once
+	selfTestErr  error
+)
+
+// SelfTest checks that the elliptic curve arithmetic that this library relies on gives the expected results, by
+// comparing a fixed multi-scalar multiplication and a fixed pairing against known answers.
+//
+// This catches a dependency resolution which pulls in a version of gnark-crypto whose arithmetic differs, which would
+// otherwise only show up as invalid commitments and proofs. The context constructors run it, and fail with its error,
+// so it does not need to be called explicitly; it is exported so that it can be run as part of a health check.
+//
+// The checks are only run once per process, which takes about a millisecond; later calls return the same result.
+// Returns a [*SelfTestError] if a check fails.
+func SelfTest() error {
+	selfTestOnce.Do(func() {
+		selfTestErr = runSelfTest()
+	})
+	return selfTestErr
+}
+
+// runSelfTest runs each of the known-answer checks.
+func runSelfTest() error {
+	_, _, genG1, genG2 := bls12381.Generators()
+
+	// 1. Multi-scalar multiplication, as used to compute commitments and proofs
+	//
+	msm, err := selfTestMultiExp(genG1)
+	if err != nil {
+		return &SelfTestError{Check: "multi-scalar multiplication: " + err.Error()}
+	}
+	msmBytes := msm.Bytes()
+	if hex.EncodeToString(msmBytes[:]) != selfTestMSM {
+		return &SelfTestError{Check: "multi-scalar multiplication"}
+	}
+
+	// 2. Point decompression, as used to deserialize commitments and proofs
+	//
+	var decompressed bls12381.G1Affine
+	if _, err := decompressed.SetBytes(msmBytes[:]); err != nil || !decompressed.Equal(msm) {
+		return &SelfTestError{Check: "point decompression"}
+	}
+
+	// 3. Pairing
+	//
+	var p bls12381.G1Affine
+	var q bls12381.G2Affine
+	p.ScalarMultiplication(&genG1, big.NewInt(3))
+	q.ScalarMultiplication(&genG2, big.NewInt(5))
+	pairing, err := bls12381.Pair([]bls12381.G1Affine{p}, []bls12381.G2Affine{q})
+	if err != nil {
+		return &SelfTestError{Check: "pairing: " + err.Error()}
+	}
+	pairingBytes := pairing.Bytes()
+	pairingHash := sha256.Sum256(pairingBytes[:])
+	want, _ := hex.DecodeString(selfTestPairing)
+	if !bytes.Equal(pairingHash[:], want) {
+		return &SelfTestError{Check: "pairing"}
+	}
+
+	// 4. Pairing check, as used to verify proofs: e([3]G1, [5]G2) * e([-15]G1, G2) == 1
+	//
+	var r bls12381.G1Affine
+	r.ScalarMultiplication(&genG1, big.NewInt(-15))
+	ok, err := bls12381.PairingCheck([]bls12381.G1Affine{p, r}, []bls12381.G2Affine{q, genG2})
+	if err != nil || !ok {
+		return &SelfTestError{Check: "pairing check"}
+	}
+
+	return nil
+}
+
+// selfTestMultiExp computes sum_i -1000003*(i+1) * [i+2]G for i in [0, 8).
+func selfTestMultiExp(genG1 bls12381.G1Affine) (*bls12381.G1Affine, error) {
+	const n = 8
+	points := make([]bls12381.G1Affine, n)
+	scalars := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		points[i].ScalarMultiplication(&genG1, big.NewInt(int64(i+2)))
+		scalars[i].SetUint64(uint64(1000003 * (i + 1)))
+		scalars[i].Neg(&scalars[i])
+	}
+	return multiexp.MultiExp(scalars, points, 0)
+}
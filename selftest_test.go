@@ -0,0 +1,46 @@
+package gokzg4844
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfTest(t *testing.T) {
+	require.NoError(t, SelfTest())
+	require.NoError(t, runSelfTest())
+}
+
+// The known answer for the multi-scalar multiplication is the generator multiplied by the sum of the products of the
+// scalars, which is computed here without a multi-scalar multiplication.
+func TestSelfTestMSMKnownAnswer(t *testing.T) {
+	_, _, genG1, _ := bls12381.Generators()
+
+	var sum fr.Element
+	for i := 0; i < 8; i++ {
+		var term fr.Element
+		term.SetUint64(uint64(1000003 * (i + 1) * (i + 2)))
+		sum.Sub(&sum, &term)
+	}
+	var sumBI big.Int
+	sum.BigInt(&sumBI)
+	var expected bls12381.G1Affine
+	expected.ScalarMultiplication(&genG1, &sumBI)
+
+	msm, err := selfTestMultiExp(genG1)
+	require.NoError(t, err)
+	require.True(t, expected.Equal(msm))
+}
+
+func TestSelfTestError(t *testing.T) {
+	var err error = &SelfTestError{Check: "pairing"}
+	require.ErrorIs(t, err, ErrSelfTestFailed)
+
+	var selfTestErr *SelfTestError
+	require.True(t, errors.As(err, &selfTestErr))
+	require.Equal(t, "pairing", selfTestErr.Check)
+}
@@ -1,10 +1,17 @@
 package gokzg4844
 
 import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"runtime"
+
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
 	"github.com/crate-crypto/go-kzg-4844/internal/utils"
+	"golang.org/x/sync/errgroup"
 )
 
 // CompressedG1Size is the number of bytes needed to represent a group element in G1 when compressed.
@@ -32,6 +39,34 @@ const SerializedScalarSize = 32
 // [FIELD_ELEMENTS_PER_BLOB]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#blob
 const ScalarsPerBlob = 4096
 
+// ScalarsPerExtBlob is the number of scalars in a blob after it has been extended with the Reed-Solomon code.
+//
+// It matches [FIELD_ELEMENTS_PER_EXT_BLOB] in the spec.
+//
+// [FIELD_ELEMENTS_PER_EXT_BLOB]: https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/polynomial-commitments-sampling.md#preset
+const ScalarsPerExtBlob = 2 * ScalarsPerBlob
+
+// ScalarsPerCell is the number of serialized scalars in a cell.
+//
+// It matches [FIELD_ELEMENTS_PER_CELL] in the spec.
+//
+// [FIELD_ELEMENTS_PER_CELL]: https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/polynomial-commitments-sampling.md#preset
+const ScalarsPerCell = 64
+
+// BytesPerCell is the number of bytes in a cell.
+//
+// It matches [BYTES_PER_CELL] in the spec.
+//
+// [BYTES_PER_CELL]: https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/polynomial-commitments-sampling.md#cells
+const BytesPerCell = ScalarsPerCell * SerializedScalarSize
+
+// CellsPerExtBlob is the number of cells that an extended blob is split into.
+//
+// It matches [CELLS_PER_EXT_BLOB] in the spec.
+//
+// [CELLS_PER_EXT_BLOB]: https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/polynomial-commitments-sampling.md#cells
+const CellsPerExtBlob = ScalarsPerExtBlob / ScalarsPerCell
+
 type (
 	// G1Point matches [G1Point] in the spec.
 	//
@@ -68,6 +103,26 @@ type (
 	//
 	// [KZGCommitment]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#custom-types
 	KZGCommitment G1Point
+
+	// Cell is a contiguous chunk of the evaluations of an extended blob.
+	//
+	// It matches [Cell] in the spec.
+	//
+	// [Cell]: https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/polynomial-commitments-sampling.md#custom-types
+	Cell [BytesPerCell]byte
+
+	// CellIndex is the position of a cell within an extended blob.
+	//
+	// It matches [CellIndex] in the spec.
+	//
+	// [CellIndex]: https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/polynomial-commitments-sampling.md#custom-types
+	CellIndex uint64
+
+	// ExtBlobCells holds all of the cells of an extended blob, in order.
+	ExtBlobCells [CellsPerExtBlob]Cell
+
+	// ExtBlobCellProofs holds a proof for each of the cells of an extended blob, in order.
+	ExtBlobCellProofs [CellsPerExtBlob]KZGProof
 )
 
 // SerializeG1Point converts a [bls12381.G1Affine] to [G1Point].
@@ -75,21 +130,51 @@ func SerializeG1Point(affine bls12381.G1Affine) G1Point {
 	return affine.Bytes()
 }
 
-// deserializeG1Point converts a [G1Point] to the internal [bls12381.G1Affine] type. It will return an error if the
-// point is not on the group or if the point is not in the correct subgroup.
+// deserializeG1Point converts a [G1Point] to the internal [bls12381.G1Affine] type. It returns
+// [ErrInvalidPointEncoding] if the encoding is malformed, [ErrPointNotOnCurve] if the point is not on the
+// curve and [ErrPointNotInSubgroup] if the point is not in the correct subgroup.
 //
 // It implements [validate_kzg_g1].
 //
 // [validate_kzg_g1]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#validate_kzg_g1
 func deserializeG1Point(serPoint G1Point) (bls12381.G1Affine, error) {
+	// The subgroup check is done separately, so that its failure can be
+	// told apart from the point not being on the curve.
 	var point bls12381.G1Affine
-	_, err := point.SetBytes(serPoint[:])
-	if err != nil {
-		return bls12381.G1Affine{}, err
+	d := bls12381.NewDecoder(bytes.NewReader(serPoint[:]), bls12381.NoSubgroupChecks())
+	if err := d.Decode(&point); err != nil {
+		return bls12381.G1Affine{}, classifyG1DecodeError(serPoint, err)
+	}
+	if !point.IsInSubGroup() {
+		return bls12381.G1Affine{}, ErrPointNotInSubgroup
 	}
 	return point, nil
 }
 
+// classifyG1DecodeError maps an error from decoding a G1 point to either [ErrInvalidPointEncoding] or
+// [ErrPointNotOnCurve].
+func classifyG1DecodeError(serPoint G1Point, err error) error {
+	// Only the compressed encoding fits into a G1Point
+	const compressedFlag = 0b100 << 5
+	if serPoint[0]&compressedFlag == 0 {
+		return ErrInvalidPointEncoding
+	}
+	if errors.Is(err, bls12381.ErrInvalidEncoding) || errors.Is(err, bls12381.ErrInvalidInfinityEncoding) {
+		return ErrInvalidPointEncoding
+	}
+
+	// The flags are valid, so the point is either rejected because the
+	// x-coordinate is not canonical, or because there is no y-coordinate
+	// for it on the curve.
+	xBytes := serPoint
+	xBytes[0] &^= 0b111 << 5
+	var x fp.Element
+	if x.SetBytesCanonical(xBytes[:]) != nil {
+		return ErrInvalidPointEncoding
+	}
+	return ErrPointNotOnCurve
+}
+
 // DeserializeKZGCommitment implements [bytes_to_kzg_commitment].
 //
 // [bytes_to_kzg_commitment]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#bytes_to_kzg_commitment
@@ -104,20 +189,195 @@ func DeserializeKZGProof(proof KZGProof) (bls12381.G1Affine, error) {
 	return deserializeG1Point(G1Point(proof))
 }
 
+// DeserializeG1Points is the same as calling [DeserializeKZGCommitment] on each of the points, except that the
+// points are deserialized by up to numGoRoutines go-routines. Most of the time goes into the subgroup checks, so
+// this cuts the latency of deserializing all of the points of a block. Setting numGoRoutines to a negative number
+// or 0 will make it default to the number of CPUs.
+//
+// If a point is invalid, a [*BatchVerificationError] is returned with the index of the first invalid point.
+func DeserializeG1Points(serPoints []G1Point, numGoRoutines int) ([]bls12381.G1Affine, error) {
+	points := make([]bls12381.G1Affine, len(serPoints))
+	if err := DeserializeG1PointsInto(points, serPoints, numGoRoutines); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+// DeserializeG1PointsInto is the same as [DeserializeG1Points], except that the points are written into dst, so that
+// its memory can be reused.
+//
+// It returns [ErrBatchLengthCheck] if dst does not have one element for each serialized point. If a point is
+// invalid, the contents of dst are unspecified.
+func DeserializeG1PointsInto(dst []bls12381.G1Affine, serPoints []G1Point, numGoRoutines int) error {
+	if len(dst) != len(serPoints) {
+		return ErrBatchLengthCheck
+	}
+	errs := deserializeG1PointsInto(dst, serPoints, numGoRoutines)
+	for i, err := range errs {
+		if err != nil {
+			return &BatchVerificationError{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
+// SerializeG1Points is the same as calling [SerializeG1Point] on each of the points, except that the points are
+// serialized by up to numGoRoutines go-routines. Setting numGoRoutines to a negative number or 0 will make it
+// default to the number of CPUs.
+func SerializeG1Points(points []bls12381.G1Affine, numGoRoutines int) []G1Point {
+	serPoints := make([]G1Point, len(points))
+	_ = SerializeG1PointsInto(serPoints, points, numGoRoutines)
+	return serPoints
+}
+
+// SerializeG1PointsInto is the same as [SerializeG1Points], except that the points are written into dst, so that its
+// memory can be reused.
+//
+// It returns [ErrBatchLengthCheck] if dst does not have one element for each point.
+func SerializeG1PointsInto(dst []G1Point, points []bls12381.G1Affine, numGoRoutines int) error {
+	if len(dst) != len(points) {
+		return ErrBatchLengthCheck
+	}
+	utils.Parallelize(len(points), numGoRoutines, func(start, end int) {
+		for i := start; i < end; i++ {
+			dst[i] = SerializeG1Point(points[i])
+		}
+	})
+	return nil
+}
+
+// DeserializeKZGCommitments is the same as [DeserializeG1Points], for commitments.
+func DeserializeKZGCommitments(commitments []KZGCommitment, numGoRoutines int) ([]bls12381.G1Affine, error) {
+	serPoints := make([]G1Point, len(commitments))
+	for i := range commitments {
+		serPoints[i] = G1Point(commitments[i])
+	}
+	return DeserializeG1Points(serPoints, numGoRoutines)
+}
+
+// DeserializeKZGProofs is the same as [DeserializeG1Points], for proofs.
+func DeserializeKZGProofs(proofs []KZGProof, numGoRoutines int) ([]bls12381.G1Affine, error) {
+	serPoints := make([]G1Point, len(proofs))
+	for i := range proofs {
+		serPoints[i] = G1Point(proofs[i])
+	}
+	return DeserializeG1Points(serPoints, numGoRoutines)
+}
+
+// deserializeG1PointsInto deserializes the points into dst with up to numGoRoutines go-routines, and returns the
+// error of each point, so that callers can report the errors in the order of their choosing.
+//
+// Note: The subgroup checks are not batched with a random linear combination of the points. The cofactor of G1 has
+// small prime factors, starting with 3, so a point outside of the subgroup would pass such a check with a
+// probability of up to a third.
+func deserializeG1PointsInto(dst []bls12381.G1Affine, serPoints []G1Point, numGoRoutines int) []error {
+	errs := make([]error, len(serPoints))
+	utils.Parallelize(len(serPoints), numGoRoutines, func(start, end int) {
+		for i := start; i < end; i++ {
+			dst[i], errs[i] = deserializeG1Point(serPoints[i])
+		}
+	})
+	return errs
+}
+
 // DeserializeBlob implements [blob_to_polynomial].
 //
+// The returned polynomial is newly allocated and does not share any memory with the blob.
+//
+// It returns [ErrBlobNotCanonical] if one of the scalars in the blob is not canonical.
+//
 // [blob_to_polynomial]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#blob_to_polynomial
 func DeserializeBlob(blob *Blob) (kzg.Polynomial, error) {
 	poly := make(kzg.Polynomial, ScalarsPerBlob)
+	if err := deserializeBlobInto(blob, poly); err != nil {
+		return nil, err
+	}
+	return poly, nil
+}
+
+// ValidateBlobFast returns [ErrBlobNotCanonical] if one of the scalars in the blob is not canonical, and nil
+// otherwise.
+//
+// This is the same check that [DeserializeBlob] does, but it compares the serialized scalars to [BlsModulus]
+// directly, instead of converting them to field elements. It does not allocate, which makes it a cheap way to reject
+// malformed blobs before doing any expensive work.
+func ValidateBlobFast(blob *Blob) error {
 	for i := 0; i < ScalarsPerBlob; i++ {
 		chunk := blob[i*SerializedScalarSize : (i+1)*SerializedScalarSize]
-		if err := poly[i].SetBytesCanonical(chunk); err != nil {
-			return nil, ErrNonCanonicalScalar
+		if bytes.Compare(chunk, BlsModulus[:]) >= 0 {
+			return ErrBlobNotCanonical
+		}
+	}
+	return nil
+}
+
+// minScalarsPerGoRoutine is the smallest number of scalars that [DeserializeBlobPar] gives to a go-routine. Below
+// this, the cost of starting the go-routine outweighs the work that it does.
+const minScalarsPerGoRoutine = 256
+
+// DeserializeBlobPar is the same as [DeserializeBlob], except that the scalars are deserialized by up to
+// numGoRoutines go-routines. Setting numGoRoutines to a negative number or 0 will make it default to the number
+// of CPUs.
+func DeserializeBlobPar(blob *Blob, numGoRoutines int) (kzg.Polynomial, error) {
+	return deserializeBlobBytesPar(blob[:], numGoRoutines)
+}
+
+// deserializeBlobBytesPar is the same as [DeserializeBlobPar], except that the blob is given as a slice, which may
+// hold any number of scalars. This is used for the blobs of a [Preset] whose size differs from that of [Blob]; the
+// length of the slice must already have been checked to be a multiple of [SerializedScalarSize].
+func deserializeBlobBytesPar(blob []byte, numGoRoutines int) (kzg.Polynomial, error) {
+	numScalars := len(blob) / SerializedScalarSize
+	if numGoRoutines <= 0 {
+		numGoRoutines = runtime.NumCPU()
+	}
+	if maxGoRoutines := numScalars / minScalarsPerGoRoutine; numGoRoutines > maxGoRoutines {
+		numGoRoutines = maxGoRoutines
+	}
+
+	poly := make(kzg.Polynomial, numScalars)
+	if numGoRoutines <= 1 {
+		if err := deserializeScalarsInto(blob, poly, 0, numScalars); err != nil {
+			return nil, err
+		}
+		return poly, nil
+	}
+
+	chunkSize := (numScalars + numGoRoutines - 1) / numGoRoutines
+	var errG errgroup.Group
+	for start := 0; start < numScalars; start += chunkSize {
+		start := start
+		end := start + chunkSize
+		if end > numScalars {
+			end = numScalars
 		}
+		errG.Go(func() error {
+			return deserializeScalarsInto(blob, poly, start, end)
+		})
+	}
+	if err := errG.Wait(); err != nil {
+		return nil, err
 	}
 	return poly, nil
 }
 
+// deserializeBlobInto is the same as [DeserializeBlob], except that the polynomial is written into poly, which
+// must have a length of [ScalarsPerBlob]. This allows callers to reuse the memory for the polynomial.
+func deserializeBlobInto(blob *Blob, poly kzg.Polynomial) error {
+	return deserializeScalarsInto(blob[:], poly, 0, ScalarsPerBlob)
+}
+
+// deserializeScalarsInto deserializes the scalars of the blob with indices in [start, end) into the same positions
+// of poly.
+func deserializeScalarsInto(blob []byte, poly kzg.Polynomial, start, end int) error {
+	for i := start; i < end; i++ {
+		chunk := blob[i*SerializedScalarSize : (i+1)*SerializedScalarSize]
+		if err := poly[i].SetBytesCanonical(chunk); err != nil {
+			return ErrBlobNotCanonical
+		}
+	}
+	return nil
+}
+
 // DeserializeScalar implements [bytes_to_bls_field].
 //
 // Note: Returns an error if the scalar is not in the range [0, p-1] (inclusive) where `p` is the prime associated with the scalar field.
@@ -136,10 +396,23 @@ func SerializeScalar(element fr.Element) Scalar {
 	return element.Bytes()
 }
 
+// SerializeBlob is the inverse of [DeserializeBlob]. It converts the evaluations of a polynomial into a [Blob], for
+// example after constructing the polynomial from rollup data or recovering it from cells.
+//
+// The evaluations must be in the order that the blob stores them in, which is the bit-reversed order by default; see
+// [WithEvaluationOrder]. Returns [ErrInvalidPolynomialLength] if the polynomial does not have [ScalarsPerBlob]
+// evaluations.
+func SerializeBlob(poly kzg.Polynomial) (*Blob, error) {
+	if len(poly) != ScalarsPerBlob {
+		return nil, ErrInvalidPolynomialLength
+	}
+	return SerializePoly(poly), nil
+}
+
 // SerializePoly converts a [kzg.Polynomial] to [Blob].
 //
-// Note: This method is never used in the API because we always expect a byte array and will never receive deserialized
-// field elements. We include it so that upstream fuzzers do not need to reimplement it.
+// Note: This method panics if the polynomial has fewer than [ScalarsPerBlob] evaluations, and ignores any evaluations
+// after those. Use [SerializeBlob] to get an error instead.
 func SerializePoly(poly kzg.Polynomial) *Blob {
 	var blob Blob
 	for i := 0; i < ScalarsPerBlob; i++ {
@@ -149,3 +422,39 @@ func SerializePoly(poly kzg.Polynomial) *Blob {
 	}
 	return &blob
 }
+
+// ConstantTimeEqual reports whether the two serialized scalars are equal, taking an amount of time which does not
+// depend on their contents. Use it instead of == or [bytes.Equal] when one of the scalars is controlled by an
+// attacker, for example when comparing a claimed value to an expected one.
+//
+// The scalars are compared as bytes, so two different encodings of the same field element, one of which is
+// not canonical, are not equal.
+func (s Scalar) ConstantTimeEqual(other Scalar) bool {
+	return subtle.ConstantTimeCompare(s[:], other[:]) == 1
+}
+
+// CellFromBytes returns the [Cell] which is backed by the given slice.
+//
+// The returned cell shares its memory with the slice, so no copy is made.
+//
+// Returns [ErrInvalidCellLength] if the slice does not have a length of [BytesPerCell].
+func CellFromBytes(byts []byte) (*Cell, error) {
+	return mainnetPreset.CellFromBytes(byts)
+}
+
+// Scalar returns the i'th serialized scalar in the cell.
+//
+// This method panics if i is not in the range [0, ScalarsPerCell).
+func (c *Cell) Scalar(i int) Scalar {
+	var scalar Scalar
+	copy(scalar[:], c[i*SerializedScalarSize:(i+1)*SerializedScalarSize])
+	return scalar
+}
+
+// Cells returns a slice of the cells in the extended blob.
+//
+// This is useful for code which expects a variable number of cells, for example when
+// only a subset of the cells is available. The slice shares its memory with the array.
+func (c *ExtBlobCells) Cells() []Cell {
+	return c[:]
+}
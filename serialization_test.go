@@ -2,6 +2,7 @@ package gokzg4844_test
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
@@ -119,3 +120,229 @@ func randPoly4096() kzg.Polynomial {
 	}
 	return poly
 }
+
+func TestCellSizes(t *testing.T) {
+	require.Equal(t, 2048, gokzg4844.BytesPerCell)
+	require.Equal(t, 128, gokzg4844.CellsPerExtBlob)
+	require.Equal(t, 2*len(gokzg4844.Blob{}), len(gokzg4844.ExtBlobCells{})*gokzg4844.BytesPerCell)
+}
+
+func TestCellFromBytes(t *testing.T) {
+	byts := make([]byte, gokzg4844.BytesPerCell)
+	byts[gokzg4844.SerializedScalarSize] = 1
+
+	cell, err := gokzg4844.CellFromBytes(byts)
+	require.NoError(t, err)
+	scalar := cell.Scalar(1)
+	require.Equal(t, byte(1), scalar[0])
+
+	// The cell should share its memory with the slice
+	byts[0] = 2
+	require.Equal(t, byte(2), cell[0])
+
+	_, err = gokzg4844.CellFromBytes(byts[1:])
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidCellLength)
+}
+
+func TestDeserializeG1PointErrors(t *testing.T) {
+	// The compression flag is not set
+	_, err := gokzg4844.DeserializeKZGCommitment(gokzg4844.KZGCommitment{})
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPointEncoding)
+
+	// The x-coordinate is larger than the base field modulus
+	var nonCanonicalX gokzg4844.KZGCommitment
+	for i := range nonCanonicalX {
+		nonCanonicalX[i] = 0xff
+	}
+	nonCanonicalX[0] = 0x9f
+	_, err = gokzg4844.DeserializeKZGCommitment(nonCanonicalX)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPointEncoding)
+
+	// Almost every x-coordinate is either not on the curve, or is on the curve but not in
+	// the subgroup, since the cofactor of G1 is large.
+	var foundNotOnCurve, foundNotInSubgroup bool
+	for x := 1; x < 256 && !(foundNotOnCurve && foundNotInSubgroup); x++ {
+		var commitment gokzg4844.KZGCommitment
+		commitment[0] = 0x80
+		commitment[len(commitment)-1] = byte(x)
+
+		_, err := gokzg4844.DeserializeKZGCommitment(commitment)
+		switch {
+		case errors.Is(err, gokzg4844.ErrPointNotOnCurve):
+			foundNotOnCurve = true
+		case errors.Is(err, gokzg4844.ErrPointNotInSubgroup):
+			foundNotInSubgroup = true
+		default:
+			t.Fatalf("unexpected result for x = %d: %v", x, err)
+		}
+	}
+	require.True(t, foundNotOnCurve)
+	require.True(t, foundNotInSubgroup)
+}
+
+func TestScalarConstantTimeEqual(t *testing.T) {
+	a := gokzg4844.Scalar(GetRandFieldElement(1))
+	b := gokzg4844.Scalar(GetRandFieldElement(2))
+	require.True(t, a.ConstantTimeEqual(a))
+	require.False(t, a.ConstantTimeEqual(b))
+
+	// The same field element with a non-canonical encoding is not equal
+	require.False(t, a.ConstantTimeEqual(createScalarNonCanonical(a)))
+}
+
+func TestDeserializeBlobPar(t *testing.T) {
+	blob := GetRandBlob(1)
+	expected, err := gokzg4844.DeserializeBlob(blob)
+	require.NoError(t, err)
+
+	for _, numGoRoutines := range []int{0, 1, 2, 3, 7, 16, 100} {
+		poly, err := gokzg4844.DeserializeBlobPar(blob, numGoRoutines)
+		require.NoError(t, err)
+		assertPolyEqual(t, expected, poly)
+	}
+
+	// A non-canonical scalar in the last chunk is still found
+	modifyBlob(blob, nonCanonicalScalar(1), (gokzg4844.ScalarsPerBlob-1)*gokzg4844.SerializedScalarSize)
+	for _, numGoRoutines := range []int{1, 3, 16} {
+		_, err := gokzg4844.DeserializeBlobPar(blob, numGoRoutines)
+		require.ErrorIs(t, err, gokzg4844.ErrBlobNotCanonical)
+	}
+}
+
+func TestDeserializeKZGCommitments(t *testing.T) {
+	commitments := make([]gokzg4844.KZGCommitment, 6)
+	proofs := make([]gokzg4844.KZGProof, 6)
+	for i := range commitments {
+		blob := GetRandBlob(int64(i))
+		commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+		require.NoError(t, err)
+		commitments[i] = commitment
+		proofs[i], err = ctx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+		require.NoError(t, err)
+	}
+
+	for _, numGoRoutines := range []int{0, 1, 2, 4, 16} {
+		points, err := gokzg4844.DeserializeKZGCommitments(commitments, numGoRoutines)
+		require.NoError(t, err)
+		quotients, err := gokzg4844.DeserializeKZGProofs(proofs, numGoRoutines)
+		require.NoError(t, err)
+		for i := range commitments {
+			expected, err := gokzg4844.DeserializeKZGCommitment(commitments[i])
+			require.NoError(t, err)
+			require.Equal(t, expected, points[i])
+			expected, err = gokzg4844.DeserializeKZGProof(proofs[i])
+			require.NoError(t, err)
+			require.Equal(t, expected, quotients[i])
+		}
+	}
+
+	// The first invalid point is reported, whichever go-routine checks it
+	notInSubgroup := pointNotInSubgroup(t)
+	commitments[4] = gokzg4844.KZGCommitment(notInSubgroup)
+	commitments[2] = gokzg4844.KZGCommitment{}
+	for _, numGoRoutines := range []int{1, 2, 4} {
+		_, err := gokzg4844.DeserializeKZGCommitments(commitments, numGoRoutines)
+		var batchErr *gokzg4844.BatchVerificationError
+		require.ErrorAs(t, err, &batchErr)
+		require.Equal(t, 2, batchErr.Index)
+		require.ErrorIs(t, err, gokzg4844.ErrInvalidPointEncoding)
+	}
+	proofs[5] = gokzg4844.KZGProof(notInSubgroup)
+	_, err := gokzg4844.DeserializeKZGProofs(proofs, 3)
+	require.ErrorIs(t, err, gokzg4844.ErrPointNotInSubgroup)
+
+	points, err := gokzg4844.DeserializeKZGCommitments(nil, 0)
+	require.NoError(t, err)
+	require.Empty(t, points)
+}
+
+func TestSerializeG1Points(t *testing.T) {
+	_, _, genG1, _ := bls12381.Generators()
+	points := make([]bls12381.G1Affine, 9)
+	points[0] = genG1
+	for i := 1; i < len(points); i++ {
+		points[i].Add(&points[i-1], &genG1)
+	}
+	// The point at infinity has its own encoding
+	points[3] = bls12381.G1Affine{}
+
+	for _, numGoRoutines := range []int{0, 1, 2, 4, 16} {
+		serPoints := gokzg4844.SerializeG1Points(points, numGoRoutines)
+		for i := range points {
+			require.Equal(t, gokzg4844.SerializeG1Point(points[i]), serPoints[i])
+		}
+
+		roundTrip, err := gokzg4844.DeserializeG1Points(serPoints, numGoRoutines)
+		require.NoError(t, err)
+		require.Equal(t, points, roundTrip)
+	}
+
+	// The buffers can be reused
+	serPoints := make([]gokzg4844.G1Point, len(points))
+	require.NoError(t, gokzg4844.SerializeG1PointsInto(serPoints, points, 2))
+	roundTrip := make([]bls12381.G1Affine, len(points))
+	require.NoError(t, gokzg4844.DeserializeG1PointsInto(roundTrip, serPoints, 2))
+	require.Equal(t, points, roundTrip)
+
+	require.ErrorIs(t, gokzg4844.SerializeG1PointsInto(serPoints[1:], points, 2), gokzg4844.ErrBatchLengthCheck)
+	require.ErrorIs(t, gokzg4844.DeserializeG1PointsInto(roundTrip[1:], serPoints, 2), gokzg4844.ErrBatchLengthCheck)
+
+	serPoints[7] = pointNotInSubgroup(t)
+	_, err := gokzg4844.DeserializeG1Points(serPoints, 4)
+	var batchErr *gokzg4844.BatchVerificationError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, 7, batchErr.Index)
+	require.ErrorIs(t, err, gokzg4844.ErrPointNotInSubgroup)
+}
+
+// pointNotInSubgroup returns the encoding of a point which is on the curve but not in the subgroup.
+func pointNotInSubgroup(t *testing.T) gokzg4844.G1Point {
+	for x := 1; x < 256; x++ {
+		var point gokzg4844.G1Point
+		point[0] = 0x80
+		point[len(point)-1] = byte(x)
+		if _, err := gokzg4844.DeserializeKZGCommitment(gokzg4844.KZGCommitment(point)); errors.Is(err, gokzg4844.ErrPointNotInSubgroup) {
+			return point
+		}
+	}
+	t.Fatal("no point outside of the subgroup found")
+	return gokzg4844.G1Point{}
+}
+
+func TestValidateBlobFast(t *testing.T) {
+	blob := GetRandBlob(1)
+	require.NoError(t, gokzg4844.ValidateBlobFast(blob))
+
+	// The largest canonical scalar is p - 1
+	largest := gokzg4844.BlsModulus
+	largest[len(largest)-1]--
+	modifyBlob(blob, largest, 0)
+	require.NoError(t, gokzg4844.ValidateBlobFast(blob))
+
+	for _, index := range []int{0, 100, gokzg4844.ScalarsPerBlob - 1} {
+		blob := GetRandBlob(1)
+		modifyBlob(blob, gokzg4844.BlsModulus, index*gokzg4844.SerializedScalarSize)
+		require.ErrorIs(t, gokzg4844.ValidateBlobFast(blob), gokzg4844.ErrBlobNotCanonical)
+
+		blob = GetRandBlob(1)
+		modifyBlob(blob, nonCanonicalScalar(int64(index)), index*gokzg4844.SerializedScalarSize)
+		require.ErrorIs(t, gokzg4844.ValidateBlobFast(blob), gokzg4844.ErrBlobNotCanonical)
+		_, err := gokzg4844.DeserializeBlob(blob)
+		require.ErrorIs(t, err, gokzg4844.ErrBlobNotCanonical)
+	}
+}
+
+func TestSerializeBlob(t *testing.T) {
+	blob := GetRandBlob(1)
+	poly, err := gokzg4844.DeserializeBlob(blob)
+	require.NoError(t, err)
+
+	roundTrip, err := gokzg4844.SerializeBlob(poly)
+	require.NoError(t, err)
+	require.Equal(t, blob, roundTrip)
+
+	_, err = gokzg4844.SerializeBlob(poly[:len(poly)-1])
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPolynomialLength)
+	_, err = gokzg4844.SerializeBlob(append(poly, fr.Element{}))
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPolynomialLength)
+}
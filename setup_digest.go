@@ -0,0 +1,106 @@
+package gokzg4844
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// MainnetSetupDigest is the digest, as computed by [SetupDigest], of the trusted setup from the Ethereum KZG ceremony,
+// which is the setup used by [NewContext4096Secure].
+var MainnetSetupDigest = [32]byte{
+	0x1a, 0x75, 0xb4, 0x57, 0xf4, 0xb6, 0x64, 0xd8,
+	0x25, 0xf6, 0xb0, 0xfa, 0x24, 0xa1, 0xee, 0xd0,
+	0x2f, 0x13, 0x69, 0xa3, 0x0b, 0xfa, 0x5b, 0xe7,
+	0xd4, 0xf7, 0xa1, 0xa7, 0x3c, 0x72, 0xdd, 0x23,
+}
+
+// SetupDigestError is returned by [VerifySetupDigest] when a trusted setup does not have the expected digest.
+//
+// errors.Is matches it with [ErrSetupDigestMismatch].
+type SetupDigestError struct {
+	// Expected is the digest that the setup was expected to have.
+	Expected [32]byte
+	// Actual is the digest of the setup.
+	Actual [32]byte
+}
+
+func (e *SetupDigestError) Error() string {
+	return fmt.Sprintf("%v: expected %x, got %x", ErrSetupDigestMismatch, e.Expected, e.Actual)
+}
+
+// Unwrap returns [ErrSetupDigestMismatch].
+func (e *SetupDigestError) Unwrap() error {
+	return ErrSetupDigestMismatch
+}
+
+// SetupDigest computes a SHA-256 digest of the points of a trusted setup, which identifies it. The digest is the same
+// as the one recorded in the [VerificationReceipt]s of a context created from the setup.
+//
+// The digest is computed from the hex-decoded points, without deserializing them, so it is much cheaper than
+// [CheckTrustedSetupIsWellFormed]. The hex encoding of the points is case-insensitive and the 0x prefix is optional,
+// so setups which only differ in their formatting have the same digest. The Lagrange G1 points must be in natural
+// order, as they are in the files used by Ethereum.
+//
+// Returns [ErrInvalidPointEncoding] if a point is not the hex encoding of a compressed point of the right size.
+func SetupDigest(setup *JSONTrustedSetup) ([32]byte, error) {
+	h := sha256.New()
+	h.Write([]byte(setupDigestDomain))
+
+	writeLength(h, len(setup.SetupG1Lagrange))
+	for _, point := range setup.SetupG1Lagrange {
+		compressed, err := decodeHexPoint(point, CompressedG1Size)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		h.Write(compressed)
+	}
+	writeLength(h, len(setup.SetupG2))
+	for _, point := range setup.SetupG2 {
+		compressed, err := decodeHexPoint(point, CompressedG2Size)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		h.Write(compressed)
+	}
+
+	var digest [32]byte
+	copy(digest[:], h.Sum(nil))
+	return digest, nil
+}
+
+// VerifySetupDigest checks that the trusted setup has the expected digest, as computed by [SetupDigest]. Use
+// [MainnetSetupDigest] to check that a setup file holds the setup from the Ethereum KZG ceremony.
+//
+// Returns a [*SetupDigestError] if the digests do not match.
+func VerifySetupDigest(setup *JSONTrustedSetup, expected [32]byte) error {
+	digest, err := SetupDigest(setup)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(digest[:], expected[:]) != 1 {
+		return &SetupDigestError{Expected: expected, Actual: digest}
+	}
+	return nil
+}
+
+// SetupDigest returns the digest, as computed by [SetupDigest], of the trusted setup that the context was created
+// with.
+func (c *Context) SetupDigest() [32]byte {
+	return c.setupDigest
+}
+
+// decodeHexPoint decodes the hex encoding of a compressed point of the given size.
+func decodeHexPoint(hexString string, size int) ([]byte, error) {
+	hexString = strings.TrimPrefix(strings.TrimPrefix(hexString, "0x"), "0X")
+	if len(hexString) != 2*size {
+		return nil, ErrInvalidPointEncoding
+	}
+	compressed, err := hex.DecodeString(hexString)
+	if err != nil {
+		return nil, ErrInvalidPointEncoding
+	}
+	return compressed, nil
+}
@@ -0,0 +1,44 @@
+package gokzg4844
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupDigest(t *testing.T) {
+	parsedSetup := JSONTrustedSetup{}
+	require.NoError(t, json.Unmarshal([]byte(testKzgSetupStr), &parsedSetup))
+
+	digest, err := SetupDigest(&parsedSetup)
+	require.NoError(t, err)
+	require.Equal(t, MainnetSetupDigest, digest)
+	require.NoError(t, VerifySetupDigest(&parsedSetup, MainnetSetupDigest))
+
+	// The digest is the one recorded by a context
+	ctx, err := NewContext4096Secure(WithBitReversalPermutation(false))
+	require.NoError(t, err)
+	require.Equal(t, MainnetSetupDigest, ctx.SetupDigest())
+
+	// The formatting of the hex strings does not matter
+	reformatted := parsedSetup
+	reformatted.SetupG2 = append([]string(nil), parsedSetup.SetupG2...)
+	reformatted.SetupG1Lagrange[0] = strings.ToUpper(strings.TrimPrefix(parsedSetup.SetupG1Lagrange[0], "0x"))
+	require.NoError(t, VerifySetupDigest(&reformatted, MainnetSetupDigest))
+
+	// A different setup
+	reformatted.SetupG1Lagrange[0], reformatted.SetupG1Lagrange[1] = reformatted.SetupG1Lagrange[1], reformatted.SetupG1Lagrange[0]
+	err = VerifySetupDigest(&reformatted, MainnetSetupDigest)
+	require.ErrorIs(t, err, ErrSetupDigestMismatch)
+	var digestErr *SetupDigestError
+	require.True(t, errors.As(err, &digestErr))
+	require.Equal(t, MainnetSetupDigest, digestErr.Expected)
+
+	// A malformed point
+	reformatted.SetupG2[0] = "0x1234"
+	_, err = SetupDigest(&reformatted)
+	require.ErrorIs(t, err, ErrInvalidPointEncoding)
+}
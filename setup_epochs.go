@@ -0,0 +1,154 @@
+package gokzg4844
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// SetupEpoch assigns a [Context], and so a trusted setup, to every slot from StartSlot until the start of the next
+// epoch.
+type SetupEpoch struct {
+	// StartSlot is the first slot that uses the context.
+	StartSlot uint64
+	// Context is the context created from the trusted setup that is used for the slots of the epoch.
+	Context *Context
+}
+
+// SetupEpochs selects the trusted setup to use for a blob from the slot that the blob belongs to.
+//
+// If a future ceremony replaces the trusted setup, blobs from before the change must still be proved and verified
+// with the old setup, while new blobs use the new one. Callers which identify forks by their digest, rather than by
+// slot, can use the first slot of each fork as the StartSlot.
+type SetupEpochs struct {
+	// epochs are sorted by StartSlot, with no two epochs starting at the same slot.
+	epochs []SetupEpoch
+}
+
+// NewSetupEpochs creates a [SetupEpochs] from the given epochs, which may be given in any order.
+//
+// Returns [ErrInvalidSetupEpochs] if no epochs are given, an epoch has a nil context, or two epochs start at the
+// same slot.
+func NewSetupEpochs(epochs ...SetupEpoch) (*SetupEpochs, error) {
+	if len(epochs) == 0 {
+		return nil, ErrInvalidSetupEpochs
+	}
+
+	sorted := make([]SetupEpoch, len(epochs))
+	copy(sorted, epochs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartSlot < sorted[j].StartSlot
+	})
+	for i, epoch := range sorted {
+		if epoch.Context == nil {
+			return nil, ErrInvalidSetupEpochs
+		}
+		if i > 0 && sorted[i-1].StartSlot == epoch.StartSlot {
+			return nil, ErrInvalidSetupEpochs
+		}
+	}
+
+	return &SetupEpochs{epochs: sorted}, nil
+}
+
+// Epochs returns a copy of the epochs, sorted by StartSlot.
+func (s *SetupEpochs) Epochs() []SetupEpoch {
+	epochs := make([]SetupEpoch, len(s.epochs))
+	copy(epochs, s.epochs)
+	return epochs
+}
+
+// ContextAt returns the context for the given slot; that is, the context of the last epoch which starts at or before
+// the slot. This can be used to call any method of [Context] for the slot, including the proving methods.
+//
+// Returns [ErrNoSetupForSlot] if the slot is before the first epoch.
+func (s *SetupEpochs) ContextAt(slot uint64) (*Context, error) {
+	// The index of the first epoch which starts after the slot
+	next := sort.Search(len(s.epochs), func(i int) bool {
+		return s.epochs[i].StartSlot > slot
+	})
+	if next == 0 {
+		return nil, ErrNoSetupForSlot
+	}
+	return s.epochs[next-1].Context, nil
+}
+
+// VerifyKZGProof is the same as [Context.VerifyKZGProof], using the context for the given slot.
+func (s *SetupEpochs) VerifyKZGProof(slot uint64, blobCommitment KZGCommitment, inputPointBytes, claimedValueBytes Scalar, kzgProof KZGProof) error {
+	c, err := s.ContextAt(slot)
+	if err != nil {
+		return err
+	}
+	return c.VerifyKZGProof(blobCommitment, inputPointBytes, claimedValueBytes, kzgProof)
+}
+
+// VerifyBlobKZGProof is the same as [Context.VerifyBlobKZGProof], using the context for the given slot.
+func (s *SetupEpochs) VerifyBlobKZGProof(slot uint64, blob *Blob, blobCommitment KZGCommitment, kzgProof KZGProof) error {
+	c, err := s.ContextAt(slot)
+	if err != nil {
+		return err
+	}
+	return c.VerifyBlobKZGProof(blob, blobCommitment, kzgProof)
+}
+
+// VerifyBlobKZGProofBatch is the same as [Context.VerifyBlobKZGProofBatch], except that each blob is verified with
+// the context for its slot, given by the element of slots at the same index.
+//
+// The blobs are grouped by context, and each group is verified as a single batch. If an element is invalid, the
+// returned [*BatchVerificationError] reports its index in blobs.
+func (s *SetupEpochs) VerifyBlobKZGProofBatch(slots []uint64, blobs []Blob, polynomialCommitments []KZGCommitment, kzgProofs []KZGProof) error {
+	return s.VerifyBlobKZGProofBatchCtx(context.Background(), slots, blobs, polynomialCommitments, kzgProofs)
+}
+
+// VerifyBlobKZGProofBatchCtx is the same as [SetupEpochs.VerifyBlobKZGProofBatch], except that it stops and returns
+// ctx.Err() if ctx is cancelled.
+func (s *SetupEpochs) VerifyBlobKZGProofBatchCtx(ctx context.Context, slots []uint64, blobs []Blob, polynomialCommitments []KZGCommitment, kzgProofs []KZGProof) error {
+	batchSize := len(blobs)
+	if len(slots) != batchSize || len(polynomialCommitments) != batchSize || len(kzgProofs) != batchSize {
+		return ErrBatchLengthCheck
+	}
+
+	// Group the indices by context, keeping the groups in the order in which their contexts first appear
+	var (
+		contexts []*Context
+		groups   = make(map[*Context][]int)
+	)
+	for i, slot := range slots {
+		c, err := s.ContextAt(slot)
+		if err != nil {
+			return &BatchVerificationError{Index: i, Err: err}
+		}
+		if _, ok := groups[c]; !ok {
+			contexts = append(contexts, c)
+		}
+		groups[c] = append(groups[c], i)
+	}
+
+	for _, c := range contexts {
+		indices := groups[c]
+		groupBlobs := make([]*Blob, len(indices))
+		groupCommitments := make([]KZGCommitment, len(indices))
+		groupProofs := make([]KZGProof, len(indices))
+		for j, i := range indices {
+			groupBlobs[j] = &blobs[i]
+			groupCommitments[j] = polynomialCommitments[i]
+			groupProofs[j] = kzgProofs[i]
+		}
+
+		if err := c.checkTypes(); err != nil {
+			return err
+		}
+		acct := c.startAccounting(ctx, "verify_blob_kzg_proof_batch", len(indices), len(indices)*(len(Blob{})+2*CompressedG1Size))
+		err := c.verifyBlobKZGProofBatch(ctx, groupBlobs, groupCommitments, groupProofs)
+		acct.end()
+
+		var batchErr *BatchVerificationError
+		if errors.As(err, &batchErr) {
+			return &BatchVerificationError{Index: indices[batchErr.Index], Err: batchErr.Err}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
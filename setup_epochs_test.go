@@ -0,0 +1,77 @@
+package gokzg4844_test
+
+import (
+	"errors"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupEpochs(t *testing.T) {
+	// A context which interprets blobs in a different order stands in for a context with a different setup, since
+	// proofs made with one do not verify with the other.
+	ctxNew, err := gokzg4844.NewContext4096Secure(gokzg4844.WithBitReversalPermutation(false))
+	require.NoError(t, err)
+
+	epochs, err := gokzg4844.NewSetupEpochs(
+		gokzg4844.SetupEpoch{StartSlot: 1000, Context: ctxNew},
+		gokzg4844.SetupEpoch{StartSlot: 10, Context: ctx},
+	)
+	require.NoError(t, err)
+
+	for slot, expected := range map[uint64]*gokzg4844.Context{10: ctx, 999: ctx, 1000: ctxNew, 1 << 40: ctxNew} {
+		got, err := epochs.ContextAt(slot)
+		require.NoError(t, err)
+		require.Same(t, expected, got)
+	}
+	_, err = epochs.ContextAt(9)
+	require.ErrorIs(t, err, gokzg4844.ErrNoSetupForSlot)
+
+	const batchSize = 4
+	slots := []uint64{10, 1000, 20, 2000}
+	blobs := make([]gokzg4844.Blob, batchSize)
+	commitments := make([]gokzg4844.KZGCommitment, batchSize)
+	proofs := make([]gokzg4844.KZGProof, batchSize)
+	for i, slot := range slots {
+		c, err := epochs.ContextAt(slot)
+		require.NoError(t, err)
+		blobs[i] = *GetRandBlob(int64(i))
+		commitments[i], err = c.BlobToKZGCommitment(&blobs[i], NumGoRoutines)
+		require.NoError(t, err)
+		proofs[i], err = c.ComputeBlobKZGProof(&blobs[i], commitments[i], NumGoRoutines)
+		require.NoError(t, err)
+
+		require.NoError(t, epochs.VerifyBlobKZGProof(slot, &blobs[i], commitments[i], proofs[i]))
+	}
+	require.NoError(t, epochs.VerifyBlobKZGProofBatch(slots, blobs, commitments, proofs))
+
+	// A proof made with the new setup does not verify for a slot of the old setup
+	err = epochs.VerifyBlobKZGProof(10, &blobs[1], commitments[1], proofs[1])
+	require.ErrorIs(t, err, gokzg4844.ErrProofInvalid)
+
+	// The index of an invalid element refers to the whole batch, not to the group of its setup
+	wrongSlots := []uint64{10, 1000, 20, 30}
+	err = epochs.VerifyBlobKZGProofBatch(wrongSlots, blobs, commitments, proofs)
+	require.ErrorIs(t, err, gokzg4844.ErrProofInvalid)
+	var batchErr *gokzg4844.BatchVerificationError
+	require.True(t, errors.As(err, &batchErr))
+	require.Equal(t, 3, batchErr.Index)
+
+	err = epochs.VerifyBlobKZGProofBatch(slots[:3], blobs, commitments, proofs)
+	require.ErrorIs(t, err, gokzg4844.ErrBatchLengthCheck)
+}
+
+func TestNewSetupEpochsInvalid(t *testing.T) {
+	_, err := gokzg4844.NewSetupEpochs()
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidSetupEpochs)
+
+	_, err = gokzg4844.NewSetupEpochs(gokzg4844.SetupEpoch{StartSlot: 0})
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidSetupEpochs)
+
+	_, err = gokzg4844.NewSetupEpochs(
+		gokzg4844.SetupEpoch{StartSlot: 5, Context: ctx},
+		gokzg4844.SetupEpoch{StartSlot: 5, Context: ctx},
+	)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidSetupEpochs)
+}
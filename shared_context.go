@@ -0,0 +1,27 @@
+package gokzg4844
+
+import "sync"
+
+var (
+	sharedContextOnce sync.Once
+	sharedContext     *Context
+	sharedContextErr  error
+)
+
+// SharedContext4096Secure returns a context which is created with [NewContext4096Secure] and no options the first
+// time that it is called, and is shared by every later call.
+//
+// This is intended for test suites which would otherwise create a new context in every test. Methods of a [Context]
+// are safe for concurrent use, so the returned context can be used by tests which run in parallel. Callers must not
+// rely on any configuration other than the defaults; use [NewContext4096Secure] to create a context with options.
+//
+// The context cannot be closed, since it is created only once; [Context.Close] returns [ErrSharedContext] for it.
+func SharedContext4096Secure() (*Context, error) {
+	sharedContextOnce.Do(func() {
+		sharedContext, sharedContextErr = NewContext4096Secure()
+		if sharedContext != nil {
+			sharedContext.shared = true
+		}
+	})
+	return sharedContext, sharedContextErr
+}
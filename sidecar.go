@@ -0,0 +1,78 @@
+package gokzg4844
+
+import (
+	"context"
+
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// BlobSidecars holds everything that a blob transaction or a block needs for its blobs, other than the blobs
+// themselves. The i'th element of each slice belongs to the i'th blob.
+type BlobSidecars struct {
+	// Commitments holds the commitment to each blob, as computed by [Context.BlobToKZGCommitment].
+	Commitments []KZGCommitment
+	// Proofs holds the proof for each blob against its commitment, as computed by [Context.ComputeBlobKZGProof].
+	Proofs []KZGProof
+	// VersionedHashes holds the versioned hash of each commitment, as computed by [KZGToVersionedHash].
+	VersionedHashes []VersionedHash
+}
+
+// BuildSidecars computes the commitment, the proof and the versioned hash of each of the blobs. This is the same as
+// calling [Context.BlobsToKZGCommitments], [Context.ComputeBlobKZGProofs] and [KZGToVersionedHash] in turn, except
+// that each blob is only deserialized once, and its proof is computed by the same worker as its commitment.
+//
+// If any of the blobs is invalid, an error is returned and no sidecars are returned.
+//
+// numGoRoutines is used to configure the amount of concurrency needed. Setting this
+// value to a negative number or 0 will make it default to the value configured with
+// [WithNumGoRoutines], which itself defaults to the number of CPUs.
+func (c *Context) BuildSidecars(blobs []Blob, numGoRoutines int) (BlobSidecars, error) {
+	return c.BuildSidecarsCtx(context.Background(), blobs, numGoRoutines)
+}
+
+// BuildSidecarsCtx is the same as [Context.BuildSidecars], except that it stops and returns ctx.Err() if ctx is
+// cancelled.
+//
+// Cancellation is checked before each blob is processed; blobs which are already being processed will run
+// to completion.
+func (c *Context) BuildSidecarsCtx(ctx context.Context, blobs []Blob, numGoRoutines int) (BlobSidecars, error) {
+	if err := c.checkTypes(); err != nil {
+		return BlobSidecars{}, err
+	}
+	acct := c.startAccounting(ctx, "build_sidecars", len(blobs), len(blobs)*len(Blob{}))
+	defer acct.end()
+
+	prof := startProfile(ctx, "build_sidecars", len(blobs))
+	defer prof.end()
+
+	sidecars := BlobSidecars{
+		Commitments:     make([]KZGCommitment, len(blobs)),
+		Proofs:          make([]KZGProof, len(blobs)),
+		VersionedHashes: make([]VersionedHash, len(blobs)),
+	}
+
+	prof.phase("commit_and_open")
+	err := c.forEachBlob(ctx, blobs, numGoRoutines, func(i int, polynomial kzg.Polynomial, msmGoRoutines int) error {
+		commitment, err := kzg.Commit(polynomial, c.commitKey(), msmGoRoutines)
+		if err != nil {
+			return err
+		}
+		sidecars.Commitments[i] = KZGCommitment(SerializeG1Point(*commitment))
+		sidecars.VersionedHashes[i] = KZGToVersionedHash(sidecars.Commitments[i])
+
+		// The commitment was just computed, so unlike in [Context.ComputeBlobKZGProofs]
+		// it does not need to be checked to be in the subgroup
+		evaluationChallenge := computeChallenge(blobs[i][:], sidecars.Commitments[i])
+		openingProof, err := kzg.Open(c.domain(), polynomial, evaluationChallenge, c.commitKey(), msmGoRoutines)
+		if err != nil {
+			return err
+		}
+		sidecars.Proofs[i] = KZGProof(SerializeG1Point(openingProof.QuotientCommitment))
+		return nil
+	})
+	if err != nil {
+		return BlobSidecars{}, err
+	}
+
+	return sidecars, nil
+}
@@ -0,0 +1,37 @@
+package gokzg4844_test
+
+import (
+	"context"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSidecars(t *testing.T) {
+	blobs := []gokzg4844.Blob{*GetRandBlob(1), *GetRandBlob(2), *GetRandBlob(3)}
+	sidecars, err := ctx.BuildSidecars(blobs, NumGoRoutines)
+	require.NoError(t, err)
+
+	commitments, err := ctx.BlobsToKZGCommitments(blobs, NumGoRoutines)
+	require.NoError(t, err)
+	proofs, err := ctx.ComputeBlobKZGProofs(blobs, commitments, NumGoRoutines)
+	require.NoError(t, err)
+	require.Equal(t, commitments, sidecars.Commitments)
+	require.Equal(t, proofs, sidecars.Proofs)
+	for i := range blobs {
+		require.Equal(t, gokzg4844.KZGToVersionedHash(commitments[i]), sidecars.VersionedHashes[i])
+	}
+	require.NoError(t, ctx.VerifyBlobKZGProofBatch(blobs, sidecars.Commitments, sidecars.Proofs))
+
+	// An invalid blob fails the whole call
+	blobs[1] = *GetRandBlob(4)
+	modifyBlob(&blobs[1], nonCanonicalScalar(1), 0)
+	_, err = ctx.BuildSidecars(blobs, NumGoRoutines)
+	require.ErrorIs(t, err, gokzg4844.ErrBlobNotCanonical)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ctx.BuildSidecarsCtx(cancelled, blobs, NumGoRoutines)
+	require.ErrorIs(t, err, context.Canceled)
+}
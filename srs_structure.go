@@ -0,0 +1,45 @@
+package gokzg4844
+
+import "fmt"
+
+// VerifySRSStructure checks that the trusted setup is a structured reference string; that is, that its points are
+// the Lagrange and monomial forms of the successive powers of a single secret tau.
+//
+// To be specific, this checks that:
+//   - All elements are in the correct subgroup, as [CheckTrustedSetupIsWellFormed] does.
+//   - There are at least two G2 points.
+//   - The monomial G1 points [tau^i]G1, which are derived from the Lagrange G1 points, and the G2 points [tau^i]H
+//     start at the generators, and tau is not zero or one.
+//   - e([tau^(i+1)]G1, H) == e([tau^i]G1, [tau]H) for every i, and the same for the G2 points. This is checked for
+//     all i at once, with a random linear combination, so it takes two multi-scalar multiplications and a few
+//     pairings.
+//
+// A setup whose points are all valid but which was corrupted or crafted, for example by swapping or replacing some of
+// its points, passes [CheckTrustedSetupIsWellFormed] but not this check. The setup from the Ethereum KZG ceremony
+// passes, so this only needs to be run when loading a setup from another source. Deriving the monomial points takes a
+// few seconds.
+//
+// Returns an error wrapping [ErrInvalidSRSStructure] if a check fails.
+func VerifySRSStructure(setup *JSONTrustedSetup) error {
+	if len(setup.SetupG2) < 2 {
+		return fmt.Errorf("%w: expected at least 2 G2 points", ErrInvalidSRSStructure)
+	}
+	if err := CheckTrustedSetupIsWellFormedPar(setup, 0); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSRSStructure, err)
+	}
+	_, lagrange, g2Points, err := parseTrustedSetup(setup, 0)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSRSStructure, err)
+	}
+
+	domain, err := NewDomain(ScalarsPerBlob)
+	if err != nil {
+		return err
+	}
+	monomial, err := domain.LagrangeToMonomialG1(lagrange, NaturalOrder)
+	if err != nil {
+		return err
+	}
+
+	return checkPowersOfTau(monomial, g2Points, ErrInvalidSRSStructure)
+}
@@ -0,0 +1,46 @@
+package gokzg4844_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySRSStructure(t *testing.T) {
+	require.NoError(t, gokzg4844.VerifySRSStructure(ctx.TrustedSetup()))
+
+	// Each point is valid, but two of them are swapped
+	swapped := ctx.TrustedSetup()
+	swapped.SetupG1Lagrange[1], swapped.SetupG1Lagrange[2] = swapped.SetupG1Lagrange[2], swapped.SetupG1Lagrange[1]
+	require.ErrorIs(t, gokzg4844.VerifySRSStructure(swapped), gokzg4844.ErrInvalidSRSStructure)
+
+	tooFew := ctx.TrustedSetup()
+	tooFew.SetupG2 = tooFew.SetupG2[:1]
+	require.ErrorIs(t, gokzg4844.VerifySRSStructure(tooFew), gokzg4844.ErrInvalidSRSStructure)
+
+	// The error of a point which cannot be decoded is kept
+	malformed := ctx.TrustedSetup()
+	malformed.SetupG1Lagrange[0] = "0xzz"
+	err := gokzg4844.VerifySRSStructure(malformed)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidSRSStructure)
+	var byteErr hex.InvalidByteError
+	require.ErrorAs(t, err, &byteErr)
+}
+
+func TestVerifySRSStructureInsecure(t *testing.T) {
+	setup, err := gokzg4844.NewInsecureSetup(big.NewInt(5), gokzg4844.ScalarsPerBlob, 3)
+	require.NoError(t, err)
+	insecure, err := setup.JSON()
+	require.NoError(t, err)
+
+	// An insecure setup is still a valid structured reference string
+	require.NoError(t, gokzg4844.VerifySRSStructure(insecure))
+
+	// The G1 and G2 points are for different secrets
+	mixed := ctx.TrustedSetup()
+	mixed.SetupG2 = insecure.SetupG2
+	require.ErrorIs(t, gokzg4844.VerifySRSStructure(mixed), gokzg4844.ErrInvalidSRSStructure)
+}
@@ -4,18 +4,38 @@ import (
 	"bytes"
 	_ "embed"
 	"encoding/hex"
-	"sync"
+	"runtime"
 
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"golang.org/x/sync/errgroup"
 )
 
 // This library will not :
-// - Check that the points are in the correct subgroup.
+// - Check that the points are in the correct subgroup, unless requested; see [SetupChecks].
 // - Check that setupG1Lagrange is the lagrange version of setupG1.
 //
 // Note: There is an embedded (via a //go:embed - compiler instruction) setup
 // testKzgSetupStr, to which we do check those properties in a test function.
 
+// SetupChecks selects when the points of a trusted setup are checked to be in the correct subgroup. See
+// [WithSetupChecks].
+type SetupChecks int
+
+const (
+	// SetupChecksUnknown checks the points of every setup except the one from the Ethereum KZG ceremony, which is
+	// identified by [MainnetSetupDigest].
+	SetupChecksUnknown SetupChecks = iota
+	// SetupChecksAlways checks the points of every setup.
+	SetupChecksAlways
+	// SetupChecksNever does not check the points. This must only be used for setups which come from a trusted
+	// source, since a point which is not in the subgroup breaks the security of the proofs.
+	SetupChecksNever
+)
+
+// maxSetupG2Points bounds the number of G2 points that is accepted when decoding a trusted setup from untrusted data,
+// so that a malicious count cannot cause a large allocation. The setups used by Ethereum have 65 G2 points.
+const maxSetupG2Points = ScalarsPerBlob
+
 // JSONTrustedSetup is a struct used for serializing the trusted setup from/to JSON format.
 //
 // The intended use-case is that library users store the trusted setup in a JSON file and we provide such a file
@@ -42,47 +62,70 @@ var testKzgSetupStr string
 // To be specific, this checks that:
 //   - All elements are in the correct subgroup.
 func CheckTrustedSetupIsWellFormed(trustedSetup *JSONTrustedSetup) error {
-	for i := 0; i < len(trustedSetup.SetupG1Lagrange); i++ {
-		var point bls12381.G1Affine
-		byts, err := hex.DecodeString(trim0xPrefix(trustedSetup.SetupG1Lagrange[i]))
-		if err != nil {
-			return err
-		}
-		_, err = point.SetBytes(byts)
-		if err != nil {
-			return err
-		}
-	}
+	return CheckTrustedSetupIsWellFormedPar(trustedSetup, 1)
+}
 
-	for i := 0; i < len(trustedSetup.SetupG2); i++ {
-		var point bls12381.G2Affine
-		byts, err := hex.DecodeString(trim0xPrefix(trustedSetup.SetupG2[i]))
-		if err != nil {
-			return err
-		}
-		_, err = point.SetBytes(byts)
-		if err != nil {
-			return err
+// CheckTrustedSetupIsWellFormedPar is the same as [CheckTrustedSetupIsWellFormed], except that the points are
+// checked by up to numGoRoutines go-routines. Setting numGoRoutines to a negative number or 0 will make it default
+// to the number of CPUs.
+func CheckTrustedSetupIsWellFormedPar(trustedSetup *JSONTrustedSetup, numGoRoutines int) error {
+	err := forEachSetupChunk(len(trustedSetup.SetupG1Lagrange), numGoRoutines, func(start, end int) error {
+		for i := start; i < end; i++ {
+			var point bls12381.G1Affine
+			byts, err := hex.DecodeString(trim0xPrefix(trustedSetup.SetupG1Lagrange[i]))
+			if err != nil {
+				return err
+			}
+			_, err = point.SetBytes(byts)
+			if err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return forEachSetupChunk(len(trustedSetup.SetupG2), numGoRoutines, func(start, end int) error {
+		for i := start; i < end; i++ {
+			var point bls12381.G2Affine
+			byts, err := hex.DecodeString(trim0xPrefix(trustedSetup.SetupG2[i]))
+			if err != nil {
+				return err
+			}
+			_, err = point.SetBytes(byts)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 // parseTrustedSetup parses the trusted setup in `JSONTrustedSetup` format
 // which contains hex encoded strings to corresponding group elements.
 // Elements are assumed to be well-formed.
 //
-// This method wil panic if the points have not been serialized correctly.
-func parseTrustedSetup(trustedSetup *JSONTrustedSetup) (bls12381.G1Affine, []bls12381.G1Affine, []bls12381.G2Affine) {
+// The points are decompressed by up to numGoRoutines go-routines, or by one
+// go-routine per CPU if numGoRoutines is not positive.
+//
+// An error is returned if the points have not been serialized correctly.
+func parseTrustedSetup(trustedSetup *JSONTrustedSetup, numGoRoutines int) (bls12381.G1Affine, []bls12381.G1Affine, []bls12381.G2Affine, error) {
 	// The G1 generator is the first element of the monomial G1 points.
 	// We do not have that and so we use the fact that the setup started at
 	// the canonical generator point.
 	_, _, genG1, _ := bls12381.Generators()
 
-	setupLagrangeG1Points := parseG1PointsNoSubgroupCheck(trustedSetup.SetupG1Lagrange[:])
-	g2Points := parseG2PointsNoSubgroupCheck(trustedSetup.SetupG2)
-	return genG1, setupLagrangeG1Points, g2Points
+	setupLagrangeG1Points, err := parseG1PointsNoSubgroupCheck(trustedSetup.SetupG1Lagrange[:], numGoRoutines)
+	if err != nil {
+		return bls12381.G1Affine{}, nil, nil, err
+	}
+	g2Points, err := parseG2PointsNoSubgroupCheck(trustedSetup.SetupG2, numGoRoutines)
+	if err != nil {
+		return bls12381.G1Affine{}, nil, nil, err
+	}
+	return genG1, setupLagrangeG1Points, g2Points, nil
 }
 
 // parseG1PointNoSubgroupCheck parses a hex-string (with the 0x prefix) into a G1 point.
@@ -123,58 +166,86 @@ func parseG2PointNoSubgroupCheck(hexString string) (bls12381.G2Affine, error) {
 // slice of G1 points.
 //
 // This is essentially a parallelized version of calling [parseG1PointNoSubgroupCheck]
-// on each element of the slice individually.
+// on each element of the slice individually, using up to numGoRoutines go-routines.
 //
 // This function performs no (expensive) subgroup checks, and should only be used
 // for trusted inputs.
-func parseG1PointsNoSubgroupCheck(hexStrings []string) []bls12381.G1Affine {
-	numG1 := len(hexStrings)
-	g1Points := make([]bls12381.G1Affine, numG1)
-
-	var wg sync.WaitGroup
-	wg.Add(numG1)
-	for i := 0; i < numG1; i++ {
-		go func(j int) {
-			g1Point, err := parseG1PointNoSubgroupCheck(hexStrings[j])
+func parseG1PointsNoSubgroupCheck(hexStrings []string, numGoRoutines int) ([]bls12381.G1Affine, error) {
+	g1Points := make([]bls12381.G1Affine, len(hexStrings))
+
+	err := forEachSetupChunk(len(hexStrings), numGoRoutines, func(start, end int) error {
+		for i := start; i < end; i++ {
+			g1Point, err := parseG1PointNoSubgroupCheck(hexStrings[i])
 			if err != nil {
-				panic(err)
+				return err
 			}
-			g1Points[j] = g1Point
-			wg.Done()
-		}(i)
+			g1Points[i] = g1Point
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	wg.Wait()
 
-	return g1Points
+	return g1Points, nil
 }
 
 // parseG2PointsNoSubgroupCheck parses a slice hex-string (with the 0x prefix) into a
 // slice of G2 points.
 //
 // This is essentially a parallelized version of calling [parseG2PointNoSubgroupCheck]
-// on each element of the slice individually.
+// on each element of the slice individually, using up to numGoRoutines go-routines.
 //
 // This function performs no (expensive) subgroup checks, and should only be used
 // for trusted inputs.
-func parseG2PointsNoSubgroupCheck(hexStrings []string) []bls12381.G2Affine {
-	numG2 := len(hexStrings)
-	g2Points := make([]bls12381.G2Affine, numG2)
-
-	var wg sync.WaitGroup
-	wg.Add(numG2)
-	for i := 0; i < numG2; i++ {
-		go func(_i int) {
-			g2Point, err := parseG2PointNoSubgroupCheck(hexStrings[_i])
+func parseG2PointsNoSubgroupCheck(hexStrings []string, numGoRoutines int) ([]bls12381.G2Affine, error) {
+	g2Points := make([]bls12381.G2Affine, len(hexStrings))
+
+	err := forEachSetupChunk(len(hexStrings), numGoRoutines, func(start, end int) error {
+		for i := start; i < end; i++ {
+			g2Point, err := parseG2PointNoSubgroupCheck(hexStrings[i])
 			if err != nil {
-				panic(err)
+				return err
 			}
-			g2Points[_i] = g2Point
-			wg.Done()
-		}(i)
+			g2Points[i] = g2Point
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	wg.Wait()
 
-	return g2Points
+	return g2Points, nil
+}
+
+// forEachSetupChunk splits the indices [0, n) into at most numGoRoutines contiguous chunks, and calls work on each
+// of them from its own go-routine. It returns the first error from work.
+//
+// Setting numGoRoutines to a negative number or 0 will make it default to the number of CPUs.
+func forEachSetupChunk(n, numGoRoutines int, work func(start, end int) error) error {
+	if numGoRoutines <= 0 {
+		numGoRoutines = runtime.NumCPU()
+	}
+	if numGoRoutines > n {
+		numGoRoutines = n
+	}
+	if numGoRoutines <= 1 {
+		return work(0, n)
+	}
+
+	chunkSize := (n + numGoRoutines - 1) / numGoRoutines
+	var errG errgroup.Group
+	for start := 0; start < n; start += chunkSize {
+		start := start
+		end := start + chunkSize
+		if end > n {
+			end = n
+		}
+		errG.Go(func() error {
+			return work(start, end)
+		})
+	}
+	return errG.Wait()
 }
 
 // trim0xPrefix removes the "0x" from a hex-string.
@@ -0,0 +1,166 @@
+package gokzg4844
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// compressionFlag is the bit of the first byte of a serialized point which marks it as compressed.
+const compressionFlag = 0x80
+
+// NewContextFromBytes is the same as [NewContext4096], except that the points of the trusted setup are given as raw
+// bytes instead of hex strings. This is useful for embedders which store the setup in their own format, or generate it
+// programmatically.
+//
+// g1Lagrange holds the [ScalarsPerBlob] Lagrange G1 points, in the natural order used by [JSONTrustedSetup], and g2
+// holds the monomial G2 points, of which there must be at least two. The points are concatenated, each in either the
+// compressed or the uncompressed encoding of the [Zcash serialization format]. The encoding of a slice is taken from
+// the first point, and all of its points must use the same encoding.
+//
+// The setup is identified by the same digest as its [JSONTrustedSetup], so the setup from the Ethereum KZG ceremony
+// skips the subgroup checks by default; see [WithSetupChecks].
+//
+// Returns [ErrInvalidPointEncoding] if the bytes cannot be split into points of a single encoding, or a point cannot be
+// deserialized, and [ErrPointNotOnCurve] or [ErrPointNotInSubgroup] if a point is invalid.
+//
+// [Zcash serialization format]: https://github.com/zkcrypto/pairing/blob/34aa52b0f7bef705917252ea63e5a13fa01af551/src/bls12_381/README.md#serialization
+func NewContextFromBytes(g1Lagrange []byte, g2 []byte, opts ...Option) (*Context, error) {
+	cfg := newConfig(opts)
+
+	if err := SelfTest(); err != nil {
+		return nil, err
+	}
+
+	g1Size, err := rawPointSize(g1Lagrange, CompressedG1Size)
+	if err != nil {
+		return nil, err
+	}
+	if len(g1Lagrange) != ScalarsPerBlob*g1Size {
+		return nil, ErrInvalidPointEncoding
+	}
+	g2Size, err := rawPointSize(g2, CompressedG2Size)
+	if err != nil {
+		return nil, err
+	}
+	if len(g2)%g2Size != 0 {
+		return nil, ErrInvalidPointEncoding
+	}
+	// This should not happen for the ETH protocol
+	// However since it's a public method, we add the check.
+	if len(g2)/g2Size < 2 {
+		return nil, kzg.ErrMinSRSSize
+	}
+
+	g1Points := make([]bls12381.G1Affine, ScalarsPerBlob)
+	err = forEachSetupChunk(len(g1Points), cfg.numGoRoutines, func(start, end int) error {
+		for i := start; i < end; i++ {
+			raw := g1Lagrange[i*g1Size : (i+1)*g1Size]
+			if err := decodeRawPointNoSubgroupCheck(raw, g1Size == CompressedG1Size, &g1Points[i]); err != nil {
+				return err
+			}
+			if !g1Points[i].IsOnCurve() {
+				return ErrPointNotOnCurve
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	g2Points := make([]bls12381.G2Affine, len(g2)/g2Size)
+	for i := range g2Points {
+		raw := g2[i*g2Size : (i+1)*g2Size]
+		if err := decodeRawPointNoSubgroupCheck(raw, g2Size == CompressedG2Size, &g2Points[i]); err != nil {
+			return nil, err
+		}
+		if !g2Points[i].IsOnCurve() {
+			return nil, ErrPointNotOnCurve
+		}
+	}
+
+	return newContext4096FromPoints(cfg, g1Points, g2Points)
+}
+
+// rawPointSize returns the size of the points in data, which is compressedSize if the first point is compressed and
+// twice that otherwise.
+func rawPointSize(data []byte, compressedSize int) (int, error) {
+	if len(data) == 0 {
+		return 0, ErrInvalidPointEncoding
+	}
+	if data[0]&compressionFlag != 0 {
+		return compressedSize, nil
+	}
+	return 2 * compressedSize, nil
+}
+
+// decodeRawPointNoSubgroupCheck decodes a point, which must be compressed if and only if compressed is true, without
+// checking that it is in the correct subgroup.
+//
+// Returns [ErrInvalidPointEncoding] if the bytes are not an encoding of a point of the expected kind.
+func decodeRawPointNoSubgroupCheck(raw []byte, compressed bool, point interface{}) error {
+	if (raw[0]&compressionFlag != 0) != compressed {
+		return ErrInvalidPointEncoding
+	}
+	d := bls12381.NewDecoder(bytes.NewReader(raw), bls12381.NoSubgroupChecks())
+	if err := d.Decode(point); err != nil {
+		return ErrInvalidPointEncoding
+	}
+	return nil
+}
+
+// newContext4096FromPoints creates a context from the deserialized points of a trusted setup, which are known to be
+// on the curve. The points are checked to be in the correct subgroup if the configuration requires it.
+func newContext4096FromPoints(cfg config, g1Points []bls12381.G1Affine, g2Points []bls12381.G2Affine) (*Context, error) {
+	return newContextFromPoints(cfg, mainnetPreset, g1Points, g2Points)
+}
+
+// newContextFromPoints is the same as [newContext4096FromPoints], for the given preset.
+func newContextFromPoints(cfg config, preset Preset, g1Points []bls12381.G1Affine, g2Points []bls12381.G2Affine) (*Context, error) {
+	setupDigest := setupDigestOfPoints(g1Points, g2Points)
+	if cfg.needsSetupChecks(setupDigest) {
+		err := forEachSetupChunk(len(g1Points), cfg.numGoRoutines, func(start, end int) error {
+			for i := start; i < end; i++ {
+				if !g1Points[i].IsInSubGroup() {
+					return ErrPointNotInSubgroup
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i := range g2Points {
+			if !g2Points[i].IsInSubGroup() {
+				return nil, ErrPointNotInSubgroup
+			}
+		}
+	}
+
+	_, _, genG1, _ := bls12381.Generators()
+	return newContext(cfg, preset, setupDigest, genG1, g1Points, g2Points)
+}
+
+// setupDigestOfPoints computes the digest, as computed by [SetupDigest], of the setup with the given points. The
+// points are compressed first, which does not need any expensive field operations.
+func setupDigestOfPoints(g1Points []bls12381.G1Affine, g2Points []bls12381.G2Affine) [32]byte {
+	h := sha256.New()
+	h.Write([]byte(setupDigestDomain))
+
+	writeLength(h, len(g1Points))
+	for i := range g1Points {
+		compressed := g1Points[i].Bytes()
+		h.Write(compressed[:])
+	}
+	writeLength(h, len(g2Points))
+	for i := range g2Points {
+		compressed := g2Points[i].Bytes()
+		h.Write(compressed[:])
+	}
+
+	var digest [32]byte
+	copy(digest[:], h.Sum(nil))
+	return digest
+}
@@ -0,0 +1,86 @@
+package gokzg4844
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+	"github.com/stretchr/testify/require"
+)
+
+// rawMainnetSetup returns the points of the mainnet setup, concatenated in the compressed or the uncompressed
+// encoding.
+func rawMainnetSetup(t *testing.T, compressed bool) ([]byte, []byte) {
+	parsedSetup := JSONTrustedSetup{}
+	err := json.Unmarshal([]byte(testKzgSetupStr), &parsedSetup)
+	require.NoError(t, err)
+
+	_, g1Points, g2Points, err := parseTrustedSetup(&parsedSetup, 0)
+	require.NoError(t, err)
+	var g1, g2 bytes.Buffer
+	for i := range g1Points {
+		if compressed {
+			b := g1Points[i].Bytes()
+			g1.Write(b[:])
+		} else {
+			b := g1Points[i].RawBytes()
+			g1.Write(b[:])
+		}
+	}
+	for i := range g2Points {
+		if compressed {
+			b := g2Points[i].Bytes()
+			g2.Write(b[:])
+		} else {
+			b := g2Points[i].RawBytes()
+			g2.Write(b[:])
+		}
+	}
+	return g1.Bytes(), g2.Bytes()
+}
+
+func TestNewContextFromBytes(t *testing.T) {
+	ctxJSON, err := NewContext4096Secure()
+	require.NoError(t, err)
+
+	for _, compressed := range []bool{true, false} {
+		g1, g2 := rawMainnetSetup(t, compressed)
+		ctxBytes, err := NewContextFromBytes(g1, g2)
+		require.NoError(t, err)
+		require.Equal(t, MainnetSetupDigest, ctxBytes.SetupDigest())
+		require.Equal(t, ctxJSON.commitKey().G1, ctxBytes.commitKey().G1)
+		require.Equal(t, *ctxJSON.openKey, *ctxBytes.openKey)
+	}
+
+	// The encodings of the G1 and G2 points are detected independently
+	g1, _ := rawMainnetSetup(t, true)
+	_, g2 := rawMainnetSetup(t, false)
+	_, err = NewContextFromBytes(g1, g2)
+	require.NoError(t, err)
+}
+
+func TestNewContextFromBytesInvalid(t *testing.T) {
+	g1, g2 := rawMainnetSetup(t, true)
+
+	_, err := NewContextFromBytes(nil, g2)
+	require.ErrorIs(t, err, ErrInvalidPointEncoding)
+	_, err = NewContextFromBytes(g1[:len(g1)-CompressedG1Size], g2)
+	require.ErrorIs(t, err, ErrInvalidPointEncoding)
+	_, err = NewContextFromBytes(g1, g2[:len(g2)-1])
+	require.ErrorIs(t, err, ErrInvalidPointEncoding)
+	_, err = NewContextFromBytes(g1, g2[:CompressedG2Size])
+	require.ErrorIs(t, err, kzg.ErrMinSRSSize)
+
+	// A point in a different encoding than the first one
+	mixed := append([]byte{}, g1...)
+	mixed[CompressedG1Size] &^= compressionFlag
+	_, err = NewContextFromBytes(mixed, g2)
+	require.ErrorIs(t, err, ErrInvalidPointEncoding)
+
+	// An uncompressed point which is not on the curve
+	g1Raw, _ := rawMainnetSetup(t, false)
+	g1Raw[UncompressedG1Size-1] ^= 1
+	_, err = NewContextFromBytes(g1Raw, g2)
+	require.ErrorIs(t, err, ErrPointNotOnCurve)
+}
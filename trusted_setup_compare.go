@@ -0,0 +1,105 @@
+package gokzg4844
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/crate-crypto/go-kzg-4844/internal/utils"
+)
+
+// SetupDiff describes the first difference found between two trusted setups.
+type SetupDiff struct {
+	// Field is the JSON name of the list in which the difference was found, ie
+	// "g1_lagrange" or "g2_monomial".
+	Field string
+	// Index is the position of the first element which differs. If the lists
+	// have different lengths, this is the length of the shorter list.
+	Index int
+}
+
+// SetupsEquivalent returns true if the two trusted setups contain the same points.
+//
+// Unlike comparing the JSON files byte for byte, this:
+//   - Ignores differences in the encoding of the hex strings, such as upper and lower case or a missing 0x prefix.
+//   - Treats a list of Lagrange G1 points which is in bit-reversed order as equal to the same list in natural order.
+//
+// If the setups are not equivalent, the returned [SetupDiff] reports the first element that differs, when both
+// setups are compared in the order they were given. If the setups are equivalent, the [SetupDiff] is the zero value.
+//
+// This method does not check that the points are in the correct subgroup; see [CheckTrustedSetupIsWellFormed].
+func SetupsEquivalent(a, b *JSONTrustedSetup) (bool, SetupDiff) {
+	g1A := canonicalizeHexPoints(a.SetupG1Lagrange[:])
+	g1B := canonicalizeHexPoints(b.SetupG1Lagrange[:])
+
+	g1Index := firstDifference(g1A, g1B)
+	if g1Index != -1 {
+		// The setup may have been stored after the bit-reversal permutation was applied
+		g1BReversed := make([]string, len(g1B))
+		copy(g1BReversed, g1B)
+		utils.BitReverse(g1BReversed)
+
+		if firstDifference(g1A, g1BReversed) != -1 {
+			return false, SetupDiff{Field: "g1_lagrange", Index: g1Index}
+		}
+	}
+
+	g2Index := firstDifference(canonicalizeHexPoints(a.SetupG2), canonicalizeHexPoints(b.SetupG2))
+	if g2Index != -1 {
+		return false, SetupDiff{Field: "g2_monomial", Index: g2Index}
+	}
+
+	return true, SetupDiff{}
+}
+
+// canonicalizeHexPoints returns the canonical encoding of each hex-encoded point.
+//
+// The canonical encoding of a point is the lowercase hex-string of its compressed serialization,
+// without a 0x prefix. Strings which do not decode to a valid point are lowercased and compared as they are.
+func canonicalizeHexPoints(hexStrings []string) []string {
+	canonical := make([]string, len(hexStrings))
+	for i, hexString := range hexStrings {
+		canonical[i] = canonicalizeHexPoint(hexString)
+	}
+	return canonical
+}
+
+// canonicalizeHexPoint returns the canonical encoding of a hex-encoded G1 or G2 point.
+func canonicalizeHexPoint(hexString string) string {
+	hexString = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(hexString)), "0x")
+
+	switch len(hexString) {
+	case 2 * CompressedG1Size:
+		point, err := parseG1PointNoSubgroupCheck("0x" + hexString)
+		if err != nil {
+			return hexString
+		}
+		compressed := point.Bytes()
+		return hex.EncodeToString(compressed[:])
+	case 2 * CompressedG2Size:
+		point, err := parseG2PointNoSubgroupCheck("0x" + hexString)
+		if err != nil {
+			return hexString
+		}
+		compressed := point.Bytes()
+		return hex.EncodeToString(compressed[:])
+	default:
+		return hexString
+	}
+}
+
+// firstDifference returns the index of the first element which differs between a and b, or -1 if they are equal.
+func firstDifference(a, b []string) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	if len(a) != len(b) {
+		return n
+	}
+	return -1
+}
@@ -0,0 +1,140 @@
+package gokzg4844
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// TrustedSetup returns the trusted setup that the context was created with. The Lagrange G1 points are in the
+// natural order used by the setup files, whatever the evaluation order of the context.
+//
+// This can be used to check which setup a running node actually loaded, or to write it out in another format with
+// [JSONTrustedSetup.WriteJSON] or [Context.WriteTextTrustedSetup].
+//
+// Returns nil if the blobs of the preset of the context do not have [ScalarsPerBlob] scalars, since the setup cannot
+// be represented as a [JSONTrustedSetup] then.
+func (c *Context) TrustedSetup() *JSONTrustedSetup {
+	if c.preset.ScalarsPerBlob != ScalarsPerBlob || c.checkOpen() != nil {
+		return nil
+	}
+
+	var setup JSONTrustedSetup
+
+	g1Points, g2Points := c.commitKey().G1, c.setupG2()
+	n := len(g1Points)
+	for i := range g1Points {
+		compressed := g1Points[i].Bytes()
+		setup.SetupG1Lagrange[c.order.SourceIndex(i, n)] = "0x" + hex.EncodeToString(compressed[:])
+	}
+	setup.SetupG2 = make([]G2CompressedHexStr, len(g2Points))
+	for i := range g2Points {
+		compressed := g2Points[i].Bytes()
+		setup.SetupG2[i] = "0x" + hex.EncodeToString(compressed[:])
+	}
+
+	return &setup
+}
+
+// WriteJSON writes the trusted setup to w in JSON format, laid out in the same way as the setup files used by
+// Ethereum: the Lagrange G1 points followed by the monomial G2 points, with each point as lowercase hex with the 0x
+// prefix.
+//
+// Returns [ErrInvalidPointEncoding] if a point is not the hex encoding of a compressed point of the right size.
+func (s *JSONTrustedSetup) WriteJSON(w io.Writer) error {
+	g1, g2, err := s.normalizedHexPoints()
+	if err != nil {
+		return err
+	}
+	for i := range g1 {
+		g1[i] = "0x" + g1[i]
+	}
+	for i := range g2 {
+		g2[i] = "0x" + g2[i]
+	}
+
+	// The field order differs from JSONTrustedSetup, to match the setup files
+	ordered := struct {
+		SetupG1Lagrange []G1CompressedHexStr `json:"g1_lagrange"`
+		SetupG2         []G2CompressedHexStr `json:"g2_monomial"`
+	}{g1, g2}
+	encoded, err := json.MarshalIndent(&ordered, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(encoded)
+	return err
+}
+
+// WriteText writes the trusted setup to w in the text format read by [ParseTextTrustedSetup], without the optional
+// monomial G1 points. Use [Context.WriteTextTrustedSetup] to include them, as newer versions of [c-kzg-4844] need.
+//
+// Returns [ErrInvalidPointEncoding] if a point is not the hex encoding of a compressed point of the right size.
+//
+// [c-kzg-4844]: https://github.com/ethereum/c-kzg-4844/blob/main/src/trusted_setup.txt
+func (s *JSONTrustedSetup) WriteText(w io.Writer) error {
+	return s.writeText(w, nil)
+}
+
+// WriteTextTrustedSetup writes the trusted setup that the context was created with to w in the text format read by
+// [ParseTextTrustedSetup], including the monomial G1 points at the end. The monomial points are derived from the
+// Lagrange points if this has not happened yet; see [Context.MonomialG1Points].
+//
+// Returns [ErrPresetMismatch] if the blobs of the preset of the context do not have [ScalarsPerBlob] scalars.
+func (c *Context) WriteTextTrustedSetup(w io.Writer) error {
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+	setup := c.TrustedSetup()
+	if setup == nil {
+		return ErrPresetMismatch
+	}
+	return setup.writeText(w, c.monomialG1())
+}
+
+// writeText writes the trusted setup in the text format, followed by the monomial G1 points if there are any.
+func (s *JSONTrustedSetup) writeText(w io.Writer, monomialG1 []bls12381.G1Affine) error {
+	g1, g2, err := s.normalizedHexPoints()
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%d\n%d\n", len(g1), len(g2))
+	for _, point := range g1 {
+		fmt.Fprintln(bw, point)
+	}
+	for _, point := range g2 {
+		fmt.Fprintln(bw, point)
+	}
+	for i := range monomialG1 {
+		compressed := monomialG1[i].Bytes()
+		fmt.Fprintln(bw, hex.EncodeToString(compressed[:]))
+	}
+	return bw.Flush()
+}
+
+// normalizedHexPoints returns the points of the setup as lowercase hex, without the 0x prefix.
+func (s *JSONTrustedSetup) normalizedHexPoints() ([]string, []string, error) {
+	g1 := make([]string, len(s.SetupG1Lagrange))
+	for i, point := range s.SetupG1Lagrange {
+		compressed, err := decodeHexPoint(point, CompressedG1Size)
+		if err != nil {
+			return nil, nil, err
+		}
+		g1[i] = hex.EncodeToString(compressed)
+	}
+	g2 := make([]string, len(s.SetupG2))
+	for i, point := range s.SetupG2 {
+		compressed, err := decodeHexPoint(point, CompressedG2Size)
+		if err != nil {
+			return nil, nil, err
+		}
+		g2[i] = hex.EncodeToString(compressed)
+	}
+	return g1, g2, nil
+}
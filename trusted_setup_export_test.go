@@ -0,0 +1,53 @@
+package gokzg4844
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustedSetupExport(t *testing.T) {
+	parsedSetup := JSONTrustedSetup{}
+	err := json.Unmarshal([]byte(testKzgSetupStr), &parsedSetup)
+	require.NoError(t, err)
+
+	// The exported setup does not depend on the evaluation order of the context
+	for _, order := range []Permutation{BitReversedOrder, NaturalOrder} {
+		ctx, err := NewContext4096(&parsedSetup, WithEvaluationOrder(order))
+		require.NoError(t, err)
+		require.Equal(t, &parsedSetup, ctx.TrustedSetup())
+	}
+
+	// The JSON is laid out in the same way as the embedded file
+	var buf bytes.Buffer
+	require.NoError(t, parsedSetup.WriteJSON(&buf))
+	require.Equal(t, strings.TrimSpace(testKzgSetupStr), buf.String())
+}
+
+func TestTrustedSetupExportText(t *testing.T) {
+	ctx, err := NewContext4096Secure()
+	require.NoError(t, err)
+	setup := ctx.TrustedSetup()
+
+	var withoutMonomial, withMonomial bytes.Buffer
+	require.NoError(t, setup.WriteText(&withoutMonomial))
+	require.NoError(t, ctx.WriteTextTrustedSetup(&withMonomial))
+	require.Equal(t, 2+ScalarsPerBlob+len(setup.SetupG2), strings.Count(withoutMonomial.String(), "\n"))
+	require.Equal(t, 2+2*ScalarsPerBlob+len(setup.SetupG2), strings.Count(withMonomial.String(), "\n"))
+
+	for _, text := range []*bytes.Buffer{&withoutMonomial, &withMonomial} {
+		parsed, err := ParseTextTrustedSetup(text)
+		require.NoError(t, err)
+		require.Equal(t, setup, parsed)
+	}
+}
+
+func TestTrustedSetupExportInvalid(t *testing.T) {
+	var setup JSONTrustedSetup
+	setup.SetupG2 = []G2CompressedHexStr{"0x00"}
+	require.ErrorIs(t, setup.WriteJSON(&bytes.Buffer{}), ErrInvalidPointEncoding)
+	require.ErrorIs(t, setup.WriteText(&bytes.Buffer{}), ErrInvalidPointEncoding)
+}
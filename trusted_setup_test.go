@@ -1,9 +1,13 @@
 package gokzg4844
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/crate-crypto/go-kzg-4844/internal/utils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,3 +19,84 @@ func TestTransformTrustedSetup(t *testing.T) {
 	err = CheckTrustedSetupIsWellFormed(&parsedSetup)
 	require.NoError(t, err)
 }
+
+func TestSetupsEquivalent(t *testing.T) {
+	parsedSetup := JSONTrustedSetup{}
+	err := json.Unmarshal([]byte(testKzgSetupStr), &parsedSetup)
+	require.NoError(t, err)
+
+	// Change the formatting of the hex strings and reverse the G1 points
+	reformattedSetup := parsedSetup
+	reformattedSetup.SetupG2 = make([]G2CompressedHexStr, len(parsedSetup.SetupG2))
+	for i, hexString := range parsedSetup.SetupG2 {
+		reformattedSetup.SetupG2[i] = strings.ToUpper(trim0xPrefix(hexString))
+	}
+	utils.BitReverse(reformattedSetup.SetupG1Lagrange[:])
+
+	equivalent, _ := SetupsEquivalent(&parsedSetup, &reformattedSetup)
+	require.True(t, equivalent)
+
+	// Swap two of the G1 points
+	modifiedSetup := parsedSetup
+	modifiedSetup.SetupG1Lagrange[5], modifiedSetup.SetupG1Lagrange[6] = modifiedSetup.SetupG1Lagrange[6], modifiedSetup.SetupG1Lagrange[5]
+	equivalent, diff := SetupsEquivalent(&parsedSetup, &modifiedSetup)
+	require.False(t, equivalent)
+	require.Equal(t, SetupDiff{Field: "g1_lagrange", Index: 5}, diff)
+
+	// Remove one of the G2 points
+	modifiedSetup = parsedSetup
+	modifiedSetup.SetupG2 = parsedSetup.SetupG2[:len(parsedSetup.SetupG2)-1]
+	equivalent, diff = SetupsEquivalent(&parsedSetup, &modifiedSetup)
+	require.False(t, equivalent)
+	require.Equal(t, SetupDiff{Field: "g2_monomial", Index: len(parsedSetup.SetupG2) - 1}, diff)
+}
+
+func TestSetupChecks(t *testing.T) {
+	parsedSetup := JSONTrustedSetup{}
+	err := json.Unmarshal([]byte(testKzgSetupStr), &parsedSetup)
+	require.NoError(t, err)
+
+	// The mainnet setup is accepted whether or not the checks are run
+	_, err = NewContext4096(&parsedSetup, WithSetupChecks(SetupChecksAlways))
+	require.NoError(t, err)
+
+	// Find a point which is on the curve but not in the subgroup
+	var notInSubgroup KZGCommitment
+	for x := 1; x < 256; x++ {
+		var candidate KZGCommitment
+		candidate[0] = 0x80
+		candidate[len(candidate)-1] = byte(x)
+		if _, err := DeserializeKZGCommitment(candidate); errors.Is(err, ErrPointNotInSubgroup) {
+			notInSubgroup = candidate
+			break
+		}
+	}
+	require.NotEqual(t, KZGCommitment{}, notInSubgroup)
+
+	badSetup := parsedSetup
+	badSetup.SetupG1Lagrange[0] = "0x" + hex.EncodeToString(notInSubgroup[:])
+
+	// An unknown setup is checked by default
+	_, err = NewContext4096(&badSetup)
+	require.Error(t, err)
+
+	// Unless the caller asks for the checks to be skipped
+	_, err = NewContext4096(&badSetup, WithSetupChecks(SetupChecksNever))
+	require.NoError(t, err)
+}
+
+func TestParseTrustedSetupPar(t *testing.T) {
+	parsedSetup := JSONTrustedSetup{}
+	err := json.Unmarshal([]byte(testKzgSetupStr), &parsedSetup)
+	require.NoError(t, err)
+
+	_, serialG1, serialG2, err := parseTrustedSetup(&parsedSetup, 1)
+	require.NoError(t, err)
+	for _, numGoRoutines := range []int{0, 3, 64, 10000} {
+		_, g1, g2, err := parseTrustedSetup(&parsedSetup, numGoRoutines)
+		require.NoError(t, err)
+		require.Equal(t, serialG1, g1)
+		require.Equal(t, serialG2, g2)
+		require.NoError(t, CheckTrustedSetupIsWellFormedPar(&parsedSetup, numGoRoutines))
+	}
+}
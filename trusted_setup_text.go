@@ -0,0 +1,134 @@
+package gokzg4844
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ParseTextTrustedSetup parses a trusted setup in the text format used by [c-kzg-4844], so that the same setup file
+// can be used by both libraries.
+//
+// The format consists of whitespace-separated fields: the number of G1 points, the number of G2 points, the G1
+// points in Lagrange form and the G2 points in monomial form, all hex-encoded without a 0x prefix. Newer files also
+// end with the G1 points in monomial form; these are checked for their encoding, but otherwise ignored, as the
+// monomial points are derived from the Lagrange points when needed.
+//
+// Like [NewContext4096], this does not check that the points are in the correct subgroup; see
+// [CheckTrustedSetupIsWellFormed]. Returns an error wrapping [ErrInvalidTextTrustedSetup] if the input is not in this
+// format, or does not have [ScalarsPerBlob] G1 points.
+//
+// [c-kzg-4844]: https://github.com/ethereum/c-kzg-4844/blob/main/src/trusted_setup.txt
+func ParseTextTrustedSetup(r io.Reader) (*JSONTrustedSetup, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	p := textSetupParser{scanner: scanner}
+
+	// 1. The number of points
+	//
+	numG1, err := p.count("G1")
+	if err != nil {
+		return nil, err
+	}
+	if numG1 != ScalarsPerBlob {
+		return nil, fmt.Errorf("%w: expected %d G1 points, found %d", ErrInvalidTextTrustedSetup, ScalarsPerBlob, numG1)
+	}
+	numG2, err := p.count("G2")
+	if err != nil {
+		return nil, err
+	}
+	if numG2 > maxSetupG2Points {
+		return nil, &CountLimitError{What: "G2 points", Count: numG2, Max: maxSetupG2Points, Err: ErrInvalidTextTrustedSetup}
+	}
+
+	// 2. The Lagrange G1 points and the monomial G2 points
+	//
+	var setup JSONTrustedSetup
+	for i := range setup.SetupG1Lagrange {
+		point, err := p.point("G1", i, CompressedG1Size)
+		if err != nil {
+			return nil, err
+		}
+		setup.SetupG1Lagrange[i] = point
+	}
+	setup.SetupG2 = make([]G2CompressedHexStr, numG2)
+	for i := range setup.SetupG2 {
+		point, err := p.point("G2", i, CompressedG2Size)
+		if err != nil {
+			return nil, err
+		}
+		setup.SetupG2[i] = point
+	}
+
+	// 3. The optional monomial G1 points
+	//
+	if p.scanner.Scan() {
+		if _, err := textSetupHexPoint("monomial G1", 0, CompressedG1Size, p.scanner.Text()); err != nil {
+			return nil, err
+		}
+		for i := 1; i < numG1; i++ {
+			if _, err := p.point("monomial G1", i, CompressedG1Size); err != nil {
+				return nil, err
+			}
+		}
+		if p.scanner.Scan() {
+			return nil, fmt.Errorf("%w: unexpected field after the points", ErrInvalidTextTrustedSetup)
+		}
+	}
+	if err := p.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return &setup, nil
+}
+
+// textSetupParser reads the fields of a trusted setup in the text format.
+type textSetupParser struct {
+	scanner *bufio.Scanner
+}
+
+// next returns the next field, or an error describing what was expected if there are no more fields.
+func (p *textSetupParser) next(expected string) (string, error) {
+	if !p.scanner.Scan() {
+		if err := p.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("%w: missing %s", ErrInvalidTextTrustedSetup, expected)
+	}
+	return p.scanner.Text(), nil
+}
+
+// count reads the number of points in a group.
+func (p *textSetupParser) count(group string) (int, error) {
+	field, err := p.next("number of " + group + " points")
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(field)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: invalid number of %s points %q", ErrInvalidTextTrustedSetup, group, field)
+	}
+	return n, nil
+}
+
+// point reads the i'th point of a group, and returns it as a hex-string with the 0x prefix.
+func (p *textSetupParser) point(group string, i int, size int) (string, error) {
+	field, err := p.next(fmt.Sprintf("%s point %d", group, i))
+	if err != nil {
+		return "", err
+	}
+	return textSetupHexPoint(group, i, size, field)
+}
+
+// textSetupHexPoint checks that the field is the hex encoding of size bytes, and returns it with the 0x prefix.
+func textSetupHexPoint(group string, i int, size int, field string) (string, error) {
+	if len(field) != 2*size {
+		return "", fmt.Errorf("%w: %s point %d has %d hex characters, expected %d", ErrInvalidTextTrustedSetup, group, i, len(field), 2*size)
+	}
+	if _, err := hex.DecodeString(field); err != nil {
+		return "", fmt.Errorf("%w: %s point %d is not hex-encoded", ErrInvalidTextTrustedSetup, group, i)
+	}
+	return "0x" + field, nil
+}
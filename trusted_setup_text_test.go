@@ -0,0 +1,70 @@
+package gokzg4844
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// textSetup returns the embedded trusted setup in the text format used by c-kzg-4844.
+func textSetup(t *testing.T, withMonomial bool) (*JSONTrustedSetup, string) {
+	parsedSetup := JSONTrustedSetup{}
+	require.NoError(t, json.Unmarshal([]byte(testKzgSetupStr), &parsedSetup))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d\n%d\n", len(parsedSetup.SetupG1Lagrange), len(parsedSetup.SetupG2))
+	for _, point := range parsedSetup.SetupG1Lagrange {
+		sb.WriteString(strings.TrimPrefix(point, "0x") + "\n")
+	}
+	for _, point := range parsedSetup.SetupG2 {
+		sb.WriteString(strings.TrimPrefix(point, "0x") + "\n")
+	}
+	if withMonomial {
+		// The values are not checked, so any G1 points can stand in for the monomial points
+		for _, point := range parsedSetup.SetupG1Lagrange {
+			sb.WriteString(strings.TrimPrefix(point, "0x") + "\n")
+		}
+	}
+	return &parsedSetup, sb.String()
+}
+
+func TestParseTextTrustedSetup(t *testing.T) {
+	for _, withMonomial := range []bool{false, true} {
+		expected, text := textSetup(t, withMonomial)
+
+		setup, err := ParseTextTrustedSetup(strings.NewReader(text))
+		require.NoError(t, err)
+		require.Equal(t, expected, setup)
+	}
+}
+
+func TestParseTextTrustedSetupErrors(t *testing.T) {
+	_, text := textSetup(t, false)
+	lines := strings.Split(text, "\n")
+
+	tests := map[string]string{
+		"empty":              "",
+		"wrong G1 count":     "4095\n65\n",
+		"invalid G2 count":   "4096\nx\n",
+		"huge G2 count":      "4096\n999999999999\n",
+		"truncated":          strings.Join(lines[:100], "\n"),
+		"short point":        strings.Replace(text, lines[2], lines[2][:10], 1),
+		"non-hex point":      strings.Replace(text, lines[2], "zz"+lines[2][2:], 1),
+		"trailing field":     text + "00\n",
+		"truncated monomial": text + lines[2] + "\n",
+	}
+	for name, input := range tests {
+		_, err := ParseTextTrustedSetup(strings.NewReader(input))
+		require.ErrorIs(t, err, ErrInvalidTextTrustedSetup, name)
+	}
+}
+
+// A huge G2 count is rejected before anything of that size is allocated.
+func TestParseTextTrustedSetupCountLimit(t *testing.T) {
+	_, err := ParseTextTrustedSetup(strings.NewReader("4096\n999999999999\n"))
+	require.ErrorIs(t, err, ErrInvalidTextTrustedSetup)
+	require.ErrorIs(t, err, ErrCountLimitExceeded)
+}
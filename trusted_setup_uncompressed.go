@@ -0,0 +1,165 @@
+package gokzg4844
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// uncompressedSetupMagic identifies a trusted setup written by [WriteUncompressedTrustedSetup] and the version of its
+// format.
+var uncompressedSetupMagic = [8]byte{'G', 'O', 'K', 'Z', 'G', 'U', 'S', '1'}
+
+const (
+	// UncompressedG1Size is the number of bytes in an uncompressed G1 point.
+	UncompressedG1Size = 2 * CompressedG1Size
+	// UncompressedG2Size is the number of bytes in an uncompressed G2 point.
+	UncompressedG2Size = 2 * CompressedG2Size
+)
+
+// WriteUncompressedTrustedSetup converts a trusted setup to the uncompressed binary format read by
+// [NewContext4096Uncompressed], and writes it to w.
+//
+// The format is the 8 byte magic "GOKZGUS1", followed by the number of Lagrange G1 points and the number of G2 points
+// as big-endian uint32s, followed by the points themselves in the same order as in the [JSONTrustedSetup]. Each
+// point is in the uncompressed encoding of the [Zcash serialization format], which is [UncompressedG1Size] bytes for
+// a G1 point and [UncompressedG2Size] bytes for a G2 point.
+//
+// Returns [ErrInvalidPointEncoding] if a point of the setup cannot be decompressed. The points are not checked to be
+// in the correct subgroup; use [CheckTrustedSetupIsWellFormed] for that.
+//
+// [Zcash serialization format]: https://github.com/zkcrypto/pairing/blob/34aa52b0f7bef705917252ea63e5a13fa01af551/src/bls12_381/README.md#serialization
+func WriteUncompressedTrustedSetup(w io.Writer, setup *JSONTrustedSetup) error {
+	g1Points := make([]bls12381.G1Affine, len(setup.SetupG1Lagrange))
+	err := forEachSetupChunk(len(g1Points), 0, func(start, end int) error {
+		for i := start; i < end; i++ {
+			compressed, err := decodeHexPoint(setup.SetupG1Lagrange[i], CompressedG1Size)
+			if err != nil {
+				return err
+			}
+			if _, err := g1Points[i].SetBytes(compressed); err != nil {
+				return ErrInvalidPointEncoding
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	g2Points := make([]bls12381.G2Affine, len(setup.SetupG2))
+	for i := range g2Points {
+		compressed, err := decodeHexPoint(setup.SetupG2[i], CompressedG2Size)
+		if err != nil {
+			return err
+		}
+		if _, err := g2Points[i].SetBytes(compressed); err != nil {
+			return ErrInvalidPointEncoding
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.Write(uncompressedSetupMagic[:])
+	var counts [8]byte
+	binary.BigEndian.PutUint32(counts[:4], uint32(len(g1Points)))
+	binary.BigEndian.PutUint32(counts[4:], uint32(len(g2Points)))
+	bw.Write(counts[:])
+	for i := range g1Points {
+		raw := g1Points[i].RawBytes()
+		bw.Write(raw[:])
+	}
+	for i := range g2Points {
+		raw := g2Points[i].RawBytes()
+		bw.Write(raw[:])
+	}
+	return bw.Flush()
+}
+
+// NewContext4096Uncompressed is the same as [NewContext4096], except that the trusted setup is read from r in the
+// uncompressed binary format written by [WriteUncompressedTrustedSetup].
+//
+// Since the points do not need to be decompressed, creating a context this way is much faster, which helps services
+// that restart frequently. The setup is identified by the same digest as its [JSONTrustedSetup], so the setup from the
+// Ethereum KZG ceremony still skips the subgroup checks by default; see [WithSetupChecks]. The points are always
+// checked to be on the curve, since that is cheap.
+//
+// Returns [ErrInvalidUncompressedTrustedSetup] if r does not hold a setup in the expected format, or
+// [ErrPointNotOnCurve] or [ErrPointNotInSubgroup] if a point is invalid.
+func NewContext4096Uncompressed(r io.Reader, opts ...Option) (*Context, error) {
+	cfg := newConfig(opts)
+
+	if err := SelfTest(); err != nil {
+		return nil, err
+	}
+
+	var header [len(uncompressedSetupMagic) + 8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, ErrInvalidUncompressedTrustedSetup
+	}
+	if !bytes.Equal(header[:len(uncompressedSetupMagic)], uncompressedSetupMagic[:]) {
+		return nil, ErrInvalidUncompressedTrustedSetup
+	}
+	numG1 := binary.BigEndian.Uint32(header[len(uncompressedSetupMagic):])
+	numG2 := binary.BigEndian.Uint32(header[len(uncompressedSetupMagic)+4:])
+	if numG1 != ScalarsPerBlob {
+		return nil, ErrInvalidUncompressedTrustedSetup
+	}
+	if numG2 > maxSetupG2Points {
+		return nil, &CountLimitError{What: "G2 points", Count: int(numG2), Max: maxSetupG2Points, Err: ErrInvalidUncompressedTrustedSetup}
+	}
+	// This should not happen for the ETH protocol
+	// However since it's a public method, we add the check.
+	if numG2 < 2 {
+		return nil, kzg.ErrMinSRSSize
+	}
+
+	g1Bytes := make([]byte, int(numG1)*UncompressedG1Size)
+	g2Bytes := make([]byte, int(numG2)*UncompressedG2Size)
+	if _, err := io.ReadFull(r, g1Bytes); err != nil {
+		return nil, ErrInvalidUncompressedTrustedSetup
+	}
+	if _, err := io.ReadFull(r, g2Bytes); err != nil {
+		return nil, ErrInvalidUncompressedTrustedSetup
+	}
+
+	g1Points := make([]bls12381.G1Affine, numG1)
+	err := forEachSetupChunk(len(g1Points), cfg.numGoRoutines, func(start, end int) error {
+		for i := start; i < end; i++ {
+			if err := decodeUncompressedNoSubgroupCheck(g1Bytes[i*UncompressedG1Size:(i+1)*UncompressedG1Size], &g1Points[i]); err != nil {
+				return err
+			}
+			if !g1Points[i].IsOnCurve() {
+				return ErrPointNotOnCurve
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	g2Points := make([]bls12381.G2Affine, numG2)
+	for i := range g2Points {
+		if err := decodeUncompressedNoSubgroupCheck(g2Bytes[i*UncompressedG2Size:(i+1)*UncompressedG2Size], &g2Points[i]); err != nil {
+			return nil, err
+		}
+		if !g2Points[i].IsOnCurve() {
+			return nil, ErrPointNotOnCurve
+		}
+	}
+
+	return newContext4096FromPoints(cfg, g1Points, g2Points)
+}
+
+// decodeUncompressedNoSubgroupCheck decodes an uncompressed point without checking that it is in the correct subgroup.
+//
+// Returns [ErrInvalidUncompressedTrustedSetup] if the bytes are not an uncompressed encoding of a point.
+func decodeUncompressedNoSubgroupCheck(raw []byte, point interface{}) error {
+	if err := decodeRawPointNoSubgroupCheck(raw, false, point); err != nil {
+		return ErrInvalidUncompressedTrustedSetup
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package gokzg4844
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/stretchr/testify/require"
+)
+
+func uncompressedMainnetSetup(t *testing.T) []byte {
+	parsedSetup := JSONTrustedSetup{}
+	err := json.Unmarshal([]byte(testKzgSetupStr), &parsedSetup)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteUncompressedTrustedSetup(&buf, &parsedSetup))
+	require.Equal(t, 16+ScalarsPerBlob*UncompressedG1Size+len(parsedSetup.SetupG2)*UncompressedG2Size, buf.Len())
+	return buf.Bytes()
+}
+
+func TestNewContext4096Uncompressed(t *testing.T) {
+	serialized := uncompressedMainnetSetup(t)
+
+	ctxUncompressed, err := NewContext4096Uncompressed(bytes.NewReader(serialized))
+	require.NoError(t, err)
+	require.Equal(t, MainnetSetupDigest, ctxUncompressed.SetupDigest())
+
+	ctxCompressed, err := NewContext4096Secure()
+	require.NoError(t, err)
+	require.Equal(t, ctxCompressed.commitKey().G1, ctxUncompressed.commitKey().G1)
+	require.Equal(t, *ctxCompressed.openKey, *ctxUncompressed.openKey)
+
+	_, err = NewContext4096Uncompressed(bytes.NewReader(serialized), WithSetupChecks(SetupChecksAlways))
+	require.NoError(t, err)
+}
+
+func TestNewContext4096UncompressedInvalid(t *testing.T) {
+	serialized := uncompressedMainnetSetup(t)
+	corrupt := func(modify func(b []byte) []byte) []byte {
+		b := make([]byte, len(serialized))
+		copy(b, serialized)
+		return modify(b)
+	}
+
+	tests := map[string]struct {
+		serialized []byte
+		err        error
+	}{
+		"empty":           {nil, ErrInvalidUncompressedTrustedSetup},
+		"magic":           {corrupt(func(b []byte) []byte { b[0] ^= 1; return b }), ErrInvalidUncompressedTrustedSetup},
+		"G1 count":        {corrupt(func(b []byte) []byte { b[11] ^= 1; return b }), ErrInvalidUncompressedTrustedSetup},
+		"G2 count":        {corrupt(func(b []byte) []byte { b[12] = 0xff; return b }), ErrCountLimitExceeded},
+		"truncated":       {corrupt(func(b []byte) []byte { return b[:len(b)-1] }), ErrInvalidUncompressedTrustedSetup},
+		"compressed flag": {corrupt(func(b []byte) []byte { b[16] |= 0x80; return b }), ErrInvalidUncompressedTrustedSetup},
+		"not on curve":    {corrupt(func(b []byte) []byte { b[16+UncompressedG1Size-1] ^= 1; return b }), ErrPointNotOnCurve},
+	}
+	for name, test := range tests {
+		_, err := NewContext4096Uncompressed(bytes.NewReader(test.serialized))
+		require.ErrorIs(t, err, test.err, name)
+	}
+}
+
+func TestNewContext4096UncompressedSubgroupCheck(t *testing.T) {
+	serialized := uncompressedMainnetSetup(t)
+
+	// Find a point which is on the curve but not in the subgroup
+	for x := 1; x < 256; x++ {
+		var candidate KZGCommitment
+		candidate[0] = 0x80
+		candidate[len(candidate)-1] = byte(x)
+		if _, err := DeserializeKZGCommitment(candidate); !errors.Is(err, ErrPointNotInSubgroup) {
+			continue
+		}
+
+		var point bls12381.G1Affine
+		d := bls12381.NewDecoder(bytes.NewReader(candidate[:]), bls12381.NoSubgroupChecks())
+		require.NoError(t, d.Decode(&point))
+		raw := point.RawBytes()
+		copy(serialized[16:], raw[:])
+
+		// The setup no longer has the mainnet digest, so it is checked by default
+		_, err := NewContext4096Uncompressed(bytes.NewReader(serialized))
+		require.ErrorIs(t, err, ErrPointNotInSubgroup)
+
+		_, err = NewContext4096Uncompressed(bytes.NewReader(serialized), WithSetupChecks(SetupChecksNever))
+		require.NoError(t, err)
+		return
+	}
+	t.Fatal("no point outside of the subgroup was found")
+}
@@ -0,0 +1,101 @@
+package gokzg4844
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// VanishingPolynomial returns the coefficients of the vanishing polynomial of the domain, Z(X) = X^n - 1, where n is
+// the size of the domain. Z is zero at exactly the roots of unity of the domain. The i'th coefficient is the
+// coefficient of X^i, so there are n+1 of them.
+func (d *Domain) VanishingPolynomial() []fr.Element {
+	return d.inner.VanishingPolynomial()
+}
+
+// EvaluateVanishingPolynomial returns Z(x), where Z is the vanishing polynomial of the domain, as returned by
+// [Domain.VanishingPolynomial].
+func (d *Domain) EvaluateVanishingPolynomial(x fr.Element) fr.Element {
+	return d.inner.EvaluateVanishingPolynomial(x)
+}
+
+// SubsetVanishingPolynomial returns the coefficients of the polynomial which is zero at exactly the roots of unity at
+// the given indices, Z_S(X) = (X - r_0)(X - r_1)...(X - r_(k-1)). The indices are positions in the roots of the domain
+// in the given order, as returned by [Domain.Roots]; use [BitReversedOrder] for the order of the evaluations of a
+// blob. For example, the indices of the evaluations which are missing from a partially received extended blob give
+// the polynomial which is used to recover them.
+//
+// The i'th coefficient is the coefficient of X^i, so there are k+1 of them. No indices give the constant polynomial 1.
+//
+// Returns [ErrInvalidRootIndex] if an index is not less than the size of the domain or is repeated, and
+// [ErrInvalidPermutation] if order is not a bijection.
+func (d *Domain) SubsetVanishingPolynomial(indices []uint64, order Permutation) ([]fr.Element, error) {
+	points, err := d.subsetRoots(indices, order)
+	if err != nil {
+		return nil, err
+	}
+	return kzg.VanishingPolynomialOfPoints(points), nil
+}
+
+// EvaluateSubsetVanishingPolynomial returns Z_S(x), where Z_S is the polynomial which is zero at exactly the roots of
+// unity at the given indices, as returned by [Domain.SubsetVanishingPolynomial]. This takes time linear in the number
+// of indices, so it is much faster than finding the coefficients to evaluate a single point.
+//
+// Returns [ErrInvalidRootIndex] if an index is not less than the size of the domain or is repeated, and
+// [ErrInvalidPermutation] if order is not a bijection.
+func (d *Domain) EvaluateSubsetVanishingPolynomial(indices []uint64, order Permutation, x fr.Element) (fr.Element, error) {
+	points, err := d.subsetRoots(indices, order)
+	if err != nil {
+		return fr.Element{}, err
+	}
+
+	result := fr.One()
+	for i := range points {
+		var factor fr.Element
+		factor.Sub(&x, &points[i])
+		result.Mul(&result, &factor)
+	}
+	return result, nil
+}
+
+// SubsetVanishingEvaluations returns the evaluations over the domain of the polynomial which is zero at exactly the
+// roots of unity at the given indices, as returned by [Domain.SubsetVanishingPolynomial]. The evaluations are in the
+// given order, which is also the order that the indices refer to, so the evaluation at each of the indices is zero and
+// every other evaluation is not.
+//
+// Returns [ErrInvalidRootIndex] if an index is not less than the size of the domain or is repeated, and
+// [ErrInvalidPermutation] if order is not a bijection.
+func (d *Domain) SubsetVanishingEvaluations(indices []uint64, order Permutation) ([]fr.Element, error) {
+	coeffs, err := d.SubsetVanishingPolynomial(indices, order)
+	if err != nil {
+		return nil, err
+	}
+
+	// When every root is given, the polynomial has n+1 coefficients. Since
+	// w^n = 1 at each root w, the coefficient of X^n adds to that of X^0.
+	n := int(d.Size())
+	if len(coeffs) > n {
+		coeffs[0].Add(&coeffs[0], &coeffs[n])
+		coeffs = coeffs[:n]
+	}
+	return d.FFT(coeffs, order)
+}
+
+// subsetRoots returns the roots of unity at the given indices of the roots of the domain in the given order.
+func (d *Domain) subsetRoots(indices []uint64, order Permutation) ([]fr.Element, error) {
+	n := d.Size()
+	sourceIndices, err := permutationSourceIndices(order, int(n))
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]fr.Element, len(indices))
+	seen := make(map[uint64]bool, len(indices))
+	for i, index := range indices {
+		if index >= n || seen[index] {
+			return nil, ErrInvalidRootIndex
+		}
+		seen[index] = true
+		points[i] = d.inner.Roots[sourceIndices[index]]
+	}
+	return points, nil
+}
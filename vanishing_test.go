@@ -0,0 +1,86 @@
+package gokzg4844_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/crate-crypto/go-kzg-4844/kzgmath"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainVanishingPolynomial(t *testing.T) {
+	domain, err := gokzg4844.NewDomain(16)
+	require.NoError(t, err)
+
+	coeffs := domain.VanishingPolynomial()
+	require.Len(t, coeffs, 17)
+	for i := uint64(0); i < domain.Size(); i++ {
+		value := kzgmath.Evaluate(coeffs, domain.Root(i))
+		require.True(t, value.IsZero())
+	}
+
+	x := fr.NewElement(5)
+	expected := kzgmath.Evaluate(coeffs, x)
+	got := domain.EvaluateVanishingPolynomial(x)
+	require.True(t, expected.Equal(&got))
+}
+
+func TestDomainSubsetVanishingPolynomial(t *testing.T) {
+	domain, err := gokzg4844.NewDomain(16)
+	require.NoError(t, err)
+	x := fr.NewElement(5)
+
+	for _, order := range []gokzg4844.Permutation{gokzg4844.NaturalOrder, gokzg4844.BitReversedOrder} {
+		roots, err := domain.Roots(order)
+		require.NoError(t, err)
+
+		for _, indices := range [][]uint64{nil, {3}, {0, 1, 9, 15}, {14, 2, 7, 8, 11, 4, 0, 5}} {
+			points := make([]fr.Element, len(indices))
+			missing := make(map[uint64]bool)
+			for i, index := range indices {
+				points[i] = roots[index]
+				missing[index] = true
+			}
+
+			coeffs, err := domain.SubsetVanishingPolynomial(indices, order)
+			require.NoError(t, err)
+			require.Equal(t, kzgmath.Vanishing(points), coeffs)
+
+			value, err := domain.EvaluateSubsetVanishingPolynomial(indices, order, x)
+			require.NoError(t, err)
+			expected := kzgmath.Evaluate(coeffs, x)
+			require.True(t, expected.Equal(&value))
+
+			// The evaluations are zero at exactly the given indices
+			evaluations, err := domain.SubsetVanishingEvaluations(indices, order)
+			require.NoError(t, err)
+			for i := range evaluations {
+				require.Equal(t, missing[uint64(i)], evaluations[i].IsZero())
+			}
+		}
+	}
+
+	// Every root gives the vanishing polynomial of the domain
+	all := make([]uint64, domain.Size())
+	for i := range all {
+		all[i] = uint64(i)
+	}
+	coeffs, err := domain.SubsetVanishingPolynomial(all, gokzg4844.BitReversedOrder)
+	require.NoError(t, err)
+	require.Equal(t, domain.VanishingPolynomial(), coeffs)
+	evaluations, err := domain.SubsetVanishingEvaluations(all, gokzg4844.BitReversedOrder)
+	require.NoError(t, err)
+	require.Equal(t, make([]fr.Element, domain.Size()), evaluations)
+
+	for _, indices := range [][]uint64{{16}, {1, 2, 1}} {
+		_, err := domain.SubsetVanishingPolynomial(indices, gokzg4844.NaturalOrder)
+		require.ErrorIs(t, err, gokzg4844.ErrInvalidRootIndex)
+		_, err = domain.EvaluateSubsetVanishingPolynomial(indices, gokzg4844.NaturalOrder, x)
+		require.ErrorIs(t, err, gokzg4844.ErrInvalidRootIndex)
+		_, err = domain.SubsetVanishingEvaluations(indices, gokzg4844.NaturalOrder)
+		require.ErrorIs(t, err, gokzg4844.ErrInvalidRootIndex)
+	}
+	_, err = domain.SubsetVanishingPolynomial([]uint64{1}, nil)
+	require.ErrorIs(t, err, gokzg4844.ErrInvalidPermutation)
+}
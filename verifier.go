@@ -0,0 +1,207 @@
+package gokzg4844
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Verifier verifies blob proofs asynchronously.
+//
+// Submissions are collected into batches, which are verified using [Context.VerifyBlobKZGProofBatch]. This amortizes
+// the cost of the pairing check over all of the proofs in a batch. A batch is verified as soon as it is full, or once
+// the oldest submission in it has waited for the maximum delay.
+//
+// If a batch fails to verify, each of its proofs is verified individually, so that an invalid proof only causes an
+// error for its own submission.
+//
+// A Verifier is safe for concurrent use. It must be closed with [Verifier.Close] once it is no longer needed.
+type Verifier struct {
+	ctx *Context
+
+	maxBatchSize int
+	maxDelay     time.Duration
+
+	// mu guards closed and pending.
+	mu     sync.Mutex
+	closed bool
+	// pending holds the submissions which are not yet in a batch, oldest
+	// first. It is not bounded, so that submitting never blocks.
+	pending []verifyRequest
+	// wake is signalled when a submission is added to pending, or when the
+	// verifier is closed.
+	wake chan struct{}
+
+	// done is closed once all submissions have been verified.
+	done chan struct{}
+}
+
+// verifyRequest is a single submission to a [Verifier].
+type verifyRequest struct {
+	blob       *Blob
+	commitment KZGCommitment
+	proof      KZGProof
+	result     chan error
+	submitted  time.Time
+}
+
+// NewVerifier returns a [Verifier] which uses this context to verify proofs.
+//
+// Batches contain at most maxBatchSize proofs, and a submission waits at most maxDelay before the batch that it is in
+// gets verified. If maxBatchSize is less than 1, it is set to 1, which means that every proof is verified on its own.
+func (c *Context) NewVerifier(maxBatchSize int, maxDelay time.Duration) *Verifier {
+	if maxBatchSize < 1 {
+		maxBatchSize = 1
+	}
+
+	v := &Verifier{
+		ctx:          c,
+		maxBatchSize: maxBatchSize,
+		maxDelay:     maxDelay,
+		wake:         make(chan struct{}, 1),
+		done:         make(chan struct{}),
+	}
+	go v.loop()
+
+	return v
+}
+
+// Submit queues the proof for verification and returns a channel which receives the result.
+//
+// Submit never blocks, even while a batch is being verified: the submissions are queued without a bound, so callers
+// which submit faster than the proofs can be verified must limit themselves, for example by waiting for results.
+//
+// The channel receives nil if the proof is valid and an error otherwise. Exactly one value is sent on the channel,
+// so the caller does not need to read from it.
+//
+// The blob must not be modified until the result has been received.
+//
+// If the verifier has been closed, the channel receives [ErrVerifierClosed].
+func (v *Verifier) Submit(blob *Blob, commitment KZGCommitment, proof KZGProof) <-chan error {
+	result := make(chan error, 1)
+
+	v.mu.Lock()
+	if v.closed {
+		v.mu.Unlock()
+		result <- ErrVerifierClosed
+		return result
+	}
+	v.pending = append(v.pending, verifyRequest{
+		blob:       blob,
+		commitment: commitment,
+		proof:      proof,
+		result:     result,
+		submitted:  time.Now(),
+	})
+	v.mu.Unlock()
+	v.signal()
+
+	return result
+}
+
+// Close stops the verifier from accepting new submissions and waits until all of the pending submissions have
+// been verified.
+//
+// It is safe to call Close more than once.
+func (v *Verifier) Close() {
+	v.mu.Lock()
+	v.closed = true
+	v.mu.Unlock()
+	v.signal()
+
+	<-v.done
+}
+
+// signal wakes the loop, if it is not already due to wake.
+func (v *Verifier) signal() {
+	select {
+	case v.wake <- struct{}{}:
+	default:
+	}
+}
+
+// loop collects submissions into batches and verifies them, until the verifier is closed and all of its submissions
+// have been verified.
+func (v *Verifier) loop() {
+	defer close(v.done)
+
+	batch := make([]verifyRequest, 0, v.maxBatchSize)
+	timer := time.NewTimer(v.maxDelay)
+	timer.Stop()
+	for {
+		// A batch is taken once it is full, once the oldest submission has
+		// waited long enough, or straight away if the verifier is closed
+		v.mu.Lock()
+		var wait time.Duration
+		n := len(v.pending)
+		if n > 0 && n < v.maxBatchSize && !v.closed {
+			wait = v.maxDelay - time.Since(v.pending[0].submitted)
+		}
+		closed := v.closed
+		if n > 0 && wait <= 0 {
+			if n > v.maxBatchSize {
+				n = v.maxBatchSize
+			}
+			batch = append(batch[:0], v.pending[:n]...)
+			remaining := copy(v.pending, v.pending[n:])
+			// Drop the references to the blobs which were taken
+			for i := remaining; i < len(v.pending); i++ {
+				v.pending[i] = verifyRequest{}
+			}
+			v.pending = v.pending[:remaining]
+		}
+		v.mu.Unlock()
+
+		switch {
+		case n > 0 && wait <= 0:
+			v.verifyBatch(batch)
+		case n == 0 && closed:
+			return
+		case n == 0:
+			<-v.wake
+		default:
+			timer.Reset(wait)
+			select {
+			case <-v.wake:
+			case <-timer.C:
+			}
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// verifyBatch verifies all of the submissions in the batch and sends each of them their result.
+func (v *Verifier) verifyBatch(batch []verifyRequest) {
+	if len(batch) > 1 {
+		blobs := make([]*Blob, len(batch))
+		commitments := make([]KZGCommitment, len(batch))
+		proofs := make([]KZGProof, len(batch))
+		for i, request := range batch {
+			blobs[i] = request.blob
+			commitments[i] = request.commitment
+			proofs[i] = request.proof
+		}
+
+		if v.ctx.verifyBlobKZGProofBatch(context.Background(), blobs, commitments, proofs) == nil {
+			for _, request := range batch {
+				request.result <- nil
+			}
+			return
+		}
+	}
+
+	// Either the batch only had a single proof or one of the proofs is invalid, so we need
+	// to verify each proof on its own to know which one(s) failed.
+	for _, request := range batch {
+		err := v.ctx.checkTypes()
+		if err == nil {
+			err = v.ctx.checkBlobKZGProof(context.Background(), request.blob[:], request.commitment, request.proof)
+		}
+		request.result <- err
+	}
+}
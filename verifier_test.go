@@ -0,0 +1,87 @@
+package gokzg4844_test
+
+import (
+	"testing"
+	"time"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifier(t *testing.T) {
+	const numBlobs = 5
+	blobs := make([]gokzg4844.Blob, numBlobs)
+	for i := range blobs {
+		blobs[i] = *GetRandBlob(int64(i))
+	}
+	commitments, err := ctx.BlobsToKZGCommitments(blobs, NumGoRoutines)
+	require.NoError(t, err)
+	proofs, err := ctx.ComputeBlobKZGProofs(blobs, commitments, NumGoRoutines)
+	require.NoError(t, err)
+
+	// Use an invalid proof for one of the blobs, which should only
+	// cause an error for its own submission.
+	const invalidIndex = 2
+	proofs[invalidIndex] = proofs[invalidIndex+1]
+
+	for _, maxBatchSize := range []int{1, 2, numBlobs} {
+		verifier := ctx.NewVerifier(maxBatchSize, 10*time.Millisecond)
+
+		results := make([]<-chan error, numBlobs)
+		for i := range blobs {
+			results[i] = verifier.Submit(&blobs[i], commitments[i], proofs[i])
+		}
+		for i, result := range results {
+			if i == invalidIndex {
+				require.Error(t, <-result)
+			} else {
+				require.NoError(t, <-result)
+			}
+		}
+
+		verifier.Close()
+		err := <-verifier.Submit(&blobs[0], commitments[0], proofs[0])
+		require.ErrorIs(t, err, gokzg4844.ErrVerifierClosed)
+		verifier.Close()
+	}
+}
+
+func TestVerifierCloseFlushesPending(t *testing.T) {
+	blob := GetRandBlob(123)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	proof, err := ctx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+
+	// The delay is long enough that the submission would not be verified
+	// before the end of the test, if Close did not flush it.
+	verifier := ctx.NewVerifier(8, time.Hour)
+	result := verifier.Submit(blob, commitment, proof)
+	verifier.Close()
+	require.NoError(t, <-result)
+}
+
+func TestVerifierSubmitDoesNotBlock(t *testing.T) {
+	blob := GetRandBlob(7)
+	commitment, err := ctx.BlobToKZGCommitment(blob, NumGoRoutines)
+	require.NoError(t, err)
+	proof, err := ctx.ComputeBlobKZGProof(blob, commitment, NumGoRoutines)
+	require.NoError(t, err)
+
+	// Each proof is verified on its own, which takes far longer than
+	// queueing all of them. If Submit waited for the verifier to make
+	// room, the first half of the proofs would be verified by the time
+	// that the last one is submitted.
+	const numSubmissions = 64
+	verifier := ctx.NewVerifier(1, time.Hour)
+	defer verifier.Close()
+	results := make([]<-chan error, numSubmissions)
+	for i := range results {
+		results[i] = verifier.Submit(blob, commitment, proof)
+	}
+	require.Empty(t, results[numSubmissions/2])
+
+	for _, result := range results {
+		require.NoError(t, <-result)
+	}
+}
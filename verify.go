@@ -1,17 +1,50 @@
 package gokzg4844
 
 import (
+	"context"
+	"errors"
+	"sync"
+
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
-	"golang.org/x/sync/errgroup"
 )
 
 // VerifyKZGProof implements [verify_kzg_proof].
 //
+// It returns [ErrProofInvalid] if the proof does not verify. Errors from deserializing the inputs, such as
+// [ErrBlobNotCanonical] or [ErrPointNotInSubgroup], are returned as they are.
+//
 // [verify_kzg_proof]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#verify_kzg_proof
 func (c *Context) VerifyKZGProof(blobCommitment KZGCommitment, inputPointBytes, claimedValueBytes Scalar, kzgProof KZGProof) error {
+	return c.VerifyKZGProofCtx(context.Background(), blobCommitment, inputPointBytes, claimedValueBytes, kzgProof)
+}
+
+// VerifyKZGProofCtx is the same as [Context.VerifyKZGProof], except that it returns ctx.Err() if ctx is
+// cancelled before the verification starts.
+func (c *Context) VerifyKZGProofCtx(ctx context.Context, blobCommitment KZGCommitment, inputPointBytes, claimedValueBytes Scalar, kzgProof KZGProof) error {
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+	acct := c.startAccounting(ctx, "verify_kzg_proof", 1, 2*CompressedG1Size+2*SerializedScalarSize)
+	defer acct.end()
+
+	return c.checkKZGProof(ctx, blobCommitment, inputPointBytes, claimedValueBytes, kzgProof)
+}
+
+// checkKZGProof implements [Context.VerifyKZGProofCtx] without recording its usage, for the methods which verify a
+// proof as part of a larger operation. The caller must check that the context is open.
+func (c *Context) checkKZGProof(ctx context.Context, blobCommitment KZGCommitment, inputPointBytes, claimedValueBytes Scalar, kzgProof KZGProof) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	prof := startProfile(ctx, "verify_kzg_proof", 1)
+	defer prof.end()
+
 	// 1. Deserialization
 	//
+	prof.phase("deserialize")
 	claimedValue, err := DeserializeScalar(claimedValueBytes)
 	if err != nil {
 		return err
@@ -33,6 +66,7 @@ func (c *Context) VerifyKZGProof(blobCommitment KZGCommitment, inputPointBytes,
 	}
 
 	// 2. Verify opening proof
+	prof.phase("verify")
 	proof := kzg.OpeningProof{
 		QuotientCommitment: quotientCommitment,
 		InputPoint:         inputPoint,
@@ -44,11 +78,46 @@ func (c *Context) VerifyKZGProof(blobCommitment KZGCommitment, inputPointBytes,
 
 // VerifyBlobKZGProof implements [verify_blob_kzg_proof].
 //
+// It returns [ErrProofInvalid] if the proof does not verify. Errors from deserializing the inputs, such as
+// [ErrBlobNotCanonical] or [ErrPointNotInSubgroup], are returned as they are.
+//
 // [verify_blob_kzg_proof]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#verify_blob_kzg_proof
 func (c *Context) VerifyBlobKZGProof(blob *Blob, blobCommitment KZGCommitment, kzgProof KZGProof) error {
+	return c.VerifyBlobKZGProofCtx(context.Background(), blob, blobCommitment, kzgProof)
+}
+
+// VerifyBlobKZGProofCtx is the same as [Context.VerifyBlobKZGProof], except that it returns ctx.Err() if ctx is
+// cancelled before the verification starts.
+func (c *Context) VerifyBlobKZGProofCtx(ctx context.Context, blob *Blob, blobCommitment KZGCommitment, kzgProof KZGProof) error {
+	if err := c.checkTypes(); err != nil {
+		return err
+	}
+	return c.verifyBlobKZGProof(ctx, blob[:], blobCommitment, kzgProof)
+}
+
+// verifyBlobKZGProof implements [Context.VerifyBlobKZGProofCtx] for a blob of the preset of the context.
+func (c *Context) verifyBlobKZGProof(ctx context.Context, blob []byte, blobCommitment KZGCommitment, kzgProof KZGProof) error {
+	acct := c.startAccounting(ctx, "verify_blob_kzg_proof", 1, len(blob)+2*CompressedG1Size)
+	defer acct.end()
+
+	return c.checkBlobKZGProof(ctx, blob, blobCommitment, kzgProof)
+}
+
+// checkBlobKZGProof is the same as [Context.verifyBlobKZGProof], except that it does not record its usage. It is
+// used by the methods which verify blob proofs as part of a larger operation, so that the usage of the operation is
+// only recorded once.
+func (c *Context) checkBlobKZGProof(ctx context.Context, blob []byte, blobCommitment KZGCommitment, kzgProof KZGProof) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	prof := startProfile(ctx, "verify_blob_kzg_proof", 1)
+	defer prof.end()
+
 	// 1. Deserialize
 	//
-	polynomial, err := DeserializeBlob(blob)
+	prof.phase("deserialize")
+	polynomial, err := deserializeBlobBytesPar(blob, 1)
 	if err != nil {
 		return err
 	}
@@ -64,15 +133,18 @@ func (c *Context) VerifyBlobKZGProof(blob *Blob, blobCommitment KZGCommitment, k
 	}
 
 	// 2. Compute the evaluation challenge
+	prof.phase("challenge")
 	evaluationChallenge := computeChallenge(blob, blobCommitment)
 
 	// 3. Compute output point/ claimed value
-	outputPoint, err := c.domain.EvaluateLagrangePolynomial(polynomial, evaluationChallenge)
+	prof.phase("evaluate")
+	outputPoint, err := c.domain().EvaluateLagrangePolynomial(polynomial, evaluationChallenge)
 	if err != nil {
 		return err
 	}
 
 	// 4. Verify opening proof
+	prof.phase("verify")
 	openingProof := kzg.OpeningProof{
 		QuotientCommitment: quotientCommitment,
 		InputPoint:         evaluationChallenge,
@@ -84,8 +156,43 @@ func (c *Context) VerifyBlobKZGProof(blob *Blob, blobCommitment KZGCommitment, k
 
 // VerifyBlobKZGProofBatch implements [verify_blob_kzg_proof_batch].
 //
+// If an element of the batch is invalid, the returned error is a [*BatchVerificationError] which reports the index of
+// the first invalid element. It wraps [ErrProofInvalid] if the proofs do not verify, or the error from deserializing
+// the element, such as [ErrBlobNotCanonical] or [ErrPointNotInSubgroup]. When the proofs do not verify, the batch is
+// bisected to find the invalid proof, which costs a number of extra batch verifications that is logarithmic in the
+// size of the batch.
+//
 // [verify_blob_kzg_proof_batch]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#verify_blob_kzg_proof_batch
 func (c *Context) VerifyBlobKZGProofBatch(blobs []Blob, polynomialCommitments []KZGCommitment, kzgProofs []KZGProof) error {
+	return c.VerifyBlobKZGProofBatchCtx(context.Background(), blobs, polynomialCommitments, kzgProofs)
+}
+
+// VerifyBlobKZGProofBatchCtx is the same as [Context.VerifyBlobKZGProofBatch], except that it stops and returns
+// ctx.Err() if ctx is cancelled.
+//
+// Cancellation is checked before each blob is processed and before the final pairing check.
+func (c *Context) VerifyBlobKZGProofBatchCtx(ctx context.Context, blobs []Blob, polynomialCommitments []KZGCommitment, kzgProofs []KZGProof) error {
+	if err := c.checkTypes(); err != nil {
+		return err
+	}
+	acct := c.startAccounting(ctx, "verify_blob_kzg_proof_batch", len(blobs), len(blobs)*(len(Blob{})+2*CompressedG1Size))
+	defer acct.end()
+
+	return c.verifyBlobKZGProofBatch(ctx, blobPointers(blobs), polynomialCommitments, kzgProofs)
+}
+
+// verifyBlobKZGProofBatch is the same as [Context.VerifyBlobKZGProofBatchCtx], except that it takes pointers to the
+// blobs. This lets callers which hold the blobs in different places avoid copying them into a single slice.
+func (c *Context) verifyBlobKZGProofBatch(ctx context.Context, blobs []*Blob, polynomialCommitments []KZGCommitment, kzgProofs []KZGProof) error {
+	if err := c.checkTypes(); err != nil {
+		return err
+	}
+	return c.verifyBlobKZGProofBatchBytes(ctx, blobSlices(blobs), polynomialCommitments, kzgProofs)
+}
+
+// verifyBlobKZGProofBatchBytes is the same as [Context.verifyBlobKZGProofBatch], except that the blobs are given as
+// slices, which must have the length of a blob of the preset of the context.
+func (c *Context) verifyBlobKZGProofBatchBytes(ctx context.Context, blobs [][]byte, polynomialCommitments []KZGCommitment, kzgProofs []KZGProof) error {
 	// 1. Check that all components in the batch have the same size
 	//
 	blobsLen := len(blobs)
@@ -95,52 +202,169 @@ func (c *Context) VerifyBlobKZGProofBatch(blobs []Blob, polynomialCommitments []
 	}
 	batchSize := blobsLen
 
+	prof := startProfile(ctx, "verify_blob_kzg_proof_batch", batchSize)
+	defer prof.end()
+
 	// 2. Collect opening proofs
 	//
-	openingProofs := make([]kzg.OpeningProof, batchSize)
-	commitments := make([]bls12381.G1Affine, batchSize)
-	for i := 0; i < batchSize; i++ {
-		// 2a. Deserialize
-		//
-		serComm := polynomialCommitments[i]
-		polynomialCommitment, err := DeserializeKZGCommitment(serComm)
-		if err != nil {
-			return err
+	prof.phase("prepare")
+	commitments, openingProofs, err := c.blobOpeningProofs(ctx, blobs, polynomialCommitments, kzgProofs)
+	if err != nil {
+		return err
+	}
+
+	// 3. Verify opening proofs
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	prof.phase("verify")
+	err = c.batchVerify(commitments, openingProofs, polynomialCommitments, kzgProofs)
+	if errors.Is(err, kzg.ErrVerifyOpeningProof) {
+		prof.phase("bisect")
+		index := c.findInvalidProof(commitments, openingProofs, polynomialCommitments, kzgProofs)
+		return &BatchVerificationError{Index: index, Err: err}
+	}
+	return err
+}
+
+// blobOpeningProofs returns the commitment and the opening proof that [verify_blob_kzg_proof] checks for each of the
+// blobs. An invalid element is reported with a [*BatchVerificationError].
+//
+// [verify_blob_kzg_proof]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#verify_blob_kzg_proof
+func (c *Context) blobOpeningProofs(ctx context.Context, blobs [][]byte, polynomialCommitments []KZGCommitment, kzgProofs []KZGProof) ([]kzg.Commitment, []kzg.OpeningProof, error) {
+	// The points are deserialized up front, where their subgroup checks can
+	// run concurrently. Their errors are kept, so that the first element of
+	// the batch which is invalid for any reason is the one reported.
+	serPoints := make([]G1Point, 2*len(blobs))
+	for i := range blobs {
+		serPoints[2*i] = G1Point(polynomialCommitments[i])
+		serPoints[2*i+1] = G1Point(kzgProofs[i])
+	}
+	points := make([]bls12381.G1Affine, len(serPoints))
+	pointErrs := deserializeG1PointsInto(points, serPoints, c.goRoutines(0))
+
+	openingProofs := make([]kzg.OpeningProof, len(blobs))
+	commitments := make([]kzg.Commitment, len(blobs))
+	polynomial := make(kzg.Polynomial, c.preset.ScalarsPerBlob)
+	for i := range blobs {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
 		}
 
-		kzgProof := kzgProofs[i]
-		quotientCommitment, err := DeserializeKZGProof(kzgProof)
+		err := pointErrs[2*i]
+		if err == nil {
+			err = pointErrs[2*i+1]
+		}
 		if err != nil {
-			return err
+			return nil, nil, &BatchVerificationError{Index: i, Err: err}
 		}
-
-		blob := &blobs[i]
-		polynomial, err := DeserializeBlob(blob)
+		commitment, openingProof, err := c.blobOpeningProofFromPoints(blobs[i], polynomial, polynomialCommitments[i], points[2*i], points[2*i+1])
 		if err != nil {
-			return err
+			return nil, nil, &BatchVerificationError{Index: i, Err: err}
 		}
+		openingProofs[i] = openingProof
+		commitments[i] = commitment
+	}
+	return commitments, openingProofs, nil
+}
 
-		// 2b. Compute the evaluation challenge
-		evaluationChallenge := computeChallenge(blob, serComm)
+// blobOpeningProof deserializes a blob along with its commitment and proof, and returns the commitment and the
+// opening proof that [verify_blob_kzg_proof] checks.
+//
+// The blob must have the length of a blob of the preset of the context. It is deserialized into polynomial, which
+// must have one element for each of its scalars, so that callers can reuse its memory.
+//
+// [verify_blob_kzg_proof]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#verify_blob_kzg_proof
+func (c *Context) blobOpeningProof(blob []byte, polynomial kzg.Polynomial, serComm KZGCommitment, serProof KZGProof) (kzg.Commitment, kzg.OpeningProof, error) {
+	// 1. Deserialize
+	//
+	polynomialCommitment, err := DeserializeKZGCommitment(serComm)
+	if err != nil {
+		return kzg.Commitment{}, kzg.OpeningProof{}, err
+	}
 
-		// 2c. Compute output point/ claimed value
-		outputPoint, err := c.domain.EvaluateLagrangePolynomial(polynomial, evaluationChallenge)
-		if err != nil {
-			return err
-		}
+	quotientCommitment, err := DeserializeKZGProof(serProof)
+	if err != nil {
+		return kzg.Commitment{}, kzg.OpeningProof{}, err
+	}
+
+	return c.blobOpeningProofFromPoints(blob, polynomial, serComm, polynomialCommitment, quotientCommitment)
+}
+
+// blobOpeningProofFromPoints is the same as [Context.blobOpeningProof], except that the commitment and the proof
+// have already been deserialized. serComm must be the serialized form of polynomialCommitment.
+func (c *Context) blobOpeningProofFromPoints(blob []byte, polynomial kzg.Polynomial, serComm KZGCommitment, polynomialCommitment kzg.Commitment, quotientCommitment bls12381.G1Affine) (kzg.Commitment, kzg.OpeningProof, error) {
+	if err := deserializeScalarsInto(blob, polynomial, 0, len(polynomial)); err != nil {
+		return kzg.Commitment{}, kzg.OpeningProof{}, err
+	}
+
+	// 2. Compute the evaluation challenge
+	evaluationChallenge := computeChallenge(blob, serComm)
+
+	// 3. Compute output point/ claimed value
+	outputPoint, err := c.domain().EvaluateLagrangePolynomial(polynomial, evaluationChallenge)
+	if err != nil {
+		return kzg.Commitment{}, kzg.OpeningProof{}, err
+	}
+
+	openingProof := kzg.OpeningProof{
+		QuotientCommitment: quotientCommitment,
+		InputPoint:         evaluationChallenge,
+		ClaimedValue:       *outputPoint,
+	}
+	return polynomialCommitment, openingProof, nil
+}
 
-		// 2d. Append opening proof to list
-		openingProof := kzg.OpeningProof{
-			QuotientCommitment: quotientCommitment,
-			InputPoint:         evaluationChallenge,
-			ClaimedValue:       *outputPoint,
+// findInvalidProof returns the index of the first invalid proof in a batch which failed to verify.
+//
+// The batch is bisected, verifying the first half of each range before the second. Since a batch only verifies if all
+// of its proofs are valid, this finds the first invalid proof with a number of batch verifications that is
+// logarithmic in the size of the batch.
+func (c *Context) findInvalidProof(commitments []kzg.Commitment, openingProofs []kzg.OpeningProof, serCommitments []KZGCommitment, serProofs []KZGProof) int {
+	start, end := 0, len(openingProofs)
+	for end-start > 1 {
+		mid := start + (end-start)/2
+		if c.batchVerify(commitments[start:mid], openingProofs[start:mid], serCommitments[start:mid], serProofs[start:mid]) != nil {
+			end = mid
+		} else {
+			start = mid
 		}
-		openingProofs[i] = openingProof
-		commitments[i] = polynomialCommitment
 	}
+	return start
+}
 
-	// 3. Verify opening proofs
-	return kzg.BatchVerifyMultiPoints(commitments, openingProofs, c.openKey)
+// batchVerify verifies the opening proofs in a batch, combining them with either a random challenge or, if the
+// context is configured with [WithFiatShamirBatchChallenge], a challenge derived from the serialized commitments and
+// proofs.
+//
+// serCommitments and serProofs must be the serialized forms of commitments and of the quotient commitments in
+// openingProofs.
+func (c *Context) batchVerify(commitments []kzg.Commitment, openingProofs []kzg.OpeningProof, serCommitments []KZGCommitment, serProofs []KZGProof) error {
+	if !c.fiatShamirBatch {
+		return kzg.BatchVerifyMultiPoints(commitments, openingProofs, c.openKey, c.batchRandomness, c.goRoutines(0))
+	}
+
+	challenge, err := c.batchChallenge(openingProofs, serCommitments, serProofs)
+	if err != nil {
+		return err
+	}
+	return kzg.BatchVerifyMultiPointsWithChallenge(commitments, openingProofs, c.openKey, challenge, c.goRoutines(0))
+}
+
+// batchChallenge returns the challenge used to fold the proofs of a batch: a hash of the batch if the context was
+// created with [WithFiatShamirBatchChallenge], and otherwise a random number read from the batch randomness.
+func (c *Context) batchChallenge(openingProofs []kzg.OpeningProof, serCommitments []KZGCommitment, serProofs []KZGProof) (fr.Element, error) {
+	if !c.fiatShamirBatch {
+		return kzg.SampleRandomNumber(c.batchRandomness)
+	}
+
+	inputPoints := make([]Scalar, len(openingProofs))
+	claimedValues := make([]Scalar, len(openingProofs))
+	for i := range openingProofs {
+		inputPoints[i] = SerializeScalar(openingProofs[i].InputPoint)
+		claimedValues[i] = SerializeScalar(openingProofs[i].ClaimedValue)
+	}
+	return computeBatchChallenge(c.preset.ScalarsPerBlob, serCommitments, inputPoints, claimedValues, serProofs), nil
 }
 
 // VerifyBlobKZGProofBatchPar implements [verify_blob_kzg_proof_batch]. This is the parallelized version of
@@ -148,22 +372,41 @@ func (c *Context) VerifyBlobKZGProofBatch(blobs []Blob, polynomialCommitments []
 // parallel. If you are worried about resource starvation on large batches, it is advised to schedule your own
 // go-routines in a more intricate way than done below for large batches.
 //
+// Like [Context.VerifyBlobKZGProofBatch], an invalid element is reported with a [*BatchVerificationError]. Since every
+// element is verified on its own, the index is that of the first element which failed for any reason.
+//
 // [verify_blob_kzg_proof_batch]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#verify_blob_kzg_proof_batch
 func (c *Context) VerifyBlobKZGProofBatchPar(blobs []Blob, commitments []KZGCommitment, proofs []KZGProof) error {
+	if err := c.checkTypes(); err != nil {
+		return err
+	}
 	// 1. Check that all components in the batch have the same size
 	if len(commitments) != len(blobs) || len(proofs) != len(blobs) {
 		return ErrBatchLengthCheck
 	}
 
+	prof := startProfile(context.Background(), "verify_blob_kzg_proof_batch_par", len(blobs))
+	defer prof.end()
+
 	// 2. Verify each opening proof using green threads
-	var errG errgroup.Group
+	var wg sync.WaitGroup
+	errs := make([]error, len(blobs))
 	for i := range blobs {
+		wg.Add(1)
 		j := i // Capture the value of the loop variable
-		errG.Go(func() error {
-			return c.VerifyBlobKZGProof(&blobs[j], commitments[j], proofs[j])
-		})
+		go func() {
+			defer wg.Done()
+			errs[j] = c.checkBlobKZGProof(context.Background(), blobs[j][:], commitments[j], proofs[j])
+		}()
 	}
 
-	// 3. Wait for all go routines to complete and check if any returned an error
-	return errG.Wait()
+	// 3. Wait for all go routines to complete and return the error with the lowest index, so
+	// that the result does not depend on the order in which the go routines finish.
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return &BatchVerificationError{Index: i, Err: err}
+		}
+	}
+	return nil
 }
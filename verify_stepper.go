@@ -0,0 +1,154 @@
+package gokzg4844
+
+import (
+	"errors"
+	"time"
+
+	"github.com/crate-crypto/go-kzg-4844/internal/kzg"
+)
+
+// VerifyStepper verifies a batch of blob proofs, as done by [Context.VerifyBlobKZGProofBatch], in steps which can be
+// interleaved with other work. This is for environments with cooperative scheduling, such as game loops or wasm
+// workers, where a long call cannot be moved to another go-routine.
+//
+// Each call to [VerifyStepper.Step] does work until the given budget is spent, and returns whether the verification
+// is done, at which point [VerifyStepper.Err] returns its result. The work is split into units which are not
+// interrupted:
+//   - deserializing one blob with its commitment and proof, and evaluating its polynomial at the challenge;
+//   - the final batch verification, which folds the proofs and checks a single pairing;
+//   - if that fails, each of the batch verifications used to find the first invalid proof.
+//
+// A step does at least one unit of work, so a step may overrun its budget by the time that a unit takes, which is a
+// few milliseconds at most for mainnet blobs. No go-routines are started by the stepper, although the multi-scalar
+// multiplications of the batch verifications may use them internally.
+//
+// A VerifyStepper is not safe for concurrent use.
+type VerifyStepper struct {
+	ctx *Context
+
+	blobs          [][]byte
+	serCommitments []KZGCommitment
+	serProofs      []KZGProof
+
+	// polynomial is reused to deserialize each blob.
+	polynomial    kzg.Polynomial
+	commitments   []kzg.Commitment
+	openingProofs []kzg.OpeningProof
+
+	// prepared is the number of blobs whose opening proofs have been computed.
+	prepared int
+	// verified is set once the whole batch has been verified, after which
+	// bisectStart and bisectEnd delimit the range that holds the first
+	// invalid proof, if the verification failed.
+	verified               bool
+	batchErr               error
+	bisectStart, bisectEnd int
+
+	done bool
+	err  error
+}
+
+// NewVerifyStepper returns a [VerifyStepper] for the verification of the blob proofs in a batch, with the same
+// arguments as [Context.VerifyBlobKZGProofBatch]. No work is done until [VerifyStepper.Step] is called.
+//
+// The blobs are not copied, so they must not be modified until the verification is done.
+//
+// Returns [ErrBatchLengthCheck] if the number of blobs, commitments and proofs differ.
+func (c *Context) NewVerifyStepper(blobs []Blob, polynomialCommitments []KZGCommitment, kzgProofs []KZGProof) (*VerifyStepper, error) {
+	if err := c.checkTypes(); err != nil {
+		return nil, err
+	}
+	if len(blobs) != len(polynomialCommitments) || len(blobs) != len(kzgProofs) {
+		return nil, ErrBatchLengthCheck
+	}
+
+	blobSlices := make([][]byte, len(blobs))
+	for i := range blobs {
+		blobSlices[i] = blobs[i][:]
+	}
+	return &VerifyStepper{
+		ctx:            c,
+		blobs:          blobSlices,
+		serCommitments: polynomialCommitments,
+		serProofs:      kzgProofs,
+		commitments:    make([]kzg.Commitment, len(blobs)),
+		openingProofs:  make([]kzg.OpeningProof, len(blobs)),
+	}, nil
+}
+
+// Step does work on the verification until budget has elapsed, and returns true once the verification is done. At
+// least one unit of work is done, even if budget is not positive. Calling Step after the verification is done does
+// nothing.
+func (s *VerifyStepper) Step(budget time.Duration) (done bool) {
+	start := time.Now()
+	for !s.done {
+		s.step()
+		if time.Since(start) >= budget {
+			break
+		}
+	}
+	return s.done
+}
+
+// Err returns the result of the verification once [VerifyStepper.Step] has returned true: nil if all of the proofs
+// are valid, and otherwise the same error as [Context.VerifyBlobKZGProofBatch]. It returns nil before then.
+func (s *VerifyStepper) Err() error {
+	return s.err
+}
+
+// step does a single unit of work.
+func (s *VerifyStepper) step() {
+	if err := s.ctx.checkOpen(); err != nil {
+		s.finish(err)
+		return
+	}
+
+	switch {
+	case s.prepared < len(s.blobs):
+		i := s.prepared
+		if s.polynomial == nil {
+			s.polynomial = make(kzg.Polynomial, s.ctx.preset.ScalarsPerBlob)
+		}
+		commitment, openingProof, err := s.ctx.blobOpeningProof(s.blobs[i], s.polynomial, s.serCommitments[i], s.serProofs[i])
+		if err != nil {
+			s.finish(&BatchVerificationError{Index: i, Err: err})
+			return
+		}
+		s.commitments[i] = commitment
+		s.openingProofs[i] = openingProof
+		s.prepared++
+
+	case !s.verified:
+		s.verified = true
+		// The polynomial is not needed anymore
+		s.polynomial = nil
+		err := s.ctx.batchVerify(s.commitments, s.openingProofs, s.serCommitments, s.serProofs)
+		if !errors.Is(err, kzg.ErrVerifyOpeningProof) {
+			s.finish(err)
+			return
+		}
+		s.batchErr = err
+		s.bisectStart, s.bisectEnd = 0, len(s.openingProofs)
+
+	case s.bisectEnd-s.bisectStart > 1:
+		// The same bisection as done by [Context.findInvalidProof]
+		start, mid := s.bisectStart, s.bisectStart+(s.bisectEnd-s.bisectStart)/2
+		if s.ctx.batchVerify(s.commitments[start:mid], s.openingProofs[start:mid], s.serCommitments[start:mid], s.serProofs[start:mid]) != nil {
+			s.bisectEnd = mid
+		} else {
+			s.bisectStart = mid
+		}
+
+	default:
+		s.finish(&BatchVerificationError{Index: s.bisectStart, Err: s.batchErr})
+	}
+}
+
+// finish ends the verification with the given result.
+func (s *VerifyStepper) finish(err error) {
+	s.done = true
+	s.err = err
+	s.commitments = nil
+	s.openingProofs = nil
+	s.polynomial = nil
+}
@@ -0,0 +1,63 @@
+package gokzg4844_test
+
+import (
+	"testing"
+	"time"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+// runStepper steps the stepper to completion with a budget of zero, so that each step does a single unit of work,
+// and returns the number of steps taken.
+func runStepper(stepper *gokzg4844.VerifyStepper) int {
+	steps := 1
+	for !stepper.Step(0) {
+		steps++
+	}
+	return steps
+}
+
+func TestVerifyStepper(t *testing.T) {
+	const batchSize = 5
+	blobs := make([]gokzg4844.Blob, batchSize)
+	commitments := make([]gokzg4844.KZGCommitment, batchSize)
+	proofs := make([]gokzg4844.KZGProof, batchSize)
+	for i := 0; i < batchSize; i++ {
+		blobs[i] = *GetRandBlob(int64(40 + i))
+		commitment, err := ctx.BlobToKZGCommitment(&blobs[i], NumGoRoutines)
+		require.NoError(t, err)
+		commitments[i] = commitment
+		proof, err := ctx.ComputeBlobKZGProof(&blobs[i], commitment, NumGoRoutines)
+		require.NoError(t, err)
+		proofs[i] = proof
+	}
+
+	// One step per blob, and one for the batch verification
+	stepper, err := ctx.NewVerifyStepper(blobs, commitments, proofs)
+	require.NoError(t, err)
+	require.Equal(t, batchSize+1, runStepper(stepper))
+	require.NoError(t, stepper.Err())
+	require.True(t, stepper.Step(0))
+
+	// An invalid proof is found by bisection
+	badProofs := append([]gokzg4844.KZGProof{}, proofs...)
+	badProofs[3] = proofs[0]
+	stepper, err = ctx.NewVerifyStepper(blobs, commitments, badProofs)
+	require.NoError(t, err)
+	require.Greater(t, runStepper(stepper), batchSize+1)
+	var batchErr *gokzg4844.BatchVerificationError
+	require.ErrorAs(t, stepper.Err(), &batchErr)
+	require.Equal(t, 3, batchErr.Index)
+	require.ErrorIs(t, stepper.Err(), gokzg4844.ErrProofInvalid)
+	require.Equal(t, ctx.VerifyBlobKZGProofBatch(blobs, commitments, badProofs), stepper.Err())
+
+	// A large budget finishes in a single step
+	stepper, err = ctx.NewVerifyStepper(blobs, commitments, proofs)
+	require.NoError(t, err)
+	require.True(t, stepper.Step(time.Minute))
+	require.NoError(t, stepper.Err())
+
+	_, err = ctx.NewVerifyStepper(blobs, commitments[1:], proofs)
+	require.ErrorIs(t, err, gokzg4844.ErrBatchLengthCheck)
+}
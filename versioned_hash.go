@@ -0,0 +1,47 @@
+package gokzg4844
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+)
+
+// VersionedHashVersionKZG is the version byte of versioned hashes of KZG commitments.
+//
+// It matches [VERSIONED_HASH_VERSION_KZG] in the spec.
+//
+// [VERSIONED_HASH_VERSION_KZG]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/beacon-chain.md#blob
+const VersionedHashVersionKZG = 0x01
+
+// VersionedHash is the hash of a commitment which blob transactions and the point evaluation precompile refer to
+// blobs by. The first byte is the version of the hash, and the rest are the last 31 bytes of the SHA-256 hash of the
+// commitment.
+type VersionedHash [32]byte
+
+// KZGToVersionedHash implements [kzg_commitment_to_versioned_hash].
+//
+// [kzg_commitment_to_versioned_hash]: https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/beacon-chain.md#kzg_commitment_to_versioned_hash
+func KZGToVersionedHash(commitment KZGCommitment) VersionedHash {
+	versionedHash := sha256.Sum256(commitment[:])
+	versionedHash[0] = VersionedHashVersionKZG
+	return versionedHash
+}
+
+// Version returns the version byte of the hash.
+func (h VersionedHash) Version() byte {
+	return h[0]
+}
+
+// Validate returns [ErrInvalidVersionedHash] if the hash does not have the version of the hash of a KZG commitment,
+// and nil otherwise. This is the check that the execution layer does on the versioned hashes of a blob transaction.
+func (h VersionedHash) Validate() error {
+	if h.Version() != VersionedHashVersionKZG {
+		return ErrInvalidVersionedHash
+	}
+	return nil
+}
+
+// Matches returns whether h is the versioned hash of the commitment. The hashes are compared in constant time.
+func (h VersionedHash) Matches(commitment KZGCommitment) bool {
+	expected := KZGToVersionedHash(commitment)
+	return subtle.ConstantTimeCompare(h[:], expected[:]) == 1
+}
@@ -0,0 +1,39 @@
+package gokzg4844_test
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKZGToVersionedHash(t *testing.T) {
+	commitment, err := ctx.BlobToKZGCommitment(GetRandBlob(1), NumGoRoutines)
+	require.NoError(t, err)
+
+	expected := sha256.Sum256(commitment[:])
+	expected[0] = 0x01
+	versionedHash := gokzg4844.KZGToVersionedHash(commitment)
+	require.Equal(t, gokzg4844.VersionedHash(expected), versionedHash)
+	require.Equal(t, byte(gokzg4844.VersionedHashVersionKZG), versionedHash.Version())
+	require.NoError(t, versionedHash.Validate())
+	require.True(t, versionedHash.Matches(commitment))
+
+	otherCommitment, err := ctx.BlobToKZGCommitment(GetRandBlob(2), NumGoRoutines)
+	require.NoError(t, err)
+	require.False(t, versionedHash.Matches(otherCommitment))
+
+	// The hash with the wrong version is invalid, and does not match the commitment
+	wrongVersion := versionedHash
+	wrongVersion[0] = 0x02
+	require.ErrorIs(t, wrongVersion.Validate(), gokzg4844.ErrInvalidVersionedHash)
+	require.False(t, wrongVersion.Matches(commitment))
+
+	encoded, err := json.Marshal(versionedHash)
+	require.NoError(t, err)
+	var decoded gokzg4844.VersionedHash
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, versionedHash, decoded)
+}